@@ -12,6 +12,7 @@ import (
 
 	"github.com/hibiken/asynq"
 	apiHttp "github.com/vibe-gaming/backend/internal/api/http"
+	"github.com/vibe-gaming/backend/internal/authstate"
 	"github.com/vibe-gaming/backend/internal/cache"
 	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/db"
@@ -29,6 +30,8 @@ import (
 	"github.com/vibe-gaming/backend/pkg/hash"
 	logger "github.com/vibe-gaming/backend/pkg/logger"
 	"github.com/vibe-gaming/backend/pkg/otp"
+	mock_sms "github.com/vibe-gaming/backend/pkg/sms/mock"
+	"github.com/vibe-gaming/backend/pkg/storage/local"
 	"go.uber.org/zap"
 )
 
@@ -77,7 +80,8 @@ func main() {
 	}()
 	logger.Info("redis connection done")
 
-	hasher := hash.NewSHA1Hasher(cfg.Auth.PasswordSalt)
+	// Пароли сотрудников хешируются argon2id, старые SHA256 хеши перехешируются при входе
+	hasher := hash.NewArgon2idHasher(hash.DefaultArgon2Params, cfg.Auth.PasswordSalt)
 
 	emailSender, err := smtp.NewSMTPSender(cfg.SMTP.From, cfg.SMTP.Pass, cfg.SMTP.Host, cfg.SMTP.Port)
 	if err != nil {
@@ -85,38 +89,92 @@ func main() {
 		return
 	}
 
-	tokenManager, err := auth.NewManager(cfg.Auth.JWT)
+	// SMS провайдер пока не подключен: коды подтверждения телефона пишутся в лог
+	if cfg.SMS.Provider != "mock" {
+		logger.Error("unsupported sms provider", zap.String("provider", cfg.SMS.Provider))
+		return
+	}
+	smsSender := mock_sms.NewLogSender()
+
+	// Хранилище ключей нужно только асимметричной подписи, для HS256 ключ шифрования не требуется
+	var keyStore auth.KeyStore
+	if cfg.Auth.JWT.SigningMethod != auth.SigningMethodHS256 {
+		keyStore, err = auth.NewRedisKeyStore(redis, cfg.Auth.JWT.KeyEncryptionKey)
+		if err != nil {
+			logger.Error("jwt key store creation err", zap.Error(err))
+			return
+		}
+	}
+
+	tokenManager, err := auth.NewManager(cfg.Auth.JWT, keyStore)
 	if err != nil {
 		logger.Error("auth manager creation err", zap.Error(err))
 		return
 	}
 
+	// Ротация ключей подписи access токенов (только для RS256/EdDSA)
+	keyRotationCtx, stopKeyRotation := context.WithCancel(context.Background())
+	defer stopKeyRotation()
+	go tokenManager.RunKeyRotation(keyRotationCtx, func(err error) {
+		logger.Error("jwt signing keys rotation failed", zap.Error(err))
+	})
+
 	otpGenerator := otp.NewGOTPGenerator()
 
 	esiaClient := esia.NewClient(cfg.ESIA)
+	if cfg.ESIA.InsecureSkipVerify && cfg.Env != "local" {
+		logger.Error("ESIA_INSECURE_SKIP_ID_TOKEN_VERIFY is allowed only with ENV=local", zap.String("env", cfg.Env))
+		return
+	}
+	idTokenVerifier, err := esia.NewIDTokenVerifier(cfg.ESIA)
+	if err != nil {
+		logger.Error("esia id_token verifier creation failed", zap.Error(err))
+		return
+	}
+	if cfg.ESIA.InsecureSkipVerify && cfg.ESIA.JWKSURL == "" && cfg.ESIA.CertsPath == "" {
+		logger.Warn("esia id_token signature verification is disabled")
+	}
+	esiaClient.SetIDTokenVerifier(idTokenVerifier)
 	socialGroupCheckerClient := socialgroupchecker.NewClient(cfg.SocialGroupChecker.BaseURL)
 	gigachatClient := gigachat.NewClient(cfg.Gigachat.ClientAuthorizationKey)
 	gigachatClient.SetClientID(cfg.Gigachat.ClientID)
 
+	// Загруженные пользователями файлы
+	if cfg.Storage.Type != "local" {
+		logger.Error("unsupported storage type", zap.String("type", cfg.Storage.Type))
+		return
+	}
+	fileStorage, err := local.NewStorage(cfg.Storage.LocalPath)
+	if err != nil {
+		logger.Error("file storage init problem", zap.Error(err))
+		return
+	}
+
 	// Services, Repos & API Handlers
 	repos := repository.NewRepositories(dbMySQL)
 	services := service.NewServices(service.Deps{
 		Config:         cfg,
 		Hasher:         hasher,
 		TokenManager:   tokenManager,
+		TokenDenylist:  auth.NewRedisDenylist(redis, cfg.Auth.JWT.AccessTokenTTL),
 		OtpGenerator:   otpGenerator,
 		Repos:          repos,
+		Redis:          redis,
 		EsiaClient:     esiaClient,
 		GigachatClient: gigachatClient,
+		Storage:        fileStorage,
 	})
 	workers := worker.NewWorkers(worker.Deps{
 		Redis:                    redis,
 		Services:                 services,
 		EmailProvider:            emailSender,
+		SMSProvider:              smsSender,
 		Config:                   cfg,
 		SocialGroupCheckerClient: socialGroupCheckerClient,
 	})
-	handlers := apiHttp.NewHandlers(services, tokenManager, cfg, esiaClient, gigachatClient)
+	// OAuth state и authorization code храним в Redis, чтобы авторизация работала при нескольких репликах
+	authStateStore := authstate.NewRedisStore(redis, authstate.DefaultStateTTL, authstate.DefaultCodeTTL)
+	handlers := apiHttp.NewHandlers(services, tokenManager, cfg, esiaClient, gigachatClient, authStateStore)
 
 	// HTTP Server
 	srv := server.NewServer(cfg, handlers.Init(cfg))
@@ -144,6 +202,18 @@ func main() {
 
 	logger.Info("asynq server started")
 
+	// Периодические задачи: истечение подтверждения социальных групп, повторные проверки и публикация льгот по расписанию
+	asynqScheduler, err := asynqserver.NewScheduler(cfg.Cache, cfg.SocialGroupChecker, cfg.Benefits)
+	if err != nil {
+		logger.Fatal("asynq: create scheduler failed", zap.Error(err))
+	}
+	if err = asynqScheduler.Start(); err != nil {
+		logger.Fatal("asynq: start scheduler failed", zap.Error(err))
+	}
+	defer asynqScheduler.Shutdown()
+
+	logger.Info("asynq scheduler started")
+
 	logger.Info("app started")
 
 	// Graceful Shutdown
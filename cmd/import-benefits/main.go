@@ -0,0 +1,97 @@
+// import-benefits загружает льготы из CSV, XLSX или JSON так же, как POST /api/v1/admin/benefits/import.
+// Подключение к БД берется из переменных окружения приложения, отчет по строкам печатается в stdout в JSON.
+// Код выхода 1 - файл не загружен, 2 - часть строк пропущена из-за ошибок
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/db"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/internal/service"
+	logger "github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	filePath := flag.String("file", "", "файл импорта")
+	format := flag.String("format", "", "csv, xlsx или json, по умолчанию по расширению файла")
+	dryRun := flag.Bool("dry-run", false, "только проверить файл и напечатать отчет")
+	organization := flag.String("organization-id", "", "UUID организации: льготы без организации получают ее, льготы других организаций отклоняются")
+	timeout := flag.Duration("timeout", 5*time.Minute, "максимальное время импорта")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+	logger.Init(cfg.LogLevel)
+
+	if *filePath == "" {
+		logger.Error("file is required, use -file")
+		return 1
+	}
+
+	importFormat := service.BenefitImportFormat(*format)
+	if importFormat == "" {
+		importFormat = service.BenefitImportFormatFromFileName(*filePath)
+	}
+
+	options := service.BenefitImportOptions{DryRun: *dryRun}
+	if *organization != "" {
+		id, err := uuid.Parse(*organization)
+		if err != nil {
+			logger.Error("invalid organization id", zap.Error(err))
+			return 1
+		}
+		options.OrganizationID = &id
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		logger.Error("open import file problem", zap.Error(err))
+		return 1
+	}
+	defer file.Close()
+
+	rows, err := service.ParseBenefitImport(importFormat, file)
+	if err != nil {
+		logger.Error("parse import file problem", zap.Error(err))
+		return 1
+	}
+
+	dbMySQL, err := db.New(cfg.Database)
+	if err != nil {
+		logger.Error("mysql connect problem", zap.Error(err))
+		return 1
+	}
+	defer dbMySQL.Close() //nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report, err := service.NewBenefitImportService(repository.NewRepositories(dbMySQL)).Import(ctx, rows, options)
+	if err != nil {
+		logger.Error("import benefits problem", zap.Error(err))
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Error("print import report problem", zap.Error(err))
+		return 1
+	}
+
+	if report.Failed > 0 {
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,125 @@
+// standin запускает локальную замену ЕСИА и сервиса проверки социальных групп.
+// Приложение направляется на стенд через ESIA_BASE_URL, ESIA_JWKS_URL и SOCIAL_GROUP_CHECKER_BASE_URL
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vibe-gaming/backend/internal/standin"
+	logger "github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	addr := flag.String("addr", ":8085", "адрес, на котором слушает стенд")
+	fixturePath := flag.String("fixtures", "", "JSON файл с гражданами и сбоями, по умолчанию встроенный набор")
+	issuer := flag.String("issuer", "", "iss в id_token, по умолчанию адрес запроса")
+	clientID := flag.String("client-id", "", "ожидаемый client_id, по умолчанию любой")
+	keyPath := flag.String("key", "", "PEM файл с RSA ключом подписи id_token, по умолчанию генерируется при запуске")
+	logLevel := flag.String("log-level", "info", "уровень логирования")
+	flag.Parse()
+
+	logger.Init(*logLevel)
+
+	fixture := standin.DefaultFixture()
+	if *fixturePath != "" {
+		var err error
+		fixture, err = standin.LoadFixture(*fixturePath)
+		if err != nil {
+			logger.Error("load fixture problem", zap.Error(err))
+			os.Exit(1)
+		}
+	}
+
+	cfg := standin.Config{
+		Issuer:   *issuer,
+		ClientID: *clientID,
+	}
+	if *keyPath != "" {
+		key, err := loadRSAKey(*keyPath)
+		if err != nil {
+			logger.Error("load signing key problem", zap.Error(err))
+			os.Exit(1)
+		}
+		cfg.SigningKey = key
+	}
+
+	srv, err := standin.New(fixture, cfg)
+	if err != nil {
+		logger.Error("init standin problem", zap.Error(err))
+		os.Exit(1)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           logRequests(srv),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Info("standin started", zap.String("addr", *addr), zap.Int("citizens", len(fixture.Citizens)))
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("standin server problem", zap.Error(err))
+			os.Exit(1)
+		}
+	}()
+
+	// Graceful Shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
+
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.Error("standin shutdown problem", zap.Error(err))
+	}
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("standin request", zap.String("method", r.Method), zap.String("path", r.URL.Path))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func loadRSAKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is not RSA")
+	}
+
+	return key, nil
+}
@@ -15,14 +15,14 @@ const docTemplateinternal = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/benefits": {
+        "/admin/benefits": {
             "get": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Получить список всех льгот с пагинацией, фильтрацией и умным поиском\n\nПоиск использует Full-Text Search MySQL с автоматическим поиском по частичному совпадению:\n- Поиск \"транс\" найдет \"транспорт\", \"транспортный\" и т.д.\n- Поиск \"пенсион\" найдет \"пенсионер\", \"пенсионный\" и т.д.\n- Каждое слово ищется с начала (prefix matching)\n\nМожно использовать Boolean операторы для сложных запросов:\n+ обязательное слово: \"+пенсионер +транспорт\"\n- исключить слово: \"льгота -студент\"\n* явный wildcard: \"транс*\"\n\"\" точная фраза: \"общественный транспорт\"",
+                "description": "Льготы в любом статусе публикации, например очередь на проверку (status=in_review).\nМенеджер организации должен указать organization_id своей организации",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,104 +30,32 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Get Benefits List",
+                "summary": "Admin Benefits",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы (по умолчанию 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество элементов на странице (по умолчанию 10, максимум 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID региона для фильтрации",
-                        "name": "region",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "UUID города для фильтрации",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Типы льгот через запятую (federal, regional, commercial) - OR логика",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Целевые группы через запятую (pensioners, disabled, students и т.д.)",
-                        "name": "target_groups",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Теги через запятую (most_popular, new, hot, best, recommended, popular, top)",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Категории через запятую (medicine, transport, food, clothing, other)",
-                        "name": "categories",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата начала периода (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата окончания периода (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Поисковый запрос (автоматически ищет по частичному совпадению)",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (created_at, views, updated_at) - по умолчанию created_at",
-                        "name": "sort_by",
+                        "description": "Статусы через запятую (draft, in_review, published, archived), по умолчанию все",
+                        "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Направление сортировки (asc, desc) - по умолчанию desc",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Показать только избранные льготы (работает только при авторизации, иначе игнорируется)",
-                        "name": "favorites",
+                        "description": "UUID организации",
+                        "name": "organization_id",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Фильтровать льготы по группам пользователя (работает только при авторизации)",
-                        "name": "filter_by_user_groups",
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Формат ответа (json или pdf) - по умолчанию json",
-                        "name": "format",
+                        "type": "integer",
+                        "description": "Количество на странице (до 100)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -135,7 +63,7 @@ const docTemplateinternal = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/v1.benefitsListResponse"
                         }
                     },
                     "400": {
@@ -144,131 +72,63 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Создать новую льготу",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Benefits"
-                ],
-                "summary": "Create Benefit",
-                "parameters": [
-                    {
-                        "description": "Данные льготы",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.createBenefitRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/v1.createBenefitResponse"
-                        }
+                    "401": {
+                        "description": "Unauthorized"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/benefits/stats": {
-            "get": {
-                "description": "Получить статистику по фильтрам - количество льгот по категориям и уровням\n\nПоддерживает те же параметры фильтрации что и GET /benefits (кроме category, так как мы его считаем)\nТипы можно указать для фильтрации статистики по конкретным типам льгот\nЭто позволяет показывать актуальные счетчики в форме фильтров при изменении других параметров",
+        "/admin/benefits/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Загрузить льготы из CSV, XLSX или JSON. Поля - как при создании льготы, плюс обязательный external_id\nи названия city и organization вместо city_id и organization_id. В CSV и XLSX первая строка - названия колонок,\nсписки target_groups, region и tags перечисляются через \";\", eligibility_rules - JSON объект. JSON - массив объектов.\nЛьгота с уже загруженным external_id обновляется (без права публикации опубликованная льгота возвращается на проверку),\nновая создается черновиком. Строки с ошибками пропускаются,\nостальные сохраняются одной транзакцией. С dry_run=true файл только проверяется.\nМенеджер организации должен указать organization_id своей организации: льготы без организации получают ее",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Get Benefits Filter Statistics",
+                "summary": "Import Benefits",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID региона для фильтрации",
-                        "name": "region",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "UUID города для фильтрации",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Типы льгот через запятую (federal, regional, commercial) - OR логика",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Целевые группы через запятую",
-                        "name": "target_groups",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Теги через запятую",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата начала периода (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата окончания периода (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
+                        "type": "file",
+                        "description": "Файл импорта",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "search",
-                        "in": "query"
+                        "description": "csv, xlsx или json, по умолчанию по расширению файла",
+                        "name": "format",
+                        "in": "formData"
                     },
                     {
                         "type": "boolean",
-                        "description": "Учитывать только избранные льготы (работает только при авторизации)",
-                        "name": "favorites",
+                        "description": "Только проверить файл и вернуть отчет",
+                        "name": "dry_run",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Фильтровать льготы по группам пользователя (работает только при авторизации)",
-                        "name": "filter_by_user_groups",
+                        "type": "string",
+                        "description": "UUID организации",
+                        "name": "organization_id",
                         "in": "query"
                     }
                 ],
@@ -276,66 +136,38 @@ const docTemplateinternal = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "object",
-                                "additionalProperties": {
-                                    "type": "integer"
-                                }
-                            }
+                            "$ref": "#/definitions/service.BenefitImportReport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
-                    }
-                }
-            }
-        },
-        "/benefits/user-stats": {
-            "get": {
-                "security": [
-                    {
-                        "UserAuth": []
-                    }
-                ],
-                "description": "Получить статистику по льготам пользователя",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Benefits"
-                ],
-                "summary": "Get User Benefits Stats",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/repository.UserBenefitsStats"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/benefits/{id}": {
+        "/admin/benefits/{id}/revisions": {
             "get": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Получить льготу по ID",
+                "description": "История изменений льготы, новые версии первыми. Версия хранит все редактируемые поля льготы после изменения\nи автора: сотрудника (author_user_id) или API ключ партнера (author_api_key_id).\nДействие: create, update, delete (последнее состояние перед удалением), restore (restored_from - номер восстановленной версии).\nИстория доступна и для удаленных льгот. Изменения до появления истории не сохранены",
                 "consumes": [
                     "application/json"
                 ],
@@ -343,13 +175,13 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Get Benefit By ID",
+                "summary": "Get Benefit Revisions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Benefit ID",
+                        "description": "Benefit ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -359,7 +191,10 @@ const docTemplateinternal = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.benefitResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.BenefitRevision"
+                            }
                         }
                     },
                     "400": {
@@ -368,22 +203,35 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
-            },
-            "put": {
-                "description": "Обновить существующую льготу",
+            }
+        },
+        "/admin/benefits/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Поля, которые отличаются в версиях from и to, со значениями в обеих версиях.\nПорядок версий не важен: from может быть новее to",
                 "consumes": [
                     "application/json"
                 ],
@@ -391,9 +239,9 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Update Benefit",
+                "summary": "Diff Benefit Revisions",
                 "parameters": [
                     {
                         "type": "string",
@@ -403,20 +251,25 @@ const docTemplateinternal = `{
                         "required": true
                     },
                     {
-                        "description": "Данные льготы",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.createBenefitRequest"
-                        }
+                        "type": "integer",
+                        "description": "Номер первой версии",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер второй версии",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.createBenefitResponse"
+                            "$ref": "#/definitions/v1.benefitRevisionDiffResponse"
                         }
                     },
                     "400": {
@@ -425,22 +278,35 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
-                "description": "Удалить льготу (soft delete)",
+            }
+        },
+        "/admin/benefits/{id}/revisions/{version}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Вернуть льготе поля из выбранной версии. Удаленная льгота восстанавливается.\nВосстановление сохраняется новой версией с action=restore, прежние версии не изменяются.\nМенеджер организации может восстановить только версию, в которой льгота принадлежит его организации.\nБез права публикации опубликованная льгота после восстановления возвращается на проверку",
                 "consumes": [
                     "application/json"
                 ],
@@ -448,9 +314,9 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Delete Benefit",
+                "summary": "Restore Benefit Revision",
                 "parameters": [
                     {
                         "type": "string",
@@ -458,16 +324,20 @@ const docTemplateinternal = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.BenefitRevision"
                         }
                     },
                     "400": {
@@ -476,29 +346,35 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/benefits/{id}/favorite": {
+        "/admin/staff": {
             "post": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Переключить статус избранной льготы (toggle). Если льгота в избранном - удалит из избранного, если нет - добавит в избранное",
+                "description": "Создание учетной записи сотрудника для входа по логину и паролю.\nРоли выдаются отдельно через /admin/users/{id}/roles",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,21 +382,26 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Benefits"
+                    "Admin"
                 ],
-                "summary": "Toggle Benefit Favorite Status",
+                "summary": "Create Staff Account",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Benefit ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные сотрудника",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createStaffRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createStaffResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -529,63 +410,50 @@ const docTemplateinternal = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/benefits/{id}/pdfdownload": {
+        "/admin/stats": {
             "get": {
-                "description": "Скачать льготу в формате PDF",
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Статистика по пользователям, льготам, городам и избранному",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "Benefits"
-                ],
-                "summary": "Get Benefit PDF Download",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Benefit ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "Admin Stats",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
+                            "$ref": "#/definitions/v1.adminStatsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                    "401": {
+                        "description": "Unauthorized"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
@@ -593,9 +461,14 @@ const docTemplateinternal = `{
                 }
             }
         },
-        "/cities": {
-            "get": {
-                "description": "Get all cities",
+        "/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удаление пользователя. Все сессии завершаются, выданные access токены сразу перестают приниматься",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,18 +476,21 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cities"
+                    "Admin"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Cities",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.City"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -622,18 +498,29 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/organizations": {
-            "get": {
-                "description": "Get all organizations, optionally filtered by city_id",
+        "/admin/users/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Снятие блокировки пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -641,26 +528,21 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Admin"
                 ],
-                "summary": "Get Organizations",
+                "summary": "Activate User",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "City ID (UUID) to filter organizations",
-                        "name": "city_id",
-                        "in": "query"
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Organization"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -668,16 +550,29 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/deactivate": {
             "post": {
-                "description": "Создать новую организацию",
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Блокировка пользователя. Все сессии завершаются, выданные access токены сразу перестают приниматься",
                 "consumes": [
                     "application/json"
                 ],
@@ -685,26 +580,21 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Admin"
                 ],
-                "summary": "Create Organization",
+                "summary": "Deactivate User",
                 "parameters": [
                     {
-                        "description": "Данные организации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.createOrganizationRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/v1.createOrganizationResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -712,18 +602,29 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/organizations/{id}": {
+        "/admin/users/{id}/group-history": {
             "get": {
-                "description": "Получить организацию по ID",
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "История статусов социальных групп пользователя с ID запросов к сервису проверки, его ответами и решениями модераторов",
                 "consumes": [
                     "application/json"
                 ],
@@ -731,13 +632,13 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Admin"
                 ],
-                "summary": "Get Organization By ID",
+                "summary": "Get User Group History For Support",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID (UUID)",
+                        "description": "User ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -747,7 +648,10 @@ const docTemplateinternal = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Organization"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.adminUserGroupEventResponse"
+                            }
                         }
                     },
                     "400": {
@@ -756,22 +660,29 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
-            },
-            "put": {
-                "description": "Обновить существующую организацию",
+            }
+        },
+        "/admin/users/{id}/profile-changes": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Журнал изменений профиля пользователя: данные ЕСИА при входе и смена email или телефона по коду, новые записи первыми",
                 "consumes": [
                     "application/json"
                 ],
@@ -779,32 +690,26 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Admin"
                 ],
-                "summary": "Update Organization",
+                "summary": "Get User Profile Changes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID (UUID)",
+                        "description": "User ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные организации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.createOrganizationRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.createOrganizationResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.userProfileChangeResponse"
+                            }
                         }
                     },
                     "400": {
@@ -813,22 +718,29 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
-                "description": "Удалить организацию (soft delete)",
+            }
+        },
+        "/admin/users/{id}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Роли пользователя. Роль citizen есть у всех пользователей и не возвращается",
                 "consumes": [
                     "application/json"
                 ],
@@ -836,13 +748,13 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Admin"
                 ],
-                "summary": "Delete Organization",
+                "summary": "Get User Roles",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID (UUID)",
+                        "description": "User ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -852,10 +764,7 @@ const docTemplateinternal = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/v1.getUserRolesResponse"
                         }
                     },
                     "400": {
@@ -864,91 +773,88 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorStruct"
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/speech/recognize": {
+            },
             "post": {
                 "security": [
                     {
-                        "UserAuth": []
-                    },
-                    {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Распознает речь из аудиофайла и возвращает текст",
+                "description": "Выдать роль пользователю. Для organization_manager обязателен organization_id.\nРоль попадает в access токен пользователя при следующем обновлении токенов",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Speech"
+                    "Admin"
                 ],
-                "summary": "Распознавание речи",
+                "summary": "Grant User Role",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Аудиофайл (MP3 или WebM)",
-                        "name": "audio",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Роль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.grantUserRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Распознанный текст",
-                        "schema": {
-                            "$ref": "#/definitions/v1.speechResponse"
-                        }
+                    "201": {
+                        "description": "Created"
                     },
                     "400": {
-                        "description": "Ошибка валидации",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/ErrorStruct"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/auth/callback": {
-            "get": {
-                "description": "Callback endpoint для Auth - получает code от ESIA и редиректит на фронтенд",
+        "/admin/users/{id}/roles/{role}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Отозвать роль пользователя. Для organization_manager указывается organization_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -956,61 +862,64 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "OAuth Callback",
+                "summary": "Revoke User Role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
-                        "in": "query",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "State parameter",
-                        "name": "state",
-                        "in": "query",
+                        "description": "Роль",
+                        "name": "role",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "organization_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Redirect to frontend with code"
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/auth/login": {
+        "/admin/verification-documents": {
             "get": {
-                "description": "Перенаправление на ESIA для авторизации",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "OAuth Login",
-                "responses": {
-                    "302": {
-                        "description": "Found"
+                "security": [
+                    {
+                        "AdminAuth": []
                     }
-                }
-            }
-        },
-        "/users/auth/token": {
-            "post": {
-                "description": "Обмен authorization code на access и refresh токены (как в Keycloak)",
+                ],
+                "description": "Очередь документов на проверку, старые первыми. По умолчанию возвращаются документы, ожидающие решения.\nУ документов о родстве в member - член семьи для сверки, snils_collisions \u003e 0 означает, что тот же СНИЛС\nдобавлен членом семьи на других аккаунтах",
                 "consumes": [
                     "application/json"
                 ],
@@ -1018,25 +927,34 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Exchange Code for Tokens",
+                "summary": "Get Verification Documents Queue",
                 "parameters": [
                     {
-                        "description": "Code и State",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.exchangeTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Статус: pending (по умолчанию), approved, rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество на странице (до 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.exchangeTokenResponse"
+                            "$ref": "#/definitions/v1.verificationDocumentsQueueResponse"
                         }
                     },
                     "400": {
@@ -1044,42 +962,52 @@ const docTemplateinternal = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/pdfdownload": {
-            "get": {
+        "/admin/verification-documents/{id}/approve": {
+            "post": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Скачать удостоверение/справку социальной группы в формате PDF. По умолчанию генерируется удостоверение пенсионера.\n\nДоступные типы групп:\n- pensioners (пенсионеры) - по умолчанию\n- disabled (инвалиды)\n- students (студенты)\n- young_families (молодые семьи)\n- large_families (многодетные семьи)\n- low_income (малоимущие)\n- children (дети)\n- veterans (ветераны)\n\nЕсли у пользователя нет реальных данных для указанной группы, будут использованы моковые данные.",
+                "description": "Одобрить документ. Группа пользователя становится подтвержденной на тот же срок, что и при проверке по СНИЛС.\nДокумент о родстве подтверждает родство с членом семьи, если тот же СНИЛС не подтвержден на допустимом числе других аккаунтов",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Get User Certificate PDF",
+                "summary": "Approve Verification Document",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Тип социальной группы (по умолчанию: pensioners)",
-                        "name": "group_type",
-                        "in": "query"
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "PDF файл удостоверения/справки",
-                        "schema": {
-                            "type": "file"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1087,36 +1015,54 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/pong": {
+        "/admin/verification-documents/{id}/file": {
             "get": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Pong",
+                "description": "Скачать документ пользователя для проверки",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Pong"
+                    "Admin"
+                ],
+                "summary": "Get Verification Document File For Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Pong",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1124,20 +1070,90 @@ const docTemplateinternal = `{
                             "$ref": "#/definitions/ErrorStruct"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/profile": {
+        "/admin/verification-documents/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Отклонить документ с указанием причины. Причина показывается пользователю в статусе группы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reject Verification Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отказа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.rejectVerificationDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/benefits": {
             "get": {
                 "security": [
                     {
                         "UserAuth": []
                     }
                 ],
-                "description": "Get user profile",
+                "description": "Получить список всех льгот с пагинацией, фильтрацией и умным поиском\n\nПоиск использует Full-Text Search MySQL с автоматическим поиском по частичному совпадению:\n- Поиск \"транс\" найдет \"транспорт\", \"транспортный\" и т.д.\n- Поиск \"пенсион\" найдет \"пенсионер\", \"пенсионный\" и т.д.\n- Каждое слово ищется с начала (prefix matching)\n\nМожно использовать Boolean операторы для сложных запросов:\n+ обязательное слово: \"+пенсионер +транспорт\"\n- исключить слово: \"льгота -студент\"\n* явный wildcard: \"транс*\"\n\"\" точная фраза: \"общественный транспорт\"",
                 "consumes": [
                     "application/json"
                 ],
@@ -1145,14 +1161,112 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Benefits"
+                ],
+                "summary": "Get Benefits List",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице (по умолчанию 10, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID региона для фильтрации",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID города для фильтрации",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Типы льгот через запятую (federal, regional, commercial) - OR логика",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Целевые группы через запятую (pensioners, disabled, students и т.д.)",
+                        "name": "target_groups",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую (most_popular, new, hot, best, recommended, popular, top)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Категории через запятую (medicine, transport, food, clothing, other)",
+                        "name": "categories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала периода (YYYY-MM-DD). Без date_from и date_to выбираются льготы, действующие сегодня",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания периода (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос (автоматически ищет по частичному совпадению)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (created_at, views, updated_at) - по умолчанию created_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Направление сортировки (asc, desc) - по умолчанию desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Показать только избранные льготы (работает только при авторизации, иначе игнорируется)",
+                        "name": "favorites",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Фильтровать льготы по подтвержденным группам пользователя и членов его семьи (работает только при авторизации). У каждой льготы заполняется qualifying_members",
+                        "name": "filter_by_user_groups",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат ответа (json или pdf) - по умолчанию json",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.getProfileResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1168,16 +1282,14 @@ const docTemplateinternal = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/update-info": {
+            },
             "post": {
                 "security": [
                     {
-                        "UserAuth": []
+                        "AdminAuth": []
                     }
                 ],
-                "description": "Register user",
+                "description": "Создать новую льготу. Льгота создается черновиком и не видна гражданам до публикации через POST /benefits/{id}/status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1185,36 +1297,59 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Benefits"
                 ],
-                "summary": "User Update Info",
+                "summary": "Create Benefit",
                 "parameters": [
                     {
-                        "description": "User update info request",
+                        "description": "Данные льготы",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.userUpdateInfoRequest"
+                            "$ref": "#/definitions/v1.createBenefitRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}/add-mock-documents": {
-            "post": {
-                "description": "Add mock documents to user",
+        "/benefits/eligibility": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Проверить право пользователя на льготы из списка. Для каждой льготы возвращается status:\neligible - все условия выполнены, not_eligible - хотя бы одно условие не выполнено,\nmissing_data - в профиле не хватает данных для проверки. В checks - результат и пояснение по каждому условию",
                 "consumes": [
                     "application/json"
                 ],
@@ -1222,138 +1357,5503 @@ const docTemplateinternal = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Benefits"
                 ],
-                "summary": "Add Mock Documents",
+                "summary": "Get Benefits Eligibility",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице (по умолчанию 10, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitsEligibilityResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ErrorStruct"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "ErrorStruct": {
-            "type": "object",
-            "properties": {
-                "error_code": {
-                    "type": "integer"
-                },
-                "error_message": {
-                    "type": "string"
-                }
-            }
         },
-        "domain.City": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "region_id": {
-                    "type": "string"
-                },
+        "/benefits/stats": {
+            "get": {
+                "description": "Получить статистику по фильтрам - количество льгот по категориям и уровням\n\nПоддерживает те же параметры фильтрации что и GET /benefits (кроме category, так как мы его считаем)\nТипы можно указать для фильтрации статистики по конкретным типам льгот\nЭто позволяет показывать актуальные счетчики в форме фильтров при изменении других параметров",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Get Benefits Filter Statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID региона для фильтрации",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID города для фильтрации",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Типы льгот через запятую (federal, regional, commercial) - OR логика",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Целевые группы через запятую",
+                        "name": "target_groups",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала периода (YYYY-MM-DD). Без date_from и date_to выбираются льготы, действующие сегодня",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания периода (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Учитывать только избранные льготы (работает только при авторизации)",
+                        "name": "favorites",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Фильтровать льготы по группам пользователя (работает только при авторизации)",
+                        "name": "filter_by_user_groups",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefits/user-stats": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Получить статистику по льготам пользователя. total_benefits учитывает подтвержденные группы всей семьи,\nmembers показывает, сколько льгот открывают группы каждого члена семьи",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Get User Benefits Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.UserBenefitsStats"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefits/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Получить льготу по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Get Benefit By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Обновить существующую льготу. Без права публикации опубликованная льгота возвращается на проверку (in_review)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Update Benefit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные льготы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удалить льготу (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Delete Benefit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefits/{id}/eligibility": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Проверить право пользователя на льготу: целевые группы семьи, возраст, место проживания, доход, количество детей, инвалидность.\nВозраст и инвалидность проверяются у того члена семьи, чья группа дает право на льготу, доход и количество детей - по профилю пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Get Benefit Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EligibilityResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/benefits/{id}/favorite": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Переключить статус избранной льготы (toggle). Если льгота в избранном - удалит из избранного, если нет - добавит в избранное",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Toggle Benefit Favorite Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefits/{id}/pdfdownload": {
+            "get": {
+                "description": "Скачать льготу в формате PDF",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Get Benefit PDF Download",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefits/{id}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Карточка льготы в любом статусе публикации, в том числе черновика. Просмотр не увеличивает счетчик просмотров",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Preview Benefit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/benefits/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Перевести льготу в другой статус публикации. Переходы: draft -\u003e in_review, in_review -\u003e draft,\nin_review -\u003e published, published -\u003e archived, archived -\u003e draft, archived -\u003e published.\nПубликовать и задавать publish_at/unpublish_at могут только роли с правом публикации (content_editor, administrator).\nЕсли при публикации publish_at в будущем, льгота остается на проверке и публикуется планировщиком.\nПовторная публикация опубликованной льготы меняет только unpublish_at. Правка льготы на проверке без права публикации отменяет запланированную публикацию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Benefits"
+                ],
+                "summary": "Change Benefit Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.changeBenefitStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/cities": {
+            "get": {
+                "description": "Get all cities",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cities"
+                ],
+                "summary": "Get Cities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.City"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "description": "Get all organizations, optionally filtered by city_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get Organizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "City ID (UUID) to filter organizations",
+                        "name": "city_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Organization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Создать новую организацию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create Organization",
+                "parameters": [
+                    {
+                        "description": "Данные организации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createOrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "description": "Получить организацию по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get Organization By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Обновить существующую организацию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Update Organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные организации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createOrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Удалить организацию (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Delete Organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Ключи доступа к API партнера, включая отозванные. Сами ключи не возвращаются, только префиксы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get Partner API Keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.getPartnerAPIKeysResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Выпустить ключ доступа к API партнера (заголовок X-API-Key). Ключ возвращается один раз и больше\nнигде не показывается. Доступные scopes: benefits:read, benefits:write, buildings:read, buildings:write",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create Partner API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ключ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createPartnerAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createPartnerAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/api-keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Отозвать ключ доступа к API партнера. Запросы с ключом сразу перестают приниматься",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Revoke Partner API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID (UUID)",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/partner/benefits": {
+            "get": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Льготы организации, которой выдан ключ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Partner Benefits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество на странице (до 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Создать льготу организации, которой выдан ключ. organization_id можно не передавать.\nЛьгота создается черновиком, для публикации ее нужно отправить на проверку через POST /partner/benefits/{id}/status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Create Partner Benefit",
+                "parameters": [
+                    {
+                        "description": "Данные льготы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/partner/benefits/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Льгота организации, которой выдан ключ. Просмотр не увеличивает счетчик просмотров",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Partner Benefit By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Обновить льготу организации, которой выдан ключ. Опубликованная льгота возвращается на проверку (in_review)\nи снова видна гражданам после одобрения редакцией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Update Partner Benefit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные льготы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createBenefitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Удалить льготу организации, которой выдан ключ (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Delete Partner Benefit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/partner/benefits/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Отправить льготу организации на проверку (draft -\u003e in_review), вернуть в черновики или перевести в архив.\nПубликует льготы редакция, ключу партнера публикация недоступна",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Change Partner Benefit Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Benefit ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.changeBenefitStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.benefitStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/partner/buildings": {
+            "get": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Здания организации, которой выдан ключ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Partner Buildings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.partnerBuildingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Добавить здание организации, которой выдан ключ. start_time и end_time в формате RFC 3339",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Create Partner Building",
+                "parameters": [
+                    {
+                        "description": "Данные здания",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.partnerBuildingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.organizationBuildingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/partner/buildings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Здание организации, которой выдан ключ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Partner Building By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Building ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.organizationBuildingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Обновить здание организации, которой выдан ключ. start_time и end_time в формате RFC 3339",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Update Partner Building",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Building ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные здания",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.partnerBuildingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.organizationBuildingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "PartnerAuth": []
+                    }
+                ],
+                "description": "Удалить здание организации, которой выдан ключ (soft delete)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner"
+                ],
+                "summary": "Delete Partner Building",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Building ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/questionnaire": {
+            "post": {
+                "description": "Анкета \"На что я имею право?\" без входа через Госуслуги. Клиент присылает все ответы, данные на текущий момент,\nи получает следующий вопрос. Набор вопросов и вариантов ответа зависит от предыдущих ответов.\nКогда вопросов не осталось (completed=true), возвращаются льготы для групп из ответов, доступные в выбранном городе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questionnaire"
+                ],
+                "summary": "Evaluate Questionnaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице (по умолчанию 10, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Ответы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.QuestionnaireAnswers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.questionnaireResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/questionnaire/drafts": {
+            "post": {
+                "description": "Сохранить ответы анкеты на 7 дней. После входа draft_id передается в POST /users/questionnaire/apply,\nи ответы переносятся в профиль",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questionnaire"
+                ],
+                "summary": "Save Questionnaire Draft",
+                "parameters": [
+                    {
+                        "description": "Ответы",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.QuestionnaireAnswers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.questionnaireDraftResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/speech/recognize": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Распознает речь из аудиофайла и возвращает текст",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Speech"
+                ],
+                "summary": "Распознавание речи",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Аудиофайл (MP3 или WebM)",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Распознанный текст",
+                        "schema": {
+                            "$ref": "#/definitions/v1.speechResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/auth/login": {
+            "post": {
+                "description": "Вход сотрудника по логину и паролю. Если у сотрудника включен TOTP, токены не выдаются:\nвозвращается mfa_token, который нужно подтвердить кодом в /staff/auth/mfa.\nПосле нескольких неудачных попыток подряд вход временно блокируется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Staff Login",
+                "parameters": [
+                    {
+                        "description": "Логин и пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffLoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/staff/auth/mfa": {
+            "post": {
+                "description": "Подтверждение входа сотрудника TOTP кодом из приложения или одноразовым кодом восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Staff MFA",
+                "parameters": [
+                    {
+                        "description": "mfa_token и код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffVerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.exchangeTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/staff/recovery-codes": {
+            "get": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Количество неиспользованных кодов восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Recovery Codes Count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffRecoveryCodesCountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Замена кодов восстановления новыми. Старые коды перестают действовать",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Regenerate Recovery Codes",
+                "parameters": [
+                    {
+                        "description": "TOTP код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffTOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/staff/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Включение второго фактора кодом из приложения. В ответе коды восстановления, они показываются только один раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Confirm TOTP",
+                "parameters": [
+                    {
+                        "description": "TOTP код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffTOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/staff/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "AdminAuth": []
+                    }
+                ],
+                "description": "Выпуск секрета TOTP. provisioning_uri показывается QR кодом для приложения-аутентификатора.\nВторой фактор включается после подтверждения кодом в /staff/totp/confirm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff Auth"
+                ],
+                "summary": "Enroll TOTP",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.staffEnrollTOTPResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/auth/callback": {
+            "get": {
+                "description": "Callback endpoint для Auth - получает code от ESIA и редиректит на фронтенд",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "OAuth Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State parameter",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to frontend with code"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/auth/login": {
+            "get": {
+                "description": "Перенаправление на ESIA для авторизации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "OAuth Login",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/users/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Выход: access токен из заголовка перестает приниматься сразу, refresh токены его сессии отзываются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/auth/refresh": {
+            "post": {
+                "description": "Обмен refresh токена на новую пару токенов. Refresh токен берется из cookie refresh_token или из тела запроса.\nКаждый refresh токен одноразовый: при повторном использовании отзываются все токены этого входа.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh Tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh токен (если не передан в cookie)",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.refreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.exchangeTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/auth/token": {
+            "post": {
+                "description": "Обмен authorization code на access и refresh токены (как в Keycloak)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Exchange Code for Tokens",
+                "parameters": [
+                    {
+                        "description": "Code и State",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.exchangeTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.exchangeTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/contacts/email": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Отправить код подтверждения на новый email. Код действует AUTH_CONTACT_CODE_TTL, новый код можно запросить\nне раньше resend_after и не больше AUTH_CONTACT_CODE_MAX_PER_HOUR раз в час",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Request Email Change",
+                "parameters": [
+                    {
+                        "description": "Новый email",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.requestEmailChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.contactChangeChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/contacts/email/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Подтвердить новый email кодом из письма. После нескольких неверных кодов код сбрасывается.\nПодтвержденный email больше не перезаписывается данными Госуслуг при входе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm Email Change",
+                "parameters": [
+                    {
+                        "description": "Код подтверждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.confirmContactChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.contactsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/contacts/phone": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Отправить код подтверждения по SMS на новый номер телефона в формате 7XXXXXXXXXX.\nОграничения на повторную отправку такие же, как у email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Request Phone Change",
+                "parameters": [
+                    {
+                        "description": "Новый номер телефона",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.requestPhoneChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.contactChangeChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/contacts/phone/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Подтвердить новый номер телефона кодом из SMS.\nПодтвержденный номер больше не перезаписывается данными Госуслуг при входе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm Phone Change",
+                "parameters": [
+                    {
+                        "description": "Код подтверждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.confirmContactChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.contactsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/documents": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Документы пользователя: паспорт, СНИЛС, регистрация",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User Documents",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.userDocumentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Добавить документ. У пользователя может быть один документ каждого типа.\ndocument_type: passport (серия и номер: 4 + 6 цифр), snils (11 цифр, проверяется контрольное число), registration (адрес).\nНомера паспорта и СНИЛС сохраняются в виде 9800 123456 и 123-456-789 00",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create User Document",
+                "parameters": [
+                    {
+                        "description": "Документ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createUserDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.userDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/documents/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Изменить номер и реквизиты документа. Тип документа не меняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update User Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Документ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.userDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.userDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Удалить документ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/eligibility-profile": {
+            "put": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Данные для проверки права на льготы: среднедушевой доход семьи в месяц (руб.), количество детей, категория инвалидности.\ndisability_category: none, group_1, group_2, group_3, child. null - значение не указано, условия льгот по нему получат статус missing_data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update Eligibility Profile",
+                "parameters": [
+                    {
+                        "description": "Данные профиля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.eligibilityProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.eligibilityProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/groups/history": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "История статусов социальных групп пользователя, новые события первыми.\nИсточник изменения: user, checker (проверка по СНИЛС), moderator (решение по документу), expiry (истек срок), profile_sync (сменился СНИЛС в ЕСИА).\nПустой from_status - группа добавлена в профиль, пустой to_status - убрана из профиля.\nСобытия групп членов семьи отмечены household_member_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User Group History",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.userGroupEventResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/household": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Члены семьи пользователя (супруг(а), дети) и статусы проверки их социальных групп.\nПодтвержденные группы членов семьи учитываются в фильтре filter_by_user_groups, статистике льгот и удостоверениях\nтолько после того, как модератор подтвердит родство по документу (relation_status = verified)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Household Members",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.householdMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Добавить члена семьи. Выбранные группы отправляются на проверку по СНИЛС члена семьи тем же сервисом, что и группы пользователя.\nrelation: spouse (супруг(а)), child (ребенок). СНИЛС принимается с разделителями или без",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Add Household Member",
+                "parameters": [
+                    {
+                        "description": "Данные члена семьи",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.addHouseholdMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.householdMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/household/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Удалить члена семьи. Его группы перестают учитываться при подборе льгот",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete Household Member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household member ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/household/{id}/groups": {
+            "put": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Изменить группы члена семьи. Подтвержденные и ожидающие проверки группы сохраняются,\nновые, отклоненные, истекшие и непроверенные отправляются на проверку заново",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update Household Member Groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household member ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Группы члена семьи",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.updateHouseholdMemberGroupsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.householdMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/household/{id}/relation-document": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Загрузить документ, подтверждающий родство с членом семьи: свидетельство о браке для супруга(и),\nсвидетельство о рождении для ребенка. Группы члена семьи учитываются только после одобрения документа модератором.\nРодство с одним СНИЛС подтверждается не больше чем на одном аккаунте для супруга(и) и на двух для ребенка.\nПринимаются файлы pdf, jpeg и png",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Upload Household Relation Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household member ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Скан документа",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.verificationDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/pdfdownload": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Скачать удостоверение/справку социальной группы в формате PDF. По умолчанию генерируется удостоверение пенсионера.\n\nДоступные типы групп:\n- pensioners (пенсионеры) - по умолчанию\n- disabled (инвалиды)\n- students (студенты)\n- young_families (молодые семьи)\n- large_families (многодетные семьи)\n- low_income (малоимущие)\n- children (дети)\n- veterans (ветераны)\n\nЕсли группа подтверждена не у пользователя, а у члена семьи с подтвержденным родством, удостоверение выдается на этого члена семьи.\nКонкретного члена семьи можно выбрать параметром member_id.\nЕсли у пользователя нет реальных данных для указанной группы, будут использованы моковые данные.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User Certificate PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип социальной группы (по умолчанию: pensioners)",
+                        "name": "group_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID члена семьи (UUID), на которого выдается удостоверение",
+                        "name": "member_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF файл удостоверения/справки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/pong": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Pong",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pong"
+                ],
+                "summary": "Pong",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Get user profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.getProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/questionnaire/apply": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Перенести в профиль ответы анкеты, заполненной до входа. Город, количество детей и категория инвалидности\nзаполняются, только если их еще нет в профиле. Группы из анкеты добавляются к группам пользователя и отправляются на проверку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Apply Questionnaire Draft",
+                "parameters": [
+                    {
+                        "description": "ID черновика",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.applyQuestionnaireDraftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Активные сессии пользователя на всех устройствах. Текущая сессия помечена флагом current",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Get Sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.getSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Выход из всех сессий пользователя на всех устройствах, включая текущую",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Logout Everywhere",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/sessions/current": {
+            "delete": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Выход из текущей сессии",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Завершение одной сессии пользователя. Refresh токены сессии перестают обмениваться сразу",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Revoke Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/update-info": {
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Register user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "User Update Info",
+                "parameters": [
+                    {
+                        "description": "User update info request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.userUpdateInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verification-documents": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Загруженные пользователем документы и решения модераторов, новые первыми",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Verification Documents",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.verificationDocumentsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Загрузить скан документа, подтверждающего социальную группу, если проверка по СНИЛС ее не подтвердила.\nГруппа должна быть в профиле и не быть подтвержденной. После загрузки группа ожидает решения модератора.\nПринимаются файлы pdf, jpeg и png",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Upload Verification Document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип социальной группы",
+                        "name": "group_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Скан документа",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.verificationDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/verification-documents/{id}/file": {
+            "get": {
+                "security": [
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Скачать загруженный пользователем документ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Verification Document File",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "ErrorStruct": {
+            "type": "object",
+            "properties": {
+                "error_code": {
+                    "type": "integer"
+                },
+                "error_message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "benefits:read",
+                "benefits:write",
+                "buildings:read",
+                "buildings:write"
+            ],
+            "x-enum-varnames": [
+                "APIKeyScopeBenefitsRead",
+                "APIKeyScopeBenefitsWrite",
+                "APIKeyScopeBuildingsRead",
+                "APIKeyScopeBuildingsWrite"
+            ]
+        },
+        "domain.BenefitFieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.BenefitLevel": {
+            "type": "string",
+            "enum": [
+                "regional",
+                "federal",
+                "commercial"
+            ],
+            "x-enum-varnames": [
+                "Regional",
+                "Federal",
+                "Commercial"
+            ]
+        },
+        "domain.BenefitRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.BenefitRevisionAction"
+                },
+                "author_api_key_id": {
+                    "type": "string"
+                },
+                "author_user_id": {
+                    "type": "string"
+                },
+                "benefit_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "description": "RestoredFrom - номер восстановленной версии, только для action=restore",
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/domain.BenefitSnapshot"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BenefitRevisionAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "restore"
+            ],
+            "x-enum-comments": {
+                "BenefitRevisionActionRestore": "Восстановлена одна из прежних версий"
+            },
+            "x-enum-varnames": [
+                "BenefitRevisionActionCreate",
+                "BenefitRevisionActionUpdate",
+                "BenefitRevisionActionDelete",
+                "BenefitRevisionActionRestore"
+            ]
+        },
+        "domain.BenefitSnapshot": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eligibility_rules": {
+                    "$ref": "#/definitions/domain.EligibilityRules"
+                },
+                "how_to_use": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "requirement": {
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BenefitTag"
+                    }
+                },
+                "target_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TargetGroup"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.BenefitLevel"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BenefitStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "in_review",
+                "published",
+                "archived"
+            ],
+            "x-enum-varnames": [
+                "BenefitStatusDraft",
+                "BenefitStatusInReview",
+                "BenefitStatusPublished",
+                "BenefitStatusArchived"
+            ]
+        },
+        "domain.BenefitTag": {
+            "type": "string",
+            "enum": [
+                "most_popular",
+                "new",
+                "hot",
+                "best",
+                "recommended",
+                "popular",
+                "top"
+            ],
+            "x-enum-varnames": [
+                "MostPopular",
+                "New",
+                "Hot",
+                "Best",
+                "Recommended",
+                "Popular",
+                "Top"
+            ]
+        },
+        "domain.Category": {
+            "type": "string",
+            "enum": [
+                "medicine",
+                "transport",
+                "food",
+                "clothing",
+                "education",
+                "payments",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "Medicine",
+                "Transport",
+                "Food",
+                "Clothing",
+                "Education",
+                "Payments",
+                "Other"
+            ]
+        },
+        "domain.City": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DisabilityCategory": {
+            "type": "string",
+            "enum": [
+                "none",
+                "group_1",
+                "group_2",
+                "group_3",
+                "child"
+            ],
+            "x-enum-comments": {
+                "DisabilityCategoryChild": "Ребенок-инвалид",
+                "DisabilityCategoryGroup1": "I группа",
+                "DisabilityCategoryGroup2": "II группа",
+                "DisabilityCategoryGroup3": "III группа",
+                "DisabilityCategoryNone": "Инвалидности нет"
+            },
+            "x-enum-varnames": [
+                "DisabilityCategoryNone",
+                "DisabilityCategoryGroup1",
+                "DisabilityCategoryGroup2",
+                "DisabilityCategoryGroup3",
+                "DisabilityCategoryChild"
+            ]
+        },
+        "domain.EligibilityCheck": {
+            "type": "object",
+            "properties": {
+                "criterion": {
+                    "$ref": "#/definitions/domain.EligibilityCriterion"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.EligibilityStatus"
+                }
+            }
+        },
+        "domain.EligibilityCriterion": {
+            "type": "string",
+            "enum": [
+                "target_group",
+                "age",
+                "residence",
+                "income",
+                "children",
+                "disability"
+            ],
+            "x-enum-varnames": [
+                "EligibilityCriterionTargetGroup",
+                "EligibilityCriterionAge",
+                "EligibilityCriterionResidence",
+                "EligibilityCriterionIncome",
+                "EligibilityCriterionChildren",
+                "EligibilityCriterionDisability"
+            ]
+        },
+        "domain.EligibilityResult": {
+            "type": "object",
+            "properties": {
+                "benefit_id": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EligibilityCheck"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.EligibilityStatus"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EligibilityRules": {
+            "type": "object",
+            "properties": {
+                "city_ids": {
+                    "description": "CityIDs, RegionIDs - города и регионы проживания. Достаточно совпадения с любым из списков",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "disability_categories": {
+                    "description": "DisabilityCategories - подходящие категории инвалидности, хотя бы одна должна совпадать",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DisabilityCategory"
+                    }
+                },
+                "max_age": {
+                    "type": "integer"
+                },
+                "max_monthly_income": {
+                    "description": "MaxMonthlyIncome - предельный среднедушевой доход семьи в месяц, руб.",
+                    "type": "integer"
+                },
+                "min_age": {
+                    "type": "integer"
+                },
+                "min_children": {
+                    "type": "integer"
+                },
+                "region_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.EligibilityStatus": {
+            "type": "string",
+            "enum": [
+                "eligible",
+                "not_eligible",
+                "missing_data"
+            ],
+            "x-enum-comments": {
+                "EligibilityStatusEligible": "Условие выполнено",
+                "EligibilityStatusMissingData": "В профиле не хватает данных",
+                "EligibilityStatusNotEligible": "Условие не выполнено"
+            },
+            "x-enum-varnames": [
+                "EligibilityStatusEligible",
+                "EligibilityStatusNotEligible",
+                "EligibilityStatusMissingData"
+            ]
+        },
+        "domain.FamilyStatus": {
+            "type": "string",
+            "enum": [
+                "single",
+                "married"
+            ],
+            "x-enum-varnames": [
+                "FamilyStatusSingle",
+                "FamilyStatusMarried"
+            ]
+        },
+        "domain.GroupType": {
+            "type": "string",
+            "enum": [
+                "pensioners",
+                "disabled",
+                "young_families",
+                "low_income",
+                "students",
+                "large_families",
+                "children",
+                "veterans"
+            ],
+            "x-enum-varnames": [
+                "UserGroupPensioners",
+                "UserGroupDisabled",
+                "UserGroupYoungFamilies",
+                "UserGroupLowIncome",
+                "UserGroupStudents",
+                "UserGroupLargeFamilies",
+                "UserGroupChildren",
+                "UserGroupVeterans"
+            ]
+        },
+        "domain.HouseholdGroup": {
+            "type": "object",
+            "properties": {
+                "group_type": {
+                    "$ref": "#/definitions/domain.GroupType"
+                },
+                "member_id": {
+                    "description": "MemberID - член семьи, пусто для самого пользователя",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "relation": {
+                    "$ref": "#/definitions/domain.HouseholdRelation"
+                }
+            }
+        },
+        "domain.HouseholdRelation": {
+            "type": "string",
+            "enum": [
+                "self",
+                "spouse",
+                "child"
+            ],
+            "x-enum-varnames": [
+                "HouseholdRelationSelf",
+                "HouseholdRelationSpouse",
+                "HouseholdRelationChild"
+            ]
+        },
+        "domain.HouseholdRelationStatus": {
+            "type": "string",
+            "enum": [
+                "unconfirmed",
+                "pending",
+                "verified",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "HouseholdRelationStatusUnconfirmed",
+                "HouseholdRelationStatusPending",
+                "HouseholdRelationStatusVerified",
+                "HouseholdRelationStatusRejected"
+            ]
+        },
+        "domain.IncomeBracket": {
+            "type": "string",
+            "enum": [
+                "below_subsistence",
+                "below_double_subsistence",
+                "above_double_subsistence"
+            ],
+            "x-enum-comments": {
+                "IncomeBracketAboveDoubleSubsistence": "Больше двух прожиточных минимумов",
+                "IncomeBracketBelowDoubleSubsistence": "До двух прожиточных минимумов",
+                "IncomeBracketBelowSubsistence": "Ниже прожиточного минимума"
+            },
+            "x-enum-varnames": [
+                "IncomeBracketBelowSubsistence",
+                "IncomeBracketBelowDoubleSubsistence",
+                "IncomeBracketAboveDoubleSubsistence"
+            ]
+        },
+        "domain.Organization": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrganizationBuilding"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrganizationBuilding": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isOpen": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "organizationID": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Question": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuestionOption"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.QuestionType"
+                }
+            }
+        },
+        "domain.QuestionOption": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QuestionType": {
+            "type": "string",
+            "enum": [
+                "number",
+                "city",
+                "single_choice",
+                "multiple_choice"
+            ],
+            "x-enum-comments": {
+                "QuestionTypeCity": "Выбор из справочника городов"
+            },
+            "x-enum-varnames": [
+                "QuestionTypeNumber",
+                "QuestionTypeCity",
+                "QuestionTypeSingleChoice",
+                "QuestionTypeMultipleChoice"
+            ]
+        },
+        "domain.QuestionnaireAnswers": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "children_count": {
+                    "type": "integer"
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "disability_category": {
+                    "$ref": "#/definitions/domain.DisabilityCategory"
+                },
+                "family_status": {
+                    "$ref": "#/definitions/domain.FamilyStatus"
+                },
+                "income_bracket": {
+                    "$ref": "#/definitions/domain.IncomeBracket"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuestionnaireStatus"
+                    }
+                }
+            }
+        },
+        "domain.QuestionnaireStatus": {
+            "type": "string",
+            "enum": [
+                "pensioner",
+                "disabled",
+                "student",
+                "veteran"
+            ],
+            "x-enum-varnames": [
+                "QuestionnaireStatusPensioner",
+                "QuestionnaireStatusDisabled",
+                "QuestionnaireStatusStudent",
+                "QuestionnaireStatusVeteran"
+            ]
+        },
+        "domain.Role": {
+            "type": "string",
+            "enum": [
+                "citizen",
+                "content_editor",
+                "organization_manager",
+                "administrator"
+            ],
+            "x-enum-varnames": [
+                "RoleCitizen",
+                "RoleContentEditor",
+                "RoleOrganizationManager",
+                "RoleAdministrator"
+            ]
+        },
+        "domain.TargetGroup": {
+            "type": "string",
+            "enum": [
+                "pensioners",
+                "disabled",
+                "young_families",
+                "low_income",
+                "students",
+                "large_families",
+                "children",
+                "veterans"
+            ],
+            "x-enum-varnames": [
+                "Pensioners",
+                "Disabled",
+                "YoungFamilies",
+                "LowIncome",
+                "Students",
+                "LargeFamilies",
+                "Children",
+                "Veterans"
+            ]
+        },
+        "domain.UserDocumentType": {
+            "type": "string",
+            "enum": [
+                "passport",
+                "snils",
+                "registration"
+            ],
+            "x-enum-varnames": [
+                "UserDocumentTypePassport",
+                "UserDocumentTypeSNILS",
+                "UserDocumentTypeRegistration"
+            ]
+        },
+        "domain.UserGroup": {
+            "type": "object",
+            "properties": {
+                "error_message": {
+                    "description": "Сообщение об ошибке",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Когда истекает",
+                    "type": "string"
+                },
+                "external_id": {
+                    "description": "ID из внешнего API",
+                    "type": "string"
+                },
+                "rejected_at": {
+                    "description": "Когда отклонена",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.VerificationStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.GroupType"
+                },
+                "verified_at": {
+                    "description": "Когда подтверждена",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserGroupEventSource": {
+            "type": "string",
+            "enum": [
+                "user",
+                "checker",
+                "moderator",
+                "expiry",
+                "profile_sync"
+            ],
+            "x-enum-comments": {
+                "UserGroupEventSourceChecker": "Сервис проверки групп по СНИЛС",
+                "UserGroupEventSourceExpiry": "Истек срок подтверждения",
+                "UserGroupEventSourceModerator": "Решение модератора по документу",
+                "UserGroupEventSourceProfileSync": "Сменился СНИЛС в ЕСИА",
+                "UserGroupEventSourceUser": "Пользователь выбрал группу или загрузил документ"
+            },
+            "x-enum-varnames": [
+                "UserGroupEventSourceUser",
+                "UserGroupEventSourceChecker",
+                "UserGroupEventSourceModerator",
+                "UserGroupEventSourceExpiry",
+                "UserGroupEventSourceProfileSync"
+            ]
+        },
+        "domain.VerificationDocumentMember": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "relation": {
+                    "$ref": "#/definitions/domain.HouseholdRelation"
+                },
+                "snils": {
+                    "type": "string"
+                },
+                "snils_collisions": {
+                    "description": "SNILSCollisions - сколько других аккаунтов добавили члена семьи с тем же СНИЛС",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.VerificationDocumentStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "VerificationDocumentStatusPending",
+                "VerificationDocumentStatusApproved",
+                "VerificationDocumentStatusRejected"
+            ]
+        },
+        "domain.VerificationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "verified",
+                "rejected",
+                "expired",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "VerificationStatusExpired": "Истекла",
+                "VerificationStatusFailed": "Не удалось проверить",
+                "VerificationStatusPending": "Ожидает подтверждения",
+                "VerificationStatusRejected": "Отклонена",
+                "VerificationStatusVerified": "Подтверждена"
+            },
+            "x-enum-varnames": [
+                "VerificationStatusPending",
+                "VerificationStatusVerified",
+                "VerificationStatusRejected",
+                "VerificationStatusExpired",
+                "VerificationStatusFailed"
+            ]
+        },
+        "repository.UserBenefitsMemberStats": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "member_id": {
+                    "description": "Пусто для самого пользователя",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "relation": {
+                    "$ref": "#/definitions/domain.HouseholdRelation"
+                },
+                "total_benefits": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.UserBenefitsStats": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "description": "Members - кому из семьи принадлежат подтвержденные группы и сколько льгот они открывают",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.UserBenefitsMemberStats"
+                    }
+                },
+                "total_benefits": {
+                    "type": "integer"
+                },
+                "total_favorites": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.BenefitImportAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "BenefitImportActionCreate",
+                "BenefitImportActionUpdate",
+                "BenefitImportActionUnchanged"
+            ]
+        },
+        "service.BenefitImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "Rows - результат по каждой строке файла",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.BenefitImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.BenefitImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action - что сделано (в dry run - что будет сделано) со строкой без ошибок",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.BenefitImportAction"
+                        }
+                    ]
+                },
+                "benefit_id": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status - статус публикации льготы после импорта: опубликованная льгота, измененная без права публикации, уходит на проверку",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.BenefitStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.addHouseholdMemberRequest": {
+            "type": "object",
+            "required": [
+                "first_name",
+                "last_name",
+                "relation",
+                "snils"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GroupType"
+                    }
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "middle_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "relation": {
+                    "$ref": "#/definitions/domain.HouseholdRelation"
+                },
+                "snils": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.adminStatsResponse": {
+            "type": "object",
+            "properties": {
+                "benefit_types": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_benefits": {
+                    "type": "integer"
+                },
+                "total_cities": {
+                    "type": "integer"
+                },
+                "total_favorites": {
+                    "type": "integer"
+                },
+                "total_users": {
+                    "type": "integer"
+                },
+                "user_groups": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "v1.adminUserGroupEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "external_request_id": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/domain.VerificationStatus"
+                },
+                "group_type": {
+                    "$ref": "#/definitions/domain.GroupType"
+                },
+                "household_member_id": {
+                    "description": "HouseholdMemberID - член семьи, которому принадлежит группа. Пусто для групп самого пользователя",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "source": {
+                    "$ref": "#/definitions/domain.UserGroupEventSource"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/domain.VerificationStatus"
+                }
+            }
+        },
+        "v1.applyQuestionnaireDraftRequest": {
+            "type": "object",
+            "required": [
+                "draft_id"
+            ],
+            "properties": {
+                "draft_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.benefitResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eligibility_rules": {
+                    "description": "EligibilityRules - условия получения льготы сверх целевых групп",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EligibilityRules"
+                        }
+                    ]
+                },
+                "favorite": {
+                    "type": "boolean"
+                },
+                "gis_deeplink": {
+                    "type": "string"
+                },
+                "how_to_use": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "organization": {
+                    "$ref": "#/definitions/v1.organizationResponse"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "qualifying_members": {
+                    "description": "QualifyingMembers - кто из семьи подходит под целевые группы льготы, заполняется при filter_by_user_groups=true",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.HouseholdGroup"
+                    }
+                },
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "requirement": {
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status - статус публикации, в публичном каталоге всегда published",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.BenefitStatus"
+                        }
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.benefitRevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BenefitFieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.benefitStatusResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.BenefitStatus"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.benefitsEligibilityResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EligibilityResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.benefitsListResponse": {
+            "type": "object",
+            "properties": {
+                "benefits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.benefitResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.changeBenefitStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "publish_at": {
+                    "description": "PublishAt - отложенная публикация льготы на проверке",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.BenefitStatus"
+                },
+                "unpublish_at": {
+                    "description": "UnpublishAt - когда перевести опубликованную льготу в архив",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.confirmContactChangeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.contactChangeChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt - до какого времени действует отправленный код",
+                    "type": "string"
+                },
+                "resend_after": {
+                    "description": "ResendAfter - с какого времени можно запросить новый код",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.contactsResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "email_confirmed_at": {
+                    "type": "string"
+                },
+                "phone_confirmed_at": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createBenefitRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "requirement",
+                "source_url",
+                "target_groups",
+                "title",
+                "type"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eligibility_rules": {
+                    "description": "EligibilityRules - условия получения: возраст, город или регион проживания, доход, количество детей, инвалидность",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EligibilityRules"
+                        }
+                    ]
+                },
+                "how_to_use": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "requirement": {
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createBenefitResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status - статус публикации после сохранения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.BenefitStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.createOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createOrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createPartnerAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "v1.createPartnerAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "description": "Key - ключ целиком. Показывается только в этом ответе",
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.APIKeyScope"
+                    }
                 }
             }
         },
-        "domain.GroupType": {
-            "type": "string",
-            "enum": [
-                "pensioners",
-                "disabled",
-                "young_families",
-                "low_income",
-                "students",
-                "large_families",
-                "children",
-                "veterans"
+        "v1.createStaffRequest": {
+            "type": "object",
+            "required": [
+                "first_name",
+                "last_name",
+                "login",
+                "password"
             ],
-            "x-enum-varnames": [
-                "UserGroupPensioners",
-                "UserGroupDisabled",
-                "UserGroupYoungFamilies",
-                "UserGroupLowIncome",
-                "UserGroupStudents",
-                "UserGroupLargeFamilies",
-                "UserGroupChildren",
-                "UserGroupVeterans"
-            ]
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "login": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 3
+                },
+                "middle_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 12
+                }
+            }
         },
-        "domain.Organization": {
+        "v1.createStaffResponse": {
             "type": "object",
             "properties": {
-                "buildings": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createUserDocumentRequest": {
+            "type": "object",
+            "required": [
+                "document_number",
+                "document_type"
+            ],
+            "properties": {
+                "document_number": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "document_type": {
+                    "$ref": "#/definitions/domain.UserDocumentType"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "v1.eligibilityProfileRequest": {
+            "type": "object",
+            "properties": {
+                "children_count": {
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 0
+                },
+                "disability_category": {
+                    "$ref": "#/definitions/domain.DisabilityCategory"
+                },
+                "monthly_income": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "v1.eligibilityProfileResponse": {
+            "type": "object",
+            "properties": {
+                "children_count": {
+                    "type": "integer"
+                },
+                "disability_category": {
+                    "$ref": "#/definitions/domain.DisabilityCategory"
+                },
+                "monthly_income": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.exchangeTokenRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.exchangeTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.getPartnerAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OrganizationBuilding"
+                        "$ref": "#/definitions/v1.partnerAPIKeyResponse"
                     }
+                }
+            }
+        },
+        "v1.getProfileResponse": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string"
                 },
-                "created_at": {
+                "children_count": {
+                    "type": "integer"
+                },
+                "citizenship": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "city_id": {
                     "type": "string"
                 },
-                "description": {
+                "disability_category": {
+                    "$ref": "#/definitions/domain.DisabilityCategory"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.userDocumentResponse"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_confirmed_at": {
+                    "description": "Когда пользователь сменил контакт с подтверждением кодом, null - контакт получен из ЕСИА",
                     "type": "string"
                 },
+                "external_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserGroup"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "inn": {
                     "type": "string"
                 },
-                "updated_at": {
+                "last_name": {
+                    "type": "string"
+                },
+                "middle_name": {
+                    "type": "string"
+                },
+                "monthly_income": {
+                    "description": "Данные для проверки права на льготы, null - не указано",
+                    "type": "integer"
+                },
+                "phone_confirmed_at": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "snils": {
+                    "type": "string"
+                },
+                "trusted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.getSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.sessionResponse"
+                    }
+                }
+            }
+        },
+        "v1.getUserRolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.userRoleResponse"
+                    }
+                }
+            }
+        },
+        "v1.grantUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/domain.Role"
+                }
+            }
+        },
+        "v1.householdMemberResponse": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserGroup"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "middle_name": {
+                    "type": "string"
+                },
+                "relation": {
+                    "$ref": "#/definitions/domain.HouseholdRelation"
+                },
+                "relation_status": {
+                    "description": "RelationStatus - подтверждение родства документом: unconfirmed, pending, verified, rejected",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.HouseholdRelationStatus"
+                        }
+                    ]
+                },
+                "relation_verified_at": {
+                    "type": "string"
+                },
+                "snils": {
                     "type": "string"
                 }
             }
         },
-        "domain.OrganizationBuilding": {
+        "v1.householdMembersResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.householdMemberResponse"
+                    }
+                }
+            }
+        },
+        "v1.organizationBuildingResponse": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
+                "end_time": {
                     "type": "string"
                 },
-                "endTime": {
+                "gis_deeplink": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isOpen": {
+                "is_open": {
                     "type": "boolean"
                 },
                 "latitude": {
@@ -1362,13 +6862,10 @@ const docTemplateinternal = `{
                 "longitude": {
                     "type": "number"
                 },
-                "organizationID": {
-                    "type": "string"
-                },
-                "phoneNumber": {
+                "phone_number": {
                     "type": "string"
                 },
-                "startTime": {
+                "start_time": {
                     "type": "string"
                 },
                 "tags": {
@@ -1379,140 +6876,91 @@ const docTemplateinternal = `{
                 },
                 "type": {
                     "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "domain.UserDocument": {
+        "v1.organizationResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "deleted_at": {
-                    "type": "string"
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.organizationBuildingResponse"
+                    }
                 },
-                "document_number": {
+                "description": {
                     "type": "string"
                 },
-                "document_type": {
-                    "$ref": "#/definitions/domain.UserDocumentType"
-                },
                 "id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.UserDocumentType": {
-            "type": "string",
-            "enum": [
-                "passport",
-                "snils",
-                "registration"
-            ],
-            "x-enum-varnames": [
-                "UserDocumentTypePassport",
-                "UserDocumentTypeSNILS",
-                "UserDocumentTypeRegistration"
-            ]
-        },
-        "domain.UserGroup": {
+        "v1.partnerAPIKeyResponse": {
             "type": "object",
             "properties": {
-                "error_message": {
-                    "description": "Сообщение об ошибке",
+                "created_at": {
                     "type": "string"
                 },
-                "expires_at": {
-                    "description": "Когда истекает",
+                "created_by": {
                     "type": "string"
                 },
-                "external_id": {
-                    "description": "ID из внешнего API",
+                "expires_at": {
                     "type": "string"
                 },
-                "rejected_at": {
-                    "description": "Когда отклонена",
+                "id": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.VerificationStatus"
-                },
-                "type": {
-                    "$ref": "#/definitions/domain.GroupType"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "verified_at": {
-                    "description": "Когда подтверждена",
+                "key_prefix": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.VerificationStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "verified",
-                "rejected",
-                "expired"
-            ],
-            "x-enum-comments": {
-                "VerificationStatusExpired": "Истекла",
-                "VerificationStatusPending": "Ожидает подтверждения",
-                "VerificationStatusRejected": "Отклонена",
-                "VerificationStatusVerified": "Подтверждена"
-            },
-            "x-enum-varnames": [
-                "VerificationStatusPending",
-                "VerificationStatusVerified",
-                "VerificationStatusRejected",
-                "VerificationStatusExpired"
-            ]
-        },
-        "repository.UserBenefitsStats": {
-            "type": "object",
-            "properties": {
-                "total_benefits": {
-                    "type": "integer"
                 },
-                "total_favorites": {
-                    "type": "integer"
-                }
-            }
-        },
-        "v1.benefitResponse": {
-            "type": "object",
-            "properties": {
-                "category": {
+                "last_used_at": {
                     "type": "string"
                 },
-                "city_id": {
+                "last_used_ip": {
                     "type": "string"
                 },
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "description": {
+                "revoked_at": {
                     "type": "string"
                 },
-                "favorite": {
-                    "type": "boolean"
-                },
-                "gis_deeplink": {
-                    "type": "string"
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "v1.partnerBuildingRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "end_time",
+                "latitude",
+                "longitude",
+                "phone_number",
+                "start_time",
+                "type"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "how_to_use": {
+                "end_time": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "is_open": {
+                    "type": "boolean"
                 },
                 "latitude": {
                     "type": "number"
@@ -1520,26 +6968,47 @@ const docTemplateinternal = `{
                 "longitude": {
                     "type": "number"
                 },
-                "organization": {
-                    "$ref": "#/definitions/v1.organizationResponse"
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 20
                 },
-                "region": {
+                "start_time": {
+                    "type": "string"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "requirement": {
+                "type": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "v1.partnerBuildingsResponse": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.organizationBuildingResponse"
+                    }
+                }
+            }
+        },
+        "v1.questionnaireBenefitResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
                     "type": "string"
                 },
-                "source_url": {
+                "city_id": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "id": {
+                    "type": "string"
                 },
                 "target_groups": {
                     "type": "array",
@@ -1553,32 +7022,50 @@ const docTemplateinternal = `{
                 "type": {
                     "type": "string"
                 },
-                "updated_at": {
+                "valid_to": {
                     "type": "string"
-                },
-                "valid_from": {
+                }
+            }
+        },
+        "v1.questionnaireDraftResponse": {
+            "type": "object",
+            "properties": {
+                "draft_id": {
                     "type": "string"
                 },
-                "valid_to": {
+                "expires_at": {
                     "type": "string"
-                },
-                "views": {
-                    "type": "integer"
                 }
             }
         },
-        "v1.benefitsListResponse": {
+        "v1.questionnaireResponse": {
             "type": "object",
             "properties": {
+                "answers": {
+                    "$ref": "#/definitions/domain.QuestionnaireAnswers"
+                },
                 "benefits": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.benefitResponse"
+                        "$ref": "#/definitions/v1.questionnaireBenefitResponse"
+                    }
+                },
+                "completed": {
+                    "type": "boolean"
+                },
+                "groups": {
+                    "description": "Groups - группы, под которые подходит гражданин по ответам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GroupType"
                     }
                 },
                 "limit": {
                     "type": "integer"
                 },
+                "next_question": {
+                    "$ref": "#/definitions/domain.Question"
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -1587,249 +7074,309 @@ const docTemplateinternal = `{
                 }
             }
         },
-        "v1.createBenefitRequest": {
+        "v1.refreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.rejectVerificationDocumentRequest": {
             "type": "object",
             "required": [
-                "description",
-                "requirement",
-                "source_url",
-                "target_groups",
-                "title",
-                "type"
+                "reason"
             ],
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "city_id": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "how_to_use": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "v1.requestEmailChangeRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
-                },
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
-                },
-                "organization_id": {
+                }
+            }
+        },
+        "v1.requestPhoneChangeRequest": {
+            "type": "object",
+            "required": [
+                "phone_number"
+            ],
+            "properties": {
+                "phone_number": {
                     "type": "string"
+                }
+            }
+        },
+        "v1.sessionResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
                 },
-                "region": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "requirement": {
+                "device": {
                     "type": "string"
                 },
-                "source_url": {
+                "expires_at": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "target_groups": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "id": {
+                    "type": "string"
                 },
-                "title": {
+                "ip": {
                     "type": "string"
                 },
-                "type": {
+                "last_used_at": {
                     "type": "string"
                 },
-                "valid_from": {
+                "signed_in_at": {
                     "type": "string"
                 },
-                "valid_to": {
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "v1.createBenefitResponse": {
+        "v1.speechResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.staffEnrollTOTPResponse": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
                     "type": "string"
                 },
-                "id": {
+                "secret": {
                     "type": "string"
                 }
             }
         },
-        "v1.createOrganizationRequest": {
+        "v1.staffLoginRequest": {
             "type": "object",
             "required": [
-                "description",
-                "name"
+                "login",
+                "password"
             ],
             "properties": {
-                "description": {
+                "login": {
                     "type": "string"
                 },
-                "name": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "v1.createOrganizationResponse": {
+        "v1.staffLoginResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "access_token": {
                     "type": "string"
                 },
-                "id": {
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "v1.exchangeTokenRequest": {
+        "v1.staffRecoveryCodesCountResponse": {
+            "type": "object",
+            "properties": {
+                "remaining": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.staffRecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.staffTOTPCodeRequest": {
             "type": "object",
             "required": [
-                "code",
-                "state"
+                "code"
             ],
             "properties": {
                 "code": {
                     "type": "string"
-                },
-                "state": {
-                    "type": "string"
                 }
             }
         },
-        "v1.exchangeTokenResponse": {
+        "v1.staffVerifyMFARequest": {
             "type": "object",
+            "required": [
+                "mfa_token"
+            ],
             "properties": {
-                "access_token": {
+                "code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "recovery_code": {
                     "type": "string"
                 }
             }
         },
-        "v1.getProfileResponse": {
+        "v1.updateHouseholdMemberGroupsRequest": {
             "type": "object",
+            "required": [
+                "groups"
+            ],
             "properties": {
-                "city_id": {
-                    "type": "string"
-                },
-                "documents": {
+                "groups": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.UserDocument"
+                        "$ref": "#/definitions/domain.GroupType"
                     }
+                }
+            }
+        },
+        "v1.userDocumentRequest": {
+            "type": "object",
+            "required": [
+                "document_number"
+            ],
+            "properties": {
+                "document_number": {
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "email": {
+                "expires_at": {
                     "type": "string"
                 },
-                "external_id": {
+                "issued_at": {
                     "type": "string"
                 },
-                "first_name": {
+                "issued_by": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "v1.userDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "groups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.UserGroup"
-                    }
-                },
-                "id": {
+                "document_number": {
                     "type": "string"
                 },
-                "last_name": {
+                "document_type": {
+                    "$ref": "#/definitions/domain.UserDocumentType"
+                },
+                "expires_at": {
                     "type": "string"
                 },
-                "middle_name": {
+                "id": {
                     "type": "string"
                 },
-                "phone_number": {
+                "issued_at": {
                     "type": "string"
                 },
-                "registered_at": {
+                "issued_by": {
                     "type": "string"
                 },
-                "snils": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "v1.organizationBuildingResponse": {
+        "v1.userDocumentsResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "end_time": {
-                    "type": "string"
-                },
-                "gis_deeplink": {
-                    "type": "string"
-                },
-                "id": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.userDocumentResponse"
+                    }
+                }
+            }
+        },
+        "v1.userGroupEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "is_open": {
-                    "type": "boolean"
-                },
-                "latitude": {
-                    "type": "number"
+                "from_status": {
+                    "$ref": "#/definitions/domain.VerificationStatus"
                 },
-                "longitude": {
-                    "type": "number"
+                "group_type": {
+                    "$ref": "#/definitions/domain.GroupType"
                 },
-                "phone_number": {
+                "household_member_id": {
+                    "description": "HouseholdMemberID - член семьи, которому принадлежит группа. Пусто для групп самого пользователя",
                     "type": "string"
                 },
-                "start_time": {
+                "message": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "source": {
+                    "$ref": "#/definitions/domain.UserGroupEventSource"
                 },
-                "type": {
-                    "type": "string"
+                "to_status": {
+                    "$ref": "#/definitions/domain.VerificationStatus"
                 }
             }
         },
-        "v1.organizationResponse": {
+        "v1.userProfileChangeResponse": {
             "type": "object",
             "properties": {
-                "buildings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1.organizationBuildingResponse"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "description": {
+                "field": {
                     "type": "string"
                 },
-                "id": {
+                "new_value": {
                     "type": "string"
                 },
-                "name": {
+                "old_value": {
                     "type": "string"
                 }
             }
         },
-        "v1.speechResponse": {
+        "v1.userRoleResponse": {
             "type": "object",
             "properties": {
-                "text": {
+                "created_at": {
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "organization_id": {
                     "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/domain.Role"
                 }
             }
         },
@@ -1850,6 +7397,87 @@ const docTemplateinternal = `{
                     }
                 }
             }
+        },
+        "v1.verificationDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "group_type": {
+                    "$ref": "#/definitions/domain.GroupType"
+                },
+                "household_member_id": {
+                    "description": "HouseholdMemberID - член семьи, родство с которым подтверждает документ. Пусто для документов о группах",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member": {
+                    "description": "Member - член семьи для сверки с документом о родстве, только в очереди модерации",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.VerificationDocumentMember"
+                        }
+                    ]
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.VerificationDocumentStatus"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.verificationDocumentsQueueResponse": {
+            "type": "object",
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.verificationDocumentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.verificationDocumentsResponse": {
+            "type": "object",
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.verificationDocumentResponse"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1858,6 +7486,11 @@ const docTemplateinternal = `{
             "name": "Authorization",
             "in": "header"
         },
+        "PartnerAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "UserAuth": {
             "type": "apiKey",
             "name": "Authorization",
@@ -0,0 +1,133 @@
+// Добавляет access токен сотрудника к запросам страниц админки. Токен хранится только в памяти страницы;
+// после перезагрузки и при ответе 401 он обновляется по refresh cookie, а если сессии нет -
+// показывается форма входа сотрудника (логин, пароль и код TOTP)
+(function () {
+    const refreshURL = '/api/v1/users/auth/refresh';
+    const loginURL = '/api/v1/staff/auth/login';
+    const mfaURL = '/api/v1/staff/auth/mfa';
+    const originalFetch = window.fetch.bind(window);
+
+    // Прежняя версия хранила токен в localStorage
+    localStorage.removeItem('admin_access_token');
+
+    let accessToken = null;
+    let pending = null;
+
+    async function postJSON(url, body) {
+        const response = await originalFetch(url, {
+            method: 'POST',
+            credentials: 'same-origin',
+            headers: {'Content-Type': 'application/json'},
+            body: JSON.stringify(body || {}),
+        });
+        const data = await response.json().catch(() => ({}));
+        return {ok: response.ok, data: data};
+    }
+
+    async function refresh() {
+        const result = await postJSON(refreshURL);
+        return result.ok ? result.data.access_token : null;
+    }
+
+    function errorText(data) {
+        return (data && (data.error_message || data.error)) || 'Не удалось войти';
+    }
+
+    // showLoginForm показывает форму входа и завершается, когда сотрудник вошел
+    function showLoginForm() {
+        return new Promise((resolve) => {
+            const overlay = document.createElement('div');
+            overlay.style.cssText = 'position:fixed;inset:0;background:rgba(0,0,0,.5);display:flex;' +
+                'align-items:center;justify-content:center;z-index:10000;font-family:sans-serif';
+            overlay.innerHTML =
+                '<form style="background:#fff;padding:24px;border-radius:8px;width:320px;display:flex;flex-direction:column;gap:12px">' +
+                '<h3 style="margin:0">Вход сотрудника</h3>' +
+                '<input name="login" placeholder="Логин" autocomplete="username" required>' +
+                '<input name="password" type="password" placeholder="Пароль" autocomplete="current-password" required>' +
+                '<input name="code" placeholder="Код из приложения или код восстановления" autocomplete="one-time-code" style="display:none">' +
+                '<div data-error style="color:#c00;font-size:14px"></div>' +
+                '<button type="submit">Войти</button>' +
+                '</form>';
+            document.body.appendChild(overlay);
+
+            const form = overlay.querySelector('form');
+            const codeInput = form.elements.code;
+            const errorBox = form.querySelector('[data-error]');
+            let mfaToken = null;
+
+            form.addEventListener('submit', async (event) => {
+                event.preventDefault();
+                errorBox.textContent = '';
+
+                let result;
+                if (mfaToken) {
+                    const code = codeInput.value.trim();
+                    // Код TOTP состоит из цифр, остальное считается кодом восстановления
+                    result = await postJSON(mfaURL, /^\d+$/.test(code)
+                        ? {mfa_token: mfaToken, code: code}
+                        : {mfa_token: mfaToken, recovery_code: code});
+                } else {
+                    result = await postJSON(loginURL, {login: form.elements.login.value, password: form.elements.password.value});
+                    if (result.ok && result.data.mfa_required) {
+                        mfaToken = result.data.mfa_token;
+                        form.elements.login.disabled = true;
+                        form.elements.password.disabled = true;
+                        codeInput.style.display = '';
+                        codeInput.required = true;
+                        codeInput.focus();
+                        return;
+                    }
+                }
+
+                if (!result.ok) {
+                    errorBox.textContent = errorText(result.data);
+                    return;
+                }
+                overlay.remove();
+                resolve(result.data.access_token);
+            });
+        });
+    }
+
+    // authenticate получает новый access токен. Параллельные запросы ждут одного обновления
+    function authenticate() {
+        if (!pending) {
+            pending = (async () => (await refresh()) || (await showLoginForm()))()
+                .then((token) => {
+                    accessToken = token;
+                    return token;
+                })
+                .finally(() => {
+                    pending = null;
+                });
+        }
+        return pending;
+    }
+
+    function withToken(init, token) {
+        const options = Object.assign({}, init);
+        const headers = new Headers(options.headers || {});
+        headers.set('Authorization', 'Bearer ' + token);
+        options.headers = headers;
+        return options;
+    }
+
+    window.fetch = async function (input, init) {
+        const url = typeof input === 'string' ? input : input.url;
+        if (!url.startsWith('/api/')) {
+            return originalFetch(input, init);
+        }
+
+        const token = accessToken || await authenticate();
+        const response = await originalFetch(input, withToken(init, token));
+        if (response.status !== 401) {
+            return response;
+        }
+
+        // Access токен истек или отозван: обновляем его и повторяем запрос один раз
+        if (accessToken === token) {
+            accessToken = null;
+        }
+        return originalFetch(input, withToken(init, accessToken || await authenticate()));
+    };
+})();
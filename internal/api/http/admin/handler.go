@@ -9,7 +9,7 @@ import (
 	"go.uber.org/zap"
 )
 
-//go:embed *.html
+//go:embed *.html *.js
 var adminFiles embed.FS
 
 type Handler struct{}
@@ -46,6 +46,18 @@ func (h *Handler) CreateOrganizationPage(c *gin.Context) {
 	h.serveHTML(c, "create-organization.html", "create organization page")
 }
 
+// AuthScript отдает скрипт, который добавляет access токен администратора ко всем запросам страниц админки
+func (h *Handler) AuthScript(c *gin.Context) {
+	script, err := adminFiles.ReadFile("auth.js")
+	if err != nil {
+		logger.Error("failed to read admin auth script", zap.Error(err))
+		c.String(http.StatusInternalServerError, "Failed to read admin auth script")
+		return
+	}
+
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", script)
+}
+
 func (h *Handler) serveHTML(c *gin.Context, filename, pageName string) {
 	htmlContent, err := adminFiles.ReadFile(filename)
 	if err != nil {
@@ -15,10 +15,10 @@ import (
 	"github.com/vibe-gaming/backend/pkg/limiter"
 	"github.com/vibe-gaming/backend/pkg/logger"
 	"github.com/vibe-gaming/backend/pkg/validator"
-	"go.uber.org/zap"
 
 	"github.com/vibe-gaming/backend/internal/api/http/admin"
 	internalV1 "github.com/vibe-gaming/backend/internal/api/http/internal/v1"
+	"github.com/vibe-gaming/backend/internal/authstate"
 	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/esia"
 	"github.com/vibe-gaming/backend/internal/service"
@@ -33,6 +33,7 @@ type Handler struct {
 	config         *config.Config
 	esiaClient     *esia.Client
 	gigachatClient *gigachat.Client
+	authStateStore authstate.Store
 }
 
 func NewHandlers(
@@ -41,6 +42,7 @@ func NewHandlers(
 	cfg *config.Config,
 	esiaClient *esia.Client,
 	gigachatClient *gigachat.Client,
+	authStateStore authstate.Store,
 ) *Handler {
 	return &Handler{
 		services:       services,
@@ -48,6 +50,7 @@ func NewHandlers(
 		config:         cfg,
 		esiaClient:     esiaClient,
 		gigachatClient: gigachatClient,
+		authStateStore: authStateStore,
 	}
 }
 
@@ -91,7 +94,7 @@ func (h *Handler) Init(cfg *config.Config) *gin.Engine {
 
 	// Админка на корневом уровне
 	router.GET("/admin/", adminHandler.AdminPage)
-	router.GET("/admin/stats", h.getAdminStats)
+	router.GET("/admin/auth.js", adminHandler.AuthScript)
 	router.GET("/admin/create-benefit", adminHandler.CreateBenefitPage)
 	router.GET("/admin/benefits", adminHandler.BenefitsListPage)
 	router.GET("/admin/benefits/:id", adminHandler.BenefitDetailPage)
@@ -99,88 +102,29 @@ func (h *Handler) Init(cfg *config.Config) *gin.Engine {
 	router.GET("/admin/organizations/:id", adminHandler.OrganizationDetailPage)
 	router.GET("/admin/create-organization", adminHandler.CreateOrganizationPage)
 
+	// Публичные ключи для проверки access токенов другими сервисами
+	router.GET("/.well-known/jwks.json", h.getJWKS)
+
 	h.initAdminRoutes(router)
 	h.initAPI(router)
 
 	return router
 }
 
+func (h *Handler) getJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, h.tokenManager.JWKS())
+}
+
 func (h *Handler) initAdminRoutes(router *gin.Engine) {
 	api := router.Group("/api")
 	adminGroup := api.Group("/admin")
 	adminHandler := admin.NewHandler()
 	adminGroup.GET("/", adminHandler.AdminPage)
-	adminGroup.GET("/stats", h.getAdminStats)
-}
-
-type adminStatsResponse struct {
-	TotalUsers     int64            `json:"total_users"`
-	TotalBenefits  int64            `json:"total_benefits"`
-	TotalCities    int64            `json:"total_cities"`
-	TotalFavorites int64            `json:"total_favorites"`
-	UserGroups     map[string]int64 `json:"user_groups"`
-	BenefitTypes   map[string]int64 `json:"benefit_types"`
-}
-
-func (h *Handler) getAdminStats(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	// Получаем статистику пользователей
-	totalUsers, err := h.services.Users.Count(ctx)
-	if err != nil {
-		logger.Error("failed to get users count", zap.Error(err))
-		totalUsers = 0
-	}
-
-	// Получаем статистику льгот
-	totalBenefits, err := h.services.Benefits.Count(ctx, nil)
-	if err != nil {
-		logger.Error("failed to get benefits count", zap.Error(err))
-		totalBenefits = 0
-	}
-
-	// Получаем статистику городов
-	totalCities, err := h.services.Cities.Count(ctx)
-	if err != nil {
-		logger.Error("failed to get cities count", zap.Error(err))
-		totalCities = 0
-	}
-
-	// Получаем статистику избранных
-	totalFavorites, err := h.services.Favorites.GetTotalCount(ctx)
-	if err != nil {
-		logger.Error("failed to get favorites count", zap.Error(err))
-		totalFavorites = 0
-	}
-
-	// Получаем статистику по группам пользователей
-	userGroups, err := h.services.Users.GetUserGroupsStats(ctx)
-	if err != nil {
-		logger.Error("failed to get user groups stats", zap.Error(err))
-		userGroups = make(map[string]int64)
-	}
-
-	// Получаем статистику по типам льгот
-	benefitTypes, err := h.services.Benefits.GetBenefitTypesStats(ctx)
-	if err != nil {
-		logger.Error("failed to get benefit types stats", zap.Error(err))
-		benefitTypes = make(map[string]int64)
-	}
-
-	response := adminStatsResponse{
-		TotalUsers:     totalUsers,
-		TotalBenefits:  totalBenefits,
-		TotalCities:    totalCities,
-		TotalFavorites: totalFavorites,
-		UserGroups:     userGroups,
-		BenefitTypes:   benefitTypes,
-	}
-
-	c.JSON(http.StatusOK, response)
 }
 
 func (h *Handler) initAPI(router *gin.Engine) {
-	internalHandlersV1 := internalV1.NewHandler(h.services, h.tokenManager, h.config, h.esiaClient, h.gigachatClient)
+	internalHandlersV1 := internalV1.NewHandler(h.services, h.tokenManager, h.config, h.esiaClient, h.gigachatClient, h.authStateStore)
 	api := router.Group("/api")
 	internalHandlersV1.Init(api)
 }
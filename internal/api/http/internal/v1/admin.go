@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/vibe-gaming/backend/internal/api/http/admin"
+	"github.com/vibe-gaming/backend/internal/domain"
 	"github.com/vibe-gaming/backend/pkg/logger"
 	"go.uber.org/zap"
 
@@ -14,7 +15,43 @@ func (h *Handler) initAdminRoutes(api *gin.RouterGroup) {
 	adminGroup := api.Group("/admin")
 	adminHandler := admin.NewHandler()
 	adminGroup.GET("/", adminHandler.AdminPage)
-	adminGroup.GET("/stats", h.getAdminStats)
+	adminGroup.GET("/stats", h.userIdentityMiddleware, h.requirePermission(domain.PermissionStatsRead), h.getAdminStats)
+
+	roles := adminGroup.Group("/users/:id/roles", h.userIdentityMiddleware, h.requirePermission(domain.PermissionRolesManage))
+	{
+		roles.GET("", h.getUserRoles)
+		roles.POST("", h.grantUserRole)
+		roles.DELETE("/:role", h.revokeUserRole)
+	}
+
+	adminGroup.POST("/staff", h.userIdentityMiddleware, h.requirePermission(domain.PermissionStaffManage), h.createStaff)
+
+	users := adminGroup.Group("/users/:id", h.userIdentityMiddleware, h.requirePermission(domain.PermissionUsersManage))
+	{
+		users.POST("/deactivate", h.deactivateUser)
+		users.POST("/activate", h.activateUser)
+		users.DELETE("", h.deleteUser)
+		users.GET("/profile-changes", h.getUserProfileChanges)
+		users.GET("/group-history", h.getAdminUserGroupHistory)
+	}
+
+	adminGroup.GET("/benefits", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.getAdminBenefits)
+	adminGroup.POST("/benefits/import", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.importBenefits)
+
+	benefitRevisions := adminGroup.Group("/benefits/:id/revisions", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage))
+	{
+		benefitRevisions.GET("", h.getBenefitRevisions)
+		benefitRevisions.GET("/diff", h.diffBenefitRevisions)
+		benefitRevisions.POST("/:version/restore", h.restoreBenefitRevision)
+	}
+
+	verifications := adminGroup.Group("/verification-documents", h.userIdentityMiddleware, h.requirePermission(domain.PermissionVerificationsModerate))
+	{
+		verifications.GET("", h.getVerificationDocumentsQueue)
+		verifications.GET("/:id/file", h.getVerificationDocumentFileForReview)
+		verifications.POST("/:id/approve", h.approveVerificationDocument)
+		verifications.POST("/:id/reject", h.rejectVerificationDocument)
+	}
 }
 
 type adminStatsResponse struct {
@@ -26,6 +63,17 @@ type adminStatsResponse struct {
 	BenefitTypes   map[string]int64 `json:"benefit_types"`
 }
 
+// @Summary Admin Stats
+// @Tags Admin
+// @Description Статистика по пользователям, льготам, городам и избранному
+// @ModuleID getAdminStats
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} adminStatsResponse
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Security AdminAuth
+// @Router /admin/stats [get]
 func (h *Handler) getAdminStats(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// @Summary Deactivate User
+// @Tags Admin
+// @Description Блокировка пользователя. Все сессии завершаются, выданные access токены сразу перестают приниматься
+// @ModuleID deactivateUser
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/deactivate [post]
+func (h *Handler) deactivateUser(c *gin.Context) {
+	userID, ok := h.parseManagedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Users.Deactivate(c.Request.Context(), userID); err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("user deactivated", zap.String("user_id", userID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Activate User
+// @Tags Admin
+// @Description Снятие блокировки пользователя
+// @ModuleID activateUser
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/activate [post]
+func (h *Handler) activateUser(c *gin.Context) {
+	userID, ok := h.parseManagedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Users.Activate(c.Request.Context(), userID); err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("user activated", zap.String("user_id", userID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete User
+// @Tags Admin
+// @Description Удаление пользователя. Все сессии завершаются, выданные access токены сразу перестают приниматься
+// @ModuleID deleteUser
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id} [delete]
+func (h *Handler) deleteUser(c *gin.Context) {
+	userID, ok := h.parseManagedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Users.Delete(c.Request.Context(), userID); err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("user deleted", zap.String("user_id", userID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+type userProfileChangeResponse struct {
+	Field     string    `json:"field"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// @Summary Get User Profile Changes
+// @Tags Admin
+// @Description Журнал изменений профиля пользователя: данные ЕСИА при входе и смена email или телефона по коду, новые записи первыми
+// @ModuleID getUserProfileChanges
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {array} userProfileChangeResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/profile-changes [get]
+func (h *Handler) getUserProfileChanges(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	changes, err := h.services.Users.GetProfileChanges(c.Request.Context(), userID)
+	if err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	response := make([]userProfileChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		item := userProfileChangeResponse{
+			Field:     change.Field,
+			CreatedAt: change.CreatedAt,
+		}
+		if change.OldValue.Valid {
+			item.OldValue = &change.OldValue.String
+		}
+		if change.NewValue.Valid {
+			item.NewValue = &change.NewValue.String
+		}
+		response = append(response, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseManagedUserID разбирает id пользователя из пути. Администратор не может заблокировать или удалить себя,
+// чтобы не остаться без доступа к админке
+func (h *Handler) parseManagedUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return uuid.Nil, false
+	}
+
+	adminID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return uuid.Nil, false
+	}
+
+	if userID == adminID {
+		errorResponse(c, CannotModifySelfCode)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+func (h *Handler) userStateErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	case errors.Is(err, service.ErrUserAlreadyDeactivated):
+		errorResponse(c, UserAlreadyDeactivatedCode)
+	case errors.Is(err, service.ErrUserNotDeactivated):
+		errorResponse(c, UserNotDeactivatedCode)
+	default:
+		logger.Error("change user state failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -19,15 +19,19 @@ import (
 func (h *Handler) initBenefits(api *gin.RouterGroup) {
 	benefits := api.Group("/benefits")
 	{
-		benefits.POST("", h.createBenefit)
-		benefits.PUT("/:id", h.updateBenefit)
-		benefits.DELETE("/:id", h.deleteBenefit)
+		benefits.POST("", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.createBenefit)
+		benefits.PUT("/:id", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.updateBenefit)
+		benefits.DELETE("/:id", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.deleteBenefit)
 		benefits.GET("", h.optionalUserIdentityMiddleware, h.getBenefitsList)
 		benefits.GET("/stats", h.optionalUserIdentityMiddleware, h.getBenefitsFilterStats)
 		benefits.GET("/:id", h.optionalUserIdentityMiddleware, h.getBenefitByID)
 		benefits.POST("/:id/favorite", h.userIdentityMiddleware, h.markBenefitAsFavorite)
 		benefits.GET("/user-stats", h.userIdentityMiddleware, h.getUserBenefitsStats)
+		benefits.GET("/eligibility", h.userIdentityMiddleware, h.getBenefitsEligibility)
+		benefits.GET("/:id/eligibility", h.userIdentityMiddleware, h.getBenefitEligibility)
 		benefits.GET("/:id/pdfdownload", h.getBenefitPDFDownload)
+		benefits.GET("/:id/preview", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.previewBenefit)
+		benefits.POST("/:id/status", h.userIdentityMiddleware, h.requirePermission(domain.PermissionBenefitsManage), h.changeBenefitStatus)
 	}
 }
 
@@ -54,6 +58,14 @@ type benefitResponse struct {
 	GisDeeplink  string                `json:"gis_deeplink,omitempty"`
 	Organization *organizationResponse `json:"organization,omitempty"`
 	Favorite     bool                  `json:"favorite"`
+	// QualifyingMembers - кто из семьи подходит под целевые группы льготы, заполняется при filter_by_user_groups=true
+	QualifyingMembers domain.HouseholdGroups `json:"qualifying_members,omitempty"`
+	// EligibilityRules - условия получения льготы сверх целевых групп
+	EligibilityRules *domain.EligibilityRules `json:"eligibility_rules,omitempty"`
+	// Status - статус публикации, в публичном каталоге всегда published
+	Status      domain.BenefitStatus `json:"status"`
+	PublishAt   *time.Time           `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time           `json:"unpublish_at,omitempty"`
 }
 
 type organizationResponse struct {
@@ -109,13 +121,13 @@ type benefitsListResponse struct {
 // @Param target_groups query string false "Целевые группы через запятую (pensioners, disabled, students и т.д.)"
 // @Param tags query string false "Теги через запятую (most_popular, new, hot, best, recommended, popular, top)"
 // @Param categories query string false "Категории через запятую (medicine, transport, food, clothing, other)"
-// @Param date_from query string false "Дата начала периода (YYYY-MM-DD)"
+// @Param date_from query string false "Дата начала периода (YYYY-MM-DD). Без date_from и date_to выбираются льготы, действующие сегодня"
 // @Param date_to query string false "Дата окончания периода (YYYY-MM-DD)"
 // @Param search query string false "Поисковый запрос (автоматически ищет по частичному совпадению)"
 // @Param sort_by query string false "Поле для сортировки (created_at, views, updated_at) - по умолчанию created_at"
 // @Param order query string false "Направление сортировки (asc, desc) - по умолчанию desc"
 // @Param favorites query boolean false "Показать только избранные льготы (работает только при авторизации, иначе игнорируется)"
-// @Param filter_by_user_groups query boolean false "Фильтровать льготы по группам пользователя (работает только при авторизации)"
+// @Param filter_by_user_groups query boolean false "Фильтровать льготы по подтвержденным группам пользователя и членов его семьи (работает только при авторизации). У каждой льготы заполняется qualifying_members"
 // @Param format query string false "Формат ответа (json или pdf) - по умолчанию json"
 // @Success 200 {object} benefitsListResponse "JSON ответ"
 // @Success 200 {file} application/pdf "PDF файл"
@@ -221,31 +233,19 @@ func (h *Handler) getBenefitsList(c *gin.Context) {
 		}
 	}
 
-	// Фильтр по группам пользователя (только для авторизованных пользователей)
+	// Фильтр по группам пользователя и членов его семьи (только для авторизованных пользователей)
+	var householdGroups domain.HouseholdGroups
 	if filterByUserGroupsStr := c.Query("filter_by_user_groups"); filterByUserGroupsStr == "true" {
 		if userID, err := h.getUserUUID(c); err == nil {
 			logger.Info("filter_by_user_groups=true received", zap.String("user_id", userID.String()))
 
-			// Получаем пользователя из репозитория
-			user, err := h.services.Users.GetOneByID(c.Request.Context(), userID)
+			// Получаем подтвержденные группы пользователя и членов его семьи
+			groups, err := h.services.Household.GetHouseholdGroups(c.Request.Context(), userID)
 			if err != nil {
-				logger.Error("failed to get user for group filtering", zap.Error(err), zap.String("user_id", userID.String()))
+				logger.Error("failed to get household groups for group filtering", zap.Error(err), zap.String("user_id", userID.String()))
 			} else {
-				logger.Info("user retrieved successfully",
-					zap.String("user_id", userID.String()),
-					zap.Int("total_groups", len(user.GroupType)))
-
-				// Собираем подтвержденные группы пользователя
-				verifiedGroups := []string{}
-				for _, group := range user.GroupType {
-					logger.Info("processing user group",
-						zap.String("group_type", string(group.Type)),
-						zap.String("status", string(group.Status)))
-
-					if group.Status == domain.VerificationStatusVerified {
-						verifiedGroups = append(verifiedGroups, string(group.Type))
-					}
-				}
+				householdGroups = groups
+				verifiedGroups := groups.Types()
 
 				// Всегда применяем фильтр, даже если групп нет
 				// Если групп нет - вернется пустой результат
@@ -413,6 +413,12 @@ func (h *Handler) getBenefitsList(c *gin.Context) {
 			GisDeeplink:  benefit.GetGisDeeplink(),
 			Organization: organization,
 			Favorite:     benefit.Favorite,
+
+			QualifyingMembers: householdGroups.Qualifying(benefit.TargetGroupIDs),
+			EligibilityRules:  benefit.EligibilityRules,
+			Status:            benefit.Status,
+			PublishAt:         benefit.PublishAt,
+			UnpublishAt:       benefit.UnpublishAt,
 		})
 	}
 
@@ -458,6 +464,62 @@ func (h *Handler) getBenefitByID(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, newBenefitDetailsResponse(benefit))
+}
+
+// newBenefitResponse - льгота без организации и данных пользователя, для партнеров и редакторов
+func newBenefitResponse(benefit *domain.Benefit) benefitResponse {
+	targetGroups := make([]string, 0, len(benefit.TargetGroupIDs))
+	for _, tg := range benefit.TargetGroupIDs {
+		targetGroups = append(targetGroups, string(tg))
+	}
+
+	var cityID *string
+	if benefit.CityID != nil {
+		cityIDStr := benefit.CityID.String()
+		cityID = &cityIDStr
+	}
+
+	var category *string
+	if benefit.Category != nil {
+		categoryStr := string(*benefit.Category)
+		category = &categoryStr
+	}
+
+	tags := make([]string, 0, len(benefit.Tags))
+	for _, tag := range benefit.Tags {
+		tags = append(tags, string(tag))
+	}
+
+	return benefitResponse{
+		ID:           benefit.ID.String(),
+		Title:        benefit.Title,
+		Description:  benefit.Description,
+		ValidFrom:    benefit.GetValidFrom(),
+		ValidTo:      benefit.GetValidTo(),
+		CreatedAt:    benefit.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    benefit.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Type:         string(benefit.Type),
+		TargetGroups: targetGroups,
+		Longitude:    benefit.Longitude,
+		Latitude:     benefit.Latitude,
+		CityID:       cityID,
+		Region:       benefit.Region,
+		Category:     category,
+		Requirement:  benefit.Requirement,
+		HowToUse:     benefit.HowToUse,
+		SourceURL:    benefit.SourceURL,
+		Tags:         tags,
+		Views:        benefit.Views,
+		GisDeeplink:  benefit.GetGisDeeplink(),
+		Status:       benefit.Status,
+		PublishAt:    benefit.PublishAt,
+		UnpublishAt:  benefit.UnpublishAt,
+	}
+}
+
+// newBenefitDetailsResponse - карточка льготы с организацией и ее зданиями
+func newBenefitDetailsResponse(benefit *domain.Benefit) benefitResponse {
 	targetGroups := make([]string, 0, len(benefit.TargetGroupIDs))
 	for _, tg := range benefit.TargetGroupIDs {
 		targetGroups = append(targetGroups, string(tg))
@@ -517,7 +579,7 @@ func (h *Handler) getBenefitByID(c *gin.Context) {
 			})
 		}
 	}
-	response := benefitResponse{
+	return benefitResponse{
 		ID:           benefit.ID.String(),
 		Title:        benefit.Title,
 		Description:  benefit.Description,
@@ -540,9 +602,12 @@ func (h *Handler) getBenefitByID(c *gin.Context) {
 		GisDeeplink:  benefit.GetGisDeeplink(),
 		Organization: organization,
 		Favorite:     benefit.Favorite,
-	}
 
-	c.JSON(http.StatusOK, response)
+		EligibilityRules: benefit.EligibilityRules,
+		Status:           benefit.Status,
+		PublishAt:        benefit.PublishAt,
+		UnpublishAt:      benefit.UnpublishAt,
+	}
 }
 
 // @Summary Get Benefits Filter Statistics
@@ -560,7 +625,7 @@ func (h *Handler) getBenefitByID(c *gin.Context) {
 // @Param type query string false "Типы льгот через запятую (federal, regional, commercial) - OR логика"
 // @Param target_groups query string false "Целевые группы через запятую"
 // @Param tags query string false "Теги через запятую"
-// @Param date_from query string false "Дата начала периода (YYYY-MM-DD)"
+// @Param date_from query string false "Дата начала периода (YYYY-MM-DD). Без date_from и date_to выбираются льготы, действующие сегодня"
 // @Param date_to query string false "Дата окончания периода (YYYY-MM-DD)"
 // @Param search query string false "Поисковый запрос"
 // @Param favorites query boolean false "Учитывать только избранные льготы (работает только при авторизации)"
@@ -637,31 +702,17 @@ func (h *Handler) getBenefitsFilterStats(c *gin.Context) {
 		}
 	}
 
-	// Фильтр по группам пользователя (только для авторизованных пользователей)
+	// Фильтр по группам пользователя и членов его семьи (только для авторизованных пользователей)
 	if filterByUserGroupsStr := c.Query("filter_by_user_groups"); filterByUserGroupsStr == "true" {
 		if userID, err := h.getUserUUID(c); err == nil {
 			logger.Info("filter_by_user_groups=true received in stats", zap.String("user_id", userID.String()))
 
-			// Получаем пользователя из репозитория
-			user, err := h.services.Users.GetOneByID(c.Request.Context(), userID)
+			// Получаем подтвержденные группы пользователя и членов его семьи
+			groups, err := h.services.Household.GetHouseholdGroups(c.Request.Context(), userID)
 			if err != nil {
-				logger.Error("failed to get user for group filtering in stats", zap.Error(err), zap.String("user_id", userID.String()))
+				logger.Error("failed to get household groups for group filtering in stats", zap.Error(err), zap.String("user_id", userID.String()))
 			} else {
-				logger.Info("user retrieved successfully in stats",
-					zap.String("user_id", userID.String()),
-					zap.Int("total_groups", len(user.GroupType)))
-
-				// Собираем подтвержденные группы пользователя
-				verifiedGroups := []string{}
-				for _, group := range user.GroupType {
-					logger.Info("processing user group in stats",
-						zap.String("group_type", string(group.Type)),
-						zap.String("status", string(group.Status)))
-
-					if group.Status == domain.VerificationStatusVerified {
-						verifiedGroups = append(verifiedGroups, string(group.Type))
-					}
-				}
+				verifiedGroups := groups.Types()
 
 				// Всегда применяем фильтр, даже если групп нет
 				// Если групп нет - вернется пустой результат
@@ -733,7 +784,8 @@ func (h *Handler) markBenefitAsFavorite(c *gin.Context) {
 
 // @Summary Get User Benefits Stats
 // @Tags Benefits
-// @Description Получить статистику по льготам пользователя
+// @Description Получить статистику по льготам пользователя. total_benefits учитывает подтвержденные группы всей семьи,
+// @Description members показывает, сколько льгот открывают группы каждого члена семьи
 // @ModuleID getUserBenefitsStats
 // @Accept  json
 // @Produce  json
@@ -830,78 +882,40 @@ type createBenefitRequest struct {
 	SourceURL      string   `json:"source_url" binding:"required"`
 	Tags           []string `json:"tags,omitempty"`
 	OrganizationID *string  `json:"organization_id,omitempty"`
+	// EligibilityRules - условия получения: возраст, город или регион проживания, доход, количество детей, инвалидность
+	EligibilityRules *domain.EligibilityRules `json:"eligibility_rules,omitempty"`
 }
 
 type createBenefitResponse struct {
 	ID        string `json:"id"`
 	CreatedAt string `json:"created_at"`
+	// Status - статус публикации после сохранения
+	Status domain.BenefitStatus `json:"status"`
 }
 
-// @Summary Create Benefit
-// @Tags Benefits
-// @Description Создать новую льготу
-// @ModuleID createBenefit
-// @Accept  json
-// @Produce  json
-// @Param input body createBenefitRequest true "Данные льготы"
-// @Success 201 {object} createBenefitResponse
-// @Failure 400 {object} ErrorStruct
-// @Failure 500 {object} ErrorStruct
-// @Router /benefits [post]
-func (h *Handler) createBenefit(c *gin.Context) {
-	var req createBenefitRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("invalid request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
-		return
-	}
-
+// benefitFromRequest проверяет данные льготы и собирает из них domain.Benefit.
+// Текст ошибки возвращается клиенту как есть
+func benefitFromRequest(req *createBenefitRequest) (*domain.Benefit, error) {
 	// Валидация типа льготы
-	validTypes := map[string]bool{
-		string(domain.Federal):    true,
-		string(domain.Regional):   true,
-		string(domain.Commercial): true,
-	}
-	if !validTypes[req.Type] {
-		logger.Error("invalid benefit type", zap.String("type", req.Type))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit type. Valid values: federal, regional, commercial"})
-		return
+	if !domain.BenefitLevel(req.Type).IsValid() {
+		return nil, errors.New("invalid benefit type. Valid values: federal, regional, commercial")
 	}
 
 	// Валидация групп
-	validGroups := map[string]bool{
-		string(domain.Pensioners):    true,
-		string(domain.Disabled):      true,
-		string(domain.YoungFamilies): true,
-		string(domain.LowIncome):     true,
-		string(domain.Students):      true,
-		string(domain.LargeFamilies): true,
-		string(domain.Children):      true,
-		string(domain.Veterans):      true,
-	}
 	for _, group := range req.TargetGroups {
-		if !validGroups[group] {
-			logger.Error("invalid target group", zap.String("group", group))
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid target group: %s", group)})
-			return
+		if !domain.TargetGroup(group).IsValid() {
+			return nil, fmt.Errorf("invalid target group: %s", group)
 		}
 	}
 
 	// Валидация категории (если указана)
-	if req.Category != nil {
-		validCategories := map[string]bool{
-			string(domain.Medicine):  true,
-			string(domain.Transport): true,
-			string(domain.Food):      true,
-			string(domain.Clothing):  true,
-			string(domain.Education): true,
-			string(domain.Payments):  true,
-			string(domain.Other):     true,
-		}
-		if !validCategories[*req.Category] {
-			logger.Error("invalid category", zap.String("category", *req.Category))
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid category: %s", *req.Category)})
-			return
+	if req.Category != nil && !domain.Category(*req.Category).IsValid() {
+		return nil, fmt.Errorf("invalid category: %s", *req.Category)
+	}
+
+	if req.EligibilityRules != nil {
+		if err := req.EligibilityRules.Validate(); err != nil {
+			return nil, err
 		}
 	}
 
@@ -910,9 +924,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 	if req.ValidFrom != nil && *req.ValidFrom != "" {
 		parsed, err := time.Parse("2006-01-02", *req.ValidFrom)
 		if err != nil {
-			logger.Error("invalid valid_from date format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid valid_from date format. Use YYYY-MM-DD"})
-			return
+			return nil, errors.New("invalid valid_from date format. Use YYYY-MM-DD")
 		}
 		validFrom = &parsed
 	}
@@ -921,9 +933,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 	if req.ValidTo != nil && *req.ValidTo != "" {
 		parsed, err := time.Parse("2006-01-02", *req.ValidTo)
 		if err != nil {
-			logger.Error("invalid valid_to date format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid valid_to date format. Use YYYY-MM-DD"})
-			return
+			return nil, errors.New("invalid valid_to date format. Use YYYY-MM-DD")
 		}
 		validTo = &parsed
 	}
@@ -945,9 +955,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 	if req.CityID != nil && *req.CityID != "" {
 		parsedCityID, err := uuid.Parse(*req.CityID)
 		if err != nil {
-			logger.Error("invalid city_id format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid city_id format"})
-			return
+			return nil, errors.New("invalid city_id format")
 		}
 		cityID = &parsedCityID
 	}
@@ -957,9 +965,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 	if req.OrganizationID != nil && *req.OrganizationID != "" {
 		parsedOrgID, err := uuid.Parse(*req.OrganizationID)
 		if err != nil {
-			logger.Error("invalid organization_id format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id format"})
-			return
+			return nil, errors.New("invalid organization_id format")
 		}
 		organizationID = &parsedOrgID
 	}
@@ -971,16 +977,14 @@ func (h *Handler) createBenefit(c *gin.Context) {
 		category = &cat
 	}
 
-	// Обработка Region - если не указан, используем пустой массив
+	// Region остается nil, если не указан: при создании подставляется пустой массив,
+	// при обновлении сохраняется текущее значение
 	var region domain.RegionList
 	if req.Region != nil {
 		region = domain.RegionList(req.Region)
-	} else {
-		region = domain.RegionList{}
 	}
 
-	// Создание объекта Benefit
-	benefit := &domain.Benefit{
+	return &domain.Benefit{
 		Title:          req.Title,
 		Description:    req.Description,
 		ValidFrom:      validFrom,
@@ -996,12 +1000,80 @@ func (h *Handler) createBenefit(c *gin.Context) {
 		HowToUse:       req.HowToUse,
 		SourceURL:      req.SourceURL,
 		Tags:           tags,
-		Views:          0,
 		OrganizationID: organizationID,
+
+		EligibilityRules: req.EligibilityRules,
+	}, nil
+}
+
+// applyBenefitChanges переносит в существующую льготу поля, которые можно изменить через API
+func applyBenefitChanges(existing *domain.Benefit, changes *domain.Benefit) {
+	existing.Title = changes.Title
+	existing.Description = changes.Description
+	existing.ValidFrom = changes.ValidFrom
+	existing.ValidTo = changes.ValidTo
+	existing.Type = changes.Type
+	existing.TargetGroupIDs = changes.TargetGroupIDs
+	existing.Longitude = changes.Longitude
+	existing.Latitude = changes.Latitude
+	existing.CityID = changes.CityID
+	// Обработка Region - если не указан, используем существующее значение или пустой массив
+	if changes.Region != nil {
+		existing.Region = changes.Region
+	} else if existing.Region == nil {
+		existing.Region = domain.RegionList{}
+	}
+	existing.Category = changes.Category
+	existing.Requirement = changes.Requirement
+	existing.HowToUse = changes.HowToUse
+	existing.SourceURL = changes.SourceURL
+	existing.Tags = changes.Tags
+	existing.OrganizationID = changes.OrganizationID
+	existing.EligibilityRules = changes.EligibilityRules
+}
+
+// @Summary Create Benefit
+// @Tags Benefits
+// @Description Создать новую льготу. Льгота создается черновиком и не видна гражданам до публикации через POST /benefits/{id}/status
+// @ModuleID createBenefit
+// @Accept  json
+// @Produce  json
+// @Param input body createBenefitRequest true "Данные льготы"
+// @Success 201 {object} createBenefitResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
+// @Router /benefits [post]
+func (h *Handler) createBenefit(c *gin.Context) {
+	var req createBenefitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	benefit, err := benefitFromRequest(&req)
+	if err != nil {
+		logger.Error("invalid benefit request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Менеджер организации может создавать льготы только своей организации
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	// Если регион не указан, используем пустой массив
+	if benefit.Region == nil {
+		benefit.Region = domain.RegionList{}
 	}
 
 	// Создание льготы через сервис
-	if err := h.services.Benefits.Create(c.Request.Context(), benefit); err != nil {
+	if err := h.services.Benefits.Create(c.Request.Context(), benefit, h.benefitAuthor(c)); err != nil {
 		logger.Error("failed to create benefit", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create benefit"})
 		return
@@ -1010,6 +1082,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 	response := createBenefitResponse{
 		ID:        benefit.ID.String(),
 		CreatedAt: benefit.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Status:    benefit.Status,
 	}
 
 	c.JSON(http.StatusCreated, response)
@@ -1017,7 +1090,7 @@ func (h *Handler) createBenefit(c *gin.Context) {
 
 // @Summary Update Benefit
 // @Tags Benefits
-// @Description Обновить существующую льготу
+// @Description Обновить существующую льготу. Без права публикации опубликованная льгота возвращается на проверку (in_review)
 // @ModuleID updateBenefit
 // @Accept  json
 // @Produce  json
@@ -1025,8 +1098,11 @@ func (h *Handler) createBenefit(c *gin.Context) {
 // @Param input body createBenefitRequest true "Данные льготы"
 // @Success 200 {object} createBenefitResponse
 // @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
 // @Failure 404 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
 // @Router /benefits/{id} [put]
 func (h *Handler) updateBenefit(c *gin.Context) {
 	id := c.Param("id")
@@ -1051,6 +1127,12 @@ func (h *Handler) updateBenefit(c *gin.Context) {
 		return
 	}
 
+	// Менеджер организации может изменять только льготы своей организации
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, existingBenefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
 	logger.Info("benefit loaded for update",
 		zap.String("id", id),
 		zap.String("title", existingBenefit.Title),
@@ -1065,146 +1147,23 @@ func (h *Handler) updateBenefit(c *gin.Context) {
 
 	logger.Info("request parsed", zap.String("id", id), zap.String("title", req.Title))
 
-	// Валидация типа льготы
-	validTypes := map[string]bool{
-		string(domain.Federal):    true,
-		string(domain.Regional):   true,
-		string(domain.Commercial): true,
-	}
-	if !validTypes[req.Type] {
-		logger.Error("invalid benefit type", zap.String("type", req.Type))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit type. Valid values: federal, regional, commercial"})
+	benefit, err := benefitFromRequest(&req)
+	if err != nil {
+		logger.Error("invalid benefit request", zap.Error(err), zap.String("id", id))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Валидация групп
-	validGroups := map[string]bool{
-		string(domain.Pensioners):    true,
-		string(domain.Disabled):      true,
-		string(domain.YoungFamilies): true,
-		string(domain.LowIncome):     true,
-		string(domain.Students):      true,
-		string(domain.LargeFamilies): true,
-		string(domain.Children):      true,
-		string(domain.Veterans):      true,
-	}
-	for _, group := range req.TargetGroups {
-		if !validGroups[group] {
-			logger.Error("invalid target group", zap.String("group", group))
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid target group: %s", group)})
-			return
-		}
-	}
-
-	// Валидация категории (если указана)
-	if req.Category != nil {
-		validCategories := map[string]bool{
-			string(domain.Medicine):  true,
-			string(domain.Transport): true,
-			string(domain.Food):      true,
-			string(domain.Clothing):  true,
-			string(domain.Education): true,
-			string(domain.Payments):  true,
-			string(domain.Other):     true,
-		}
-		if !validCategories[*req.Category] {
-			logger.Error("invalid category", zap.String("category", *req.Category))
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid category: %s", *req.Category)})
-			return
-		}
-	}
-
-	// Парсинг дат
-	var validFrom *time.Time
-	if req.ValidFrom != nil && *req.ValidFrom != "" {
-		parsed, err := time.Parse("2006-01-02", *req.ValidFrom)
-		if err != nil {
-			logger.Error("invalid valid_from date format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid valid_from date format. Use YYYY-MM-DD"})
-			return
-		}
-		validFrom = &parsed
-	}
-
-	var validTo *time.Time
-	if req.ValidTo != nil && *req.ValidTo != "" {
-		parsed, err := time.Parse("2006-01-02", *req.ValidTo)
-		if err != nil {
-			logger.Error("invalid valid_to date format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid valid_to date format. Use YYYY-MM-DD"})
-			return
-		}
-		validTo = &parsed
-	}
-
-	// Конвертация групп
-	targetGroups := make(domain.TargetGroupList, 0, len(req.TargetGroups))
-	for _, group := range req.TargetGroups {
-		targetGroups = append(targetGroups, domain.TargetGroup(group))
-	}
-
-	// Конвертация тегов
-	tags := make(domain.BenefitTagList, 0, len(req.Tags))
-	for _, tag := range req.Tags {
-		tags = append(tags, domain.BenefitTag(tag))
-	}
-
-	// Конвертация CityID
-	var cityID *uuid.UUID
-	if req.CityID != nil && *req.CityID != "" {
-		parsedCityID, err := uuid.Parse(*req.CityID)
-		if err != nil {
-			logger.Error("invalid city_id format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid city_id format"})
-			return
-		}
-		cityID = &parsedCityID
-	}
-
-	// Конвертация OrganizationID
-	var organizationID *uuid.UUID
-	if req.OrganizationID != nil && *req.OrganizationID != "" {
-		parsedOrgID, err := uuid.Parse(*req.OrganizationID)
-		if err != nil {
-			logger.Error("invalid organization_id format", zap.Error(err))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id format"})
-			return
-		}
-		organizationID = &parsedOrgID
-	}
-
-	// Конвертация категории
-	var category *domain.Category
-	if req.Category != nil && *req.Category != "" {
-		cat := domain.Category(*req.Category)
-		category = &cat
+	// и не может передать льготу другой организации
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
 	}
 
-	// Обновление объекта Benefit
-	existingBenefit.Title = req.Title
-	existingBenefit.Description = req.Description
-	existingBenefit.ValidFrom = validFrom
-	existingBenefit.ValidTo = validTo
-	existingBenefit.Type = domain.BenefitLevel(req.Type)
-	existingBenefit.TargetGroupIDs = targetGroups
-	existingBenefit.Longitude = req.Longitude
-	existingBenefit.Latitude = req.Latitude
-	existingBenefit.CityID = cityID
-	// Обработка Region - если не указан, используем существующее значение или пустой массив
-	if req.Region != nil {
-		existingBenefit.Region = domain.RegionList(req.Region)
-	} else if existingBenefit.Region == nil {
-		existingBenefit.Region = domain.RegionList{}
-	}
-	existingBenefit.Category = category
-	existingBenefit.Requirement = req.Requirement
-	existingBenefit.HowToUse = req.HowToUse
-	existingBenefit.SourceURL = req.SourceURL
-	existingBenefit.Tags = tags
-	existingBenefit.OrganizationID = organizationID
+	applyBenefitChanges(existingBenefit, benefit)
 
 	// Обновление льготы через сервис
-	if err := h.services.Benefits.Update(c.Request.Context(), existingBenefit); err != nil {
+	if err := h.services.Benefits.Update(c.Request.Context(), existingBenefit, h.benefitAuthor(c)); err != nil {
 		logger.Error("failed to update benefit",
 			zap.Error(err),
 			zap.String("benefit_id", id),
@@ -1218,6 +1177,7 @@ func (h *Handler) updateBenefit(c *gin.Context) {
 	response := createBenefitResponse{
 		ID:        existingBenefit.ID.String(),
 		CreatedAt: existingBenefit.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Status:    existingBenefit.Status,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -1232,8 +1192,11 @@ func (h *Handler) updateBenefit(c *gin.Context) {
 // @Param id path string true "Benefit ID (UUID)"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
 // @Failure 404 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
 // @Router /benefits/{id} [delete]
 func (h *Handler) deleteBenefit(c *gin.Context) {
 	id := c.Param("id")
@@ -1243,7 +1206,25 @@ func (h *Handler) deleteBenefit(c *gin.Context) {
 		return
 	}
 
-	err := h.services.Benefits.Delete(c.Request.Context(), id)
+	benefit, err := h.services.Benefits.GetByIDWithoutIncrement(c.Request.Context(), id, nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			logger.Error("benefit not found", zap.String("id", id))
+			c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+			return
+		}
+		logger.Error("failed to get benefit", zap.Error(err), zap.String("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get benefit"})
+		return
+	}
+
+	// Менеджер организации может удалять только льготы своей организации
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	err = h.services.Benefits.Delete(c.Request.Context(), id, h.benefitAuthor(c))
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			logger.Error("benefit not found", zap.String("id", id))
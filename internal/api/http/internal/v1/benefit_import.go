@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// @Summary Import Benefits
+// @Tags Admin
+// @Description Загрузить льготы из CSV, XLSX или JSON. Поля - как при создании льготы, плюс обязательный external_id
+// @Description и названия city и organization вместо city_id и organization_id. В CSV и XLSX первая строка - названия колонок,
+// @Description списки target_groups, region и tags перечисляются через ";", eligibility_rules - JSON объект. JSON - массив объектов.
+// @Description Льгота с уже загруженным external_id обновляется (без права публикации опубликованная льгота возвращается на проверку),
+// @Description новая создается черновиком. Строки с ошибками пропускаются,
+// @Description остальные сохраняются одной транзакцией. С dry_run=true файл только проверяется.
+// @Description Менеджер организации должен указать organization_id своей организации: льготы без организации получают ее
+// @ModuleID importBenefits
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "Файл импорта"
+// @Param format formData string false "csv, xlsx или json, по умолчанию по расширению файла"
+// @Param dry_run query bool false "Только проверить файл и вернуть отчет"
+// @Param organization_id query string false "UUID организации"
+// @Success 200 {object} service.BenefitImportReport
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/benefits/import [post]
+func (h *Handler) importBenefits(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	var organizationID *uuid.UUID
+	if organizationParam := c.Query("organization_id"); organizationParam != "" {
+		id, err := uuid.Parse(organizationParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+			return
+		}
+		organizationID = &id
+	}
+	// Без organization_id в файле могут быть льготы любых организаций
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, organizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	maxSize := h.config.Benefits.ImportMaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, BenefitImportTooLargeCode)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	format := service.BenefitImportFormat(c.PostForm("format"))
+	if format == "" {
+		format = service.BenefitImportFormatFromFileName(fileHeader.Filename)
+	}
+	if !format.IsValid() {
+		errorResponse(c, InvalidBenefitImportFormatCode)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("open uploaded file failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	defer file.Close()
+
+	rows, err := service.ParseBenefitImport(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.services.BenefitImport.Import(c.Request.Context(), rows, service.BenefitImportOptions{
+		DryRun:         dryRun,
+		OrganizationID: organizationID,
+		Author:         h.benefitAuthor(c),
+	})
+	if err != nil {
+		logger.Error("import benefits failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	logger.Info("benefits imported",
+		zap.String("file_name", fileHeader.Filename),
+		zap.Bool("dry_run", report.DryRun),
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+		zap.Int("unchanged", report.Unchanged),
+		zap.Int("failed", report.Failed))
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type benefitRevisionDiffResponse struct {
+	From    int                         `json:"from"`
+	To      int                         `json:"to"`
+	Changes []domain.BenefitFieldChange `json:"changes"`
+}
+
+// @Summary Get Benefit Revisions
+// @Tags Admin
+// @Description История изменений льготы, новые версии первыми. Версия хранит все редактируемые поля льготы после изменения
+// @Description и автора: сотрудника (author_user_id) или API ключ партнера (author_api_key_id).
+// @Description Действие: create, update, delete (последнее состояние перед удалением), restore (restored_from - номер восстановленной версии).
+// @Description История доступна и для удаленных льгот. Изменения до появления истории не сохранены
+// @ModuleID getBenefitRevisions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Success 200 {array} domain.BenefitRevision
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/benefits/{id}/revisions [get]
+func (h *Handler) getBenefitRevisions(c *gin.Context) {
+	_, revisions, ok := h.getManagedBenefitRevisions(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// @Summary Diff Benefit Revisions
+// @Tags Admin
+// @Description Поля, которые отличаются в версиях from и to, со значениями в обеих версиях.
+// @Description Порядок версий не важен: from может быть новее to
+// @ModuleID diffBenefitRevisions
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Param from query int true "Номер первой версии"
+// @Param to query int true "Номер второй версии"
+// @Success 200 {object} benefitRevisionDiffResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/benefits/{id}/revisions/diff [get]
+func (h *Handler) diffBenefitRevisions(c *gin.Context) {
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil || from < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from version"})
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil || to < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to version"})
+		return
+	}
+
+	benefit, _, ok := h.getManagedBenefitRevisions(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.services.Benefits.DiffRevisions(c.Request.Context(), benefit.ID, from, to)
+	if err != nil {
+		h.benefitRevisionErrorResponse(c, benefit.ID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, benefitRevisionDiffResponse{
+		From:    from,
+		To:      to,
+		Changes: changes,
+	})
+}
+
+// @Summary Restore Benefit Revision
+// @Tags Admin
+// @Description Вернуть льготе поля из выбранной версии. Удаленная льгота восстанавливается.
+// @Description Восстановление сохраняется новой версией с action=restore, прежние версии не изменяются.
+// @Description Менеджер организации может восстановить только версию, в которой льгота принадлежит его организации.
+// @Description Без права публикации опубликованная льгота после восстановления возвращается на проверку
+// @ModuleID restoreBenefitRevision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Param version path int true "Номер версии"
+// @Success 200 {object} domain.BenefitRevision
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/benefits/{id}/revisions/{version}/restore [post]
+func (h *Handler) restoreBenefitRevision(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return
+	}
+
+	benefit, revisions, ok := h.getManagedBenefitRevisions(c)
+	if !ok {
+		return
+	}
+
+	var source *domain.BenefitRevision
+	for i := range revisions {
+		if revisions[i].Version == version {
+			source = &revisions[i]
+			break
+		}
+	}
+	if source == nil {
+		errorResponse(c, BenefitRevisionNotFoundCode)
+		return
+	}
+
+	// Восстановление не должно передавать льготу организации, которой управлять нельзя
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, source.Snapshot.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	_, revision, err := h.services.Benefits.RestoreRevision(c.Request.Context(), benefit.ID, version, h.benefitAuthor(c))
+	if err != nil {
+		h.benefitRevisionErrorResponse(c, benefit.ID, err)
+		return
+	}
+
+	logger.Info("benefit revision restored",
+		zap.String("benefit_id", benefit.ID.String()),
+		zap.Int("restored_from", version),
+		zap.Int("version", revision.Version))
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// getManagedBenefitRevisions загружает льготу из пути вместе с историей и проверяет,
+// что сотрудник может управлять льготами ее организации
+func (h *Handler) getManagedBenefitRevisions(c *gin.Context) (*domain.Benefit, []domain.BenefitRevision, bool) {
+	benefitID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit id"})
+		return nil, nil, false
+	}
+
+	benefit, revisions, err := h.services.Benefits.GetRevisions(c.Request.Context(), benefitID)
+	if err != nil {
+		h.benefitRevisionErrorResponse(c, benefitID, err)
+		return nil, nil, false
+	}
+
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return nil, nil, false
+	}
+
+	return benefit, revisions, true
+}
+
+func (h *Handler) benefitRevisionErrorResponse(c *gin.Context, benefitID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, service.ErrBenefitNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+	case errors.Is(err, service.ErrBenefitRevisionNotFound):
+		errorResponse(c, BenefitRevisionNotFoundCode)
+	default:
+		logger.Error("benefit revision request failed", zap.String("benefit_id", benefitID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// benefitAuthor - автор изменения льготы через админские эндпоинты
+func (h *Handler) benefitAuthor(c *gin.Context) domain.BenefitAuthor {
+	author := domain.BenefitAuthor{CanPublish: h.hasPermission(c, domain.PermissionBenefitsPublish)}
+	if userID, err := h.getUserUUID(c); err == nil {
+		author.UserID = &userID
+	}
+	return author
+}
+
+// partnerBenefitAuthor - автор изменения льготы через партнерский API
+func partnerBenefitAuthor(key *domain.PartnerAPIKey) domain.BenefitAuthor {
+	return domain.BenefitAuthor{APIKeyID: &key.ID}
+}
@@ -0,0 +1,277 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type changeBenefitStatusRequest struct {
+	Status domain.BenefitStatus `json:"status" binding:"required"`
+	// PublishAt - отложенная публикация льготы на проверке
+	PublishAt *time.Time `json:"publish_at"`
+	// UnpublishAt - когда перевести опубликованную льготу в архив
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+type benefitStatusResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Status      domain.BenefitStatus `json:"status"`
+	PublishAt   *time.Time           `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time           `json:"unpublish_at,omitempty"`
+}
+
+// @Summary Change Benefit Status
+// @Tags Benefits
+// @Description Перевести льготу в другой статус публикации. Переходы: draft -> in_review, in_review -> draft,
+// @Description in_review -> published, published -> archived, archived -> draft, archived -> published.
+// @Description Публиковать и задавать publish_at/unpublish_at могут только роли с правом публикации (content_editor, administrator).
+// @Description Если при публикации publish_at в будущем, льгота остается на проверке и публикуется планировщиком.
+// @Description Повторная публикация опубликованной льготы меняет только unpublish_at. Правка льготы на проверке без права публикации отменяет запланированную публикацию
+// @ModuleID changeBenefitStatus
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Param input body changeBenefitStatusRequest true "Новый статус"
+// @Success 200 {object} benefitStatusResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /benefits/{id}/status [post]
+func (h *Handler) changeBenefitStatus(c *gin.Context) {
+	var req changeBenefitStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	benefit, ok := h.getManagedBenefit(c)
+	if !ok {
+		return
+	}
+
+	h.applyBenefitStatusChange(c, benefit, req, h.benefitAuthor(c))
+}
+
+// @Summary Change Partner Benefit Status
+// @Tags Partner
+// @Description Отправить льготу организации на проверку (draft -> in_review), вернуть в черновики или перевести в архив.
+// @Description Публикует льготы редакция, ключу партнера публикация недоступна
+// @ModuleID changePartnerBenefitStatus
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Param input body changeBenefitStatusRequest true "Новый статус"
+// @Success 200 {object} benefitStatusResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits/{id}/status [post]
+func (h *Handler) changePartnerBenefitStatus(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	var req changeBenefitStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	benefit, ok := h.getPartnerBenefit(c)
+	if !ok {
+		return
+	}
+
+	h.applyBenefitStatusChange(c, benefit, req, partnerBenefitAuthor(key))
+}
+
+// @Summary Preview Benefit
+// @Tags Benefits
+// @Description Карточка льготы в любом статусе публикации, в том числе черновика. Просмотр не увеличивает счетчик просмотров
+// @ModuleID previewBenefit
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Success 200 {object} benefitResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /benefits/{id}/preview [get]
+func (h *Handler) previewBenefit(c *gin.Context) {
+	benefit, ok := h.getManagedBenefit(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, newBenefitDetailsResponse(benefit))
+}
+
+// @Summary Admin Benefits
+// @Tags Admin
+// @Description Льготы в любом статусе публикации, например очередь на проверку (status=in_review).
+// @Description Менеджер организации должен указать organization_id своей организации
+// @ModuleID getAdminBenefits
+// @Accept  json
+// @Produce  json
+// @Param status query string false "Статусы через запятую (draft, in_review, published, archived), по умолчанию все"
+// @Param organization_id query string false "UUID организации"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество на странице (до 100)"
+// @Success 200 {object} benefitsListResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/benefits [get]
+func (h *Handler) getAdminBenefits(c *gin.Context) {
+	page := 1
+	limit := 10
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	filters := &service.BenefitFilters{
+		Statuses: domain.BenefitStatuses(),
+	}
+	if statusParam := c.Query("status"); statusParam != "" {
+		filters.Statuses = nil
+		for _, value := range strings.Split(statusParam, ",") {
+			status := domain.BenefitStatus(strings.TrimSpace(value))
+			if !status.IsValid() {
+				errorResponse(c, InvalidBenefitStatusCode)
+				return
+			}
+			filters.Statuses = append(filters.Statuses, status)
+		}
+	}
+
+	var organizationID *uuid.UUID
+	if organizationParam := c.Query("organization_id"); organizationParam != "" {
+		id, err := uuid.Parse(organizationParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+			return
+		}
+		organizationID = &id
+		organizationIDStr := id.String()
+		filters.OrganizationID = &organizationIDStr
+	}
+	// Без organization_id выборка идет по всем организациям, это доступно только ролям без ограничения организацией
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, organizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	benefits, total, err := h.services.Benefits.GetAll(c.Request.Context(), page, limit, filters)
+	if err != nil {
+		logger.Error("failed to get admin benefits", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get benefits"})
+		return
+	}
+
+	response := benefitsListResponse{
+		Benefits: make([]benefitResponse, 0, len(benefits)),
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}
+	for _, benefit := range benefits {
+		response.Benefits = append(response.Benefits, newBenefitResponse(benefit))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) applyBenefitStatusChange(c *gin.Context, benefit *domain.Benefit, req changeBenefitStatusRequest, author domain.BenefitAuthor) {
+	from := benefit.Status
+	change := service.BenefitStatusChange{
+		Status:      req.Status,
+		PublishAt:   req.PublishAt,
+		UnpublishAt: req.UnpublishAt,
+	}
+	if err := h.services.Benefits.ChangeStatus(c.Request.Context(), benefit, change, author); err != nil {
+		h.benefitStatusErrorResponse(c, benefit.ID, err)
+		return
+	}
+
+	logger.Info("benefit status changed",
+		zap.String("benefit_id", benefit.ID.String()),
+		zap.String("from", string(from)),
+		zap.String("to", string(benefit.Status)),
+		zap.Any("publish_at", benefit.PublishAt),
+		zap.Any("unpublish_at", benefit.UnpublishAt))
+
+	c.JSON(http.StatusOK, benefitStatusResponse{
+		ID:          benefit.ID,
+		Status:      benefit.Status,
+		PublishAt:   benefit.PublishAt,
+		UnpublishAt: benefit.UnpublishAt,
+	})
+}
+
+// getManagedBenefit загружает льготу из пути в любом статусе публикации и проверяет,
+// что сотрудник может управлять льготами ее организации. При ошибке ответ уже записан
+func (h *Handler) getManagedBenefit(c *gin.Context) (*domain.Benefit, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit id"})
+		return nil, false
+	}
+
+	benefit, err := h.services.Benefits.GetByIDWithoutIncrement(c.Request.Context(), id.String(), nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+			return nil, false
+		}
+		logger.Error("failed to get benefit", zap.Error(err), zap.String("id", id.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get benefit"})
+		return nil, false
+	}
+
+	if !h.canManageOrganization(c, domain.PermissionBenefitsManage, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return nil, false
+	}
+
+	return benefit, true
+}
+
+func (h *Handler) benefitStatusErrorResponse(c *gin.Context, benefitID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidBenefitStatus):
+		errorResponse(c, InvalidBenefitStatusCode)
+	case errors.Is(err, service.ErrBenefitStatusTransition):
+		errorResponse(c, BenefitStatusTransitionCode)
+	case errors.Is(err, service.ErrBenefitPublishForbidden):
+		forbiddenErrorResponse(c, BenefitPublishForbiddenCode)
+	case errors.Is(err, service.ErrInvalidBenefitSchedule):
+		errorResponse(c, InvalidBenefitScheduleCode)
+	case errors.Is(err, service.ErrBenefitStatusChanged):
+		errorResponse(c, BenefitStatusChangedCode)
+	default:
+		logger.Error("change benefit status failed", zap.String("benefit_id", benefitID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type requestEmailChangeRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type requestPhoneChangeRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required,phonenumber"`
+}
+
+type confirmContactChangeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type contactChangeChallengeResponse struct {
+	// ExpiresAt - до какого времени действует отправленный код
+	ExpiresAt time.Time `json:"expires_at"`
+	// ResendAfter - с какого времени можно запросить новый код
+	ResendAfter time.Time `json:"resend_after"`
+}
+
+type contactsResponse struct {
+	Email            *string    `json:"email"`
+	PhoneNumber      *string    `json:"phone_number"`
+	EmailConfirmedAt *time.Time `json:"email_confirmed_at"`
+	PhoneConfirmedAt *time.Time `json:"phone_confirmed_at"`
+}
+
+// @Summary Request Email Change
+// @Tags Users
+// @Description Отправить код подтверждения на новый email. Код действует AUTH_CONTACT_CODE_TTL, новый код можно запросить
+// @Description не раньше resend_after и не больше AUTH_CONTACT_CODE_MAX_PER_HOUR раз в час
+// @ModuleID requestEmailChange
+// @Accept  json
+// @Produce  json
+// @Param input body requestEmailChangeRequest true "Новый email"
+// @Success 202 {object} contactChangeChallengeResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 429 {object} ErrorStruct
+// @Failure 500
+// @Security UserAuth
+// @Router /users/contacts/email [post]
+func (h *Handler) requestEmailChange(c *gin.Context) {
+	var req requestEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.contactBindErrorResponse(c, err)
+		return
+	}
+
+	h.requestContactChange(c, domain.ContactTypeEmail, req.Email)
+}
+
+// @Summary Confirm Email Change
+// @Tags Users
+// @Description Подтвердить новый email кодом из письма. После нескольких неверных кодов код сбрасывается.
+// @Description Подтвержденный email больше не перезаписывается данными Госуслуг при входе
+// @ModuleID confirmEmailChange
+// @Accept  json
+// @Produce  json
+// @Param input body confirmContactChangeRequest true "Код подтверждения"
+// @Success 200 {object} contactsResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/contacts/email/confirm [post]
+func (h *Handler) confirmEmailChange(c *gin.Context) {
+	h.confirmContactChange(c, domain.ContactTypeEmail)
+}
+
+// @Summary Request Phone Change
+// @Tags Users
+// @Description Отправить код подтверждения по SMS на новый номер телефона в формате 7XXXXXXXXXX.
+// @Description Ограничения на повторную отправку такие же, как у email
+// @ModuleID requestPhoneChange
+// @Accept  json
+// @Produce  json
+// @Param input body requestPhoneChangeRequest true "Новый номер телефона"
+// @Success 202 {object} contactChangeChallengeResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 429 {object} ErrorStruct
+// @Failure 500
+// @Security UserAuth
+// @Router /users/contacts/phone [post]
+func (h *Handler) requestPhoneChange(c *gin.Context) {
+	var req requestPhoneChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.contactBindErrorResponse(c, err)
+		return
+	}
+
+	h.requestContactChange(c, domain.ContactTypePhone, req.PhoneNumber)
+}
+
+// @Summary Confirm Phone Change
+// @Tags Users
+// @Description Подтвердить новый номер телефона кодом из SMS.
+// @Description Подтвержденный номер больше не перезаписывается данными Госуслуг при входе
+// @ModuleID confirmPhoneChange
+// @Accept  json
+// @Produce  json
+// @Param input body confirmContactChangeRequest true "Код подтверждения"
+// @Success 200 {object} contactsResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/contacts/phone/confirm [post]
+func (h *Handler) confirmPhoneChange(c *gin.Context) {
+	h.confirmContactChange(c, domain.ContactTypePhone)
+}
+
+func (h *Handler) requestContactChange(c *gin.Context, contactType domain.ContactType, value string) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	challenge, err := h.services.Contacts.RequestChange(c.Request.Context(), userID, contactType, value)
+	if err != nil {
+		h.contactErrorResponse(c, userID, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, contactChangeChallengeResponse{
+		ExpiresAt:   challenge.ExpiresAt,
+		ResendAfter: challenge.ResendAfter,
+	})
+}
+
+func (h *Handler) confirmContactChange(c *gin.Context, contactType domain.ContactType) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req confirmContactChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.contactBindErrorResponse(c, err)
+		return
+	}
+
+	user, err := h.services.Contacts.ConfirmChange(c.Request.Context(), userID, contactType, req.Code)
+	if err != nil {
+		h.contactErrorResponse(c, userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contactsResponse{
+		Email:            &user.Email.String,
+		PhoneNumber:      &user.PhoneNumber.String,
+		EmailConfirmedAt: user.EmailConfirmedAt,
+		PhoneConfirmedAt: user.PhoneConfirmedAt,
+	})
+}
+
+func (h *Handler) contactBindErrorResponse(c *gin.Context, err error) {
+	var verr validator.ValidationErrors
+	if errors.As(err, &verr) {
+		validationErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+}
+
+func (h *Handler) contactErrorResponse(c *gin.Context, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidContactValue):
+		errorResponse(c, InvalidContactValueCode)
+	case errors.Is(err, service.ErrContactNotChanged):
+		errorResponse(c, ContactNotChangedCode)
+	case errors.Is(err, service.ErrContactCodeResendTooEarly):
+		tooManyRequestsErrorResponse(c, ContactCodeResendTooEarlyCode, h.config.Auth.ContactChange.ResendInterval)
+	case errors.Is(err, service.ErrContactCodeRateLimited):
+		tooManyRequestsErrorResponse(c, ContactCodeRateLimitedCode, time.Hour)
+	case errors.Is(err, service.ErrContactCodeNotFound):
+		errorResponse(c, ContactCodeNotFoundCode)
+	case errors.Is(err, service.ErrInvalidContactCode):
+		errorResponse(c, InvalidContactCodeCode)
+	case errors.Is(err, service.ErrContactCodeAttemptsExceeded):
+		errorResponse(c, ContactCodeAttemptsExceededCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	default:
+		logger.Error("contact change failed", zap.String("user_id", userID.String()), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
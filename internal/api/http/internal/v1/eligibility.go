@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type eligibilityProfileRequest struct {
+	MonthlyIncome      *int64                     `json:"monthly_income" binding:"omitempty,min=0"`
+	ChildrenCount      *int                       `json:"children_count" binding:"omitempty,min=0,max=30"`
+	DisabilityCategory *domain.DisabilityCategory `json:"disability_category"`
+}
+
+type eligibilityProfileResponse struct {
+	MonthlyIncome      *int64                     `json:"monthly_income"`
+	ChildrenCount      *int                       `json:"children_count"`
+	DisabilityCategory *domain.DisabilityCategory `json:"disability_category"`
+}
+
+type benefitsEligibilityResponse struct {
+	Results []domain.EligibilityResult `json:"results"`
+	Total   int64                      `json:"total"`
+	Page    int                        `json:"page"`
+	Limit   int                        `json:"limit"`
+}
+
+// @Summary Update Eligibility Profile
+// @Tags Users
+// @Description Данные для проверки права на льготы: среднедушевой доход семьи в месяц (руб.), количество детей, категория инвалидности.
+// @Description disability_category: none, group_1, group_2, group_3, child. null - значение не указано, условия льгот по нему получат статус missing_data
+// @ModuleID updateEligibilityProfile
+// @Accept  json
+// @Produce  json
+// @Param input body eligibilityProfileRequest true "Данные профиля"
+// @Success 200 {object} eligibilityProfileResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/eligibility-profile [put]
+func (h *Handler) updateEligibilityProfile(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req eligibilityProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	user, err := h.services.Users.UpdateEligibilityProfile(c.Request.Context(), userID, service.EligibilityProfileInput{
+		MonthlyIncome:      req.MonthlyIncome,
+		ChildrenCount:      req.ChildrenCount,
+		DisabilityCategory: req.DisabilityCategory,
+	})
+	if err != nil {
+		h.eligibilityErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, eligibilityProfileResponse{
+		MonthlyIncome:      user.MonthlyIncome,
+		ChildrenCount:      user.ChildrenCount,
+		DisabilityCategory: user.DisabilityCategory,
+	})
+}
+
+// @Summary Get Benefits Eligibility
+// @Tags Benefits
+// @Description Проверить право пользователя на льготы из списка. Для каждой льготы возвращается status:
+// @Description eligible - все условия выполнены, not_eligible - хотя бы одно условие не выполнено,
+// @Description missing_data - в профиле не хватает данных для проверки. В checks - результат и пояснение по каждому условию
+// @ModuleID getBenefitsEligibility
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Номер страницы (по умолчанию 1)"
+// @Param limit query int false "Количество элементов на странице (по умолчанию 10, максимум 100)"
+// @Success 200 {object} benefitsEligibilityResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /benefits/eligibility [get]
+func (h *Handler) getBenefitsEligibility(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	results, total, err := h.services.Eligibility.CheckBenefits(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		h.eligibilityErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, benefitsEligibilityResponse{
+		Results: results,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+	})
+}
+
+// @Summary Get Benefit Eligibility
+// @Tags Benefits
+// @Description Проверить право пользователя на льготу: целевые группы семьи, возраст, место проживания, доход, количество детей, инвалидность.
+// @Description Возраст и инвалидность проверяются у того члена семьи, чья группа дает право на льготу, доход и количество детей - по профилю пользователя
+// @ModuleID getBenefitEligibility
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Success 200 {object} domain.EligibilityResult
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security UserAuth
+// @Router /benefits/{id}/eligibility [get]
+func (h *Handler) getBenefitEligibility(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit id"})
+		return
+	}
+
+	result, err := h.services.Eligibility.CheckBenefit(c.Request.Context(), userID, id)
+	if err != nil {
+		h.eligibilityErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) eligibilityErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+	case errors.Is(err, service.ErrInvalidDisabilityCategory):
+		errorResponse(c, InvalidDisabilityCategoryCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	default:
+		logger.Error("eligibility request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -16,6 +16,145 @@ const (
 
 	CityNotFoundErrorCode    = 1005
 	CityNotFoundErrorMessage = "city not found"
+
+	UserRefreshTokenInvalidCode    = 1006
+	UserRefreshTokenInvalidMessage = "user refresh token invalid"
+	UserRefreshTokenReusedCode     = 1007
+	UserRefreshTokenReusedMessage  = "user refresh token reused, all sessions of this login are revoked"
+
+	SessionNotFoundCode    = 1008
+	SessionNotFoundMessage = "session not found"
+
+	AccessDeniedCode                = 1009
+	AccessDeniedMessage             = "access denied"
+	InvalidRoleCode                 = 1010
+	InvalidRoleMessage              = "invalid role"
+	RoleOrganizationMismatchCode    = 1011
+	RoleOrganizationMismatchMessage = "organization_id is required for organization_manager role only"
+	RoleAlreadyGrantedCode          = 1012
+	RoleAlreadyGrantedMessage       = "role already granted"
+	RoleNotFoundCode                = 1013
+	RoleNotFoundMessage             = "role not found"
+	OrganizationNotFoundCode        = 1014
+	OrganizationNotFoundMessage     = "organization not found"
+
+	StaffInvalidCredentialsCode      = 1015
+	StaffInvalidCredentialsMessage   = "invalid login or password"
+	StaffAccountLockedCode           = 1016
+	StaffAccountLockedMessage        = "too many failed attempts, account is temporarily locked"
+	StaffMFAChallengeNotFoundCode    = 1017
+	StaffMFAChallengeNotFoundMessage = "mfa token is invalid or expired, sign in again"
+	StaffInvalidTOTPCode             = 1018
+	StaffInvalidTOTPMessage          = "invalid totp code"
+	StaffInvalidRecoveryCodeCode     = 1019
+	StaffInvalidRecoveryCodeMessage  = "invalid recovery code"
+	StaffTOTPAlreadyEnabledCode      = 1020
+	StaffTOTPAlreadyEnabledMessage   = "totp already enabled"
+	StaffTOTPNotEnrolledCode         = 1021
+	StaffTOTPNotEnrolledMessage      = "totp is not enrolled"
+	StaffNotFoundCode                = 1022
+	StaffNotFoundMessage             = "staff account not found"
+	StaffLoginTakenCode              = 1023
+	StaffLoginTakenMessage           = "login already taken"
+
+	UserDeactivatedCode           = 1024
+	UserDeactivatedMessage        = "user is deactivated"
+	UserAlreadyDeactivatedCode    = 1025
+	UserAlreadyDeactivatedMessage = "user already deactivated"
+	UserNotDeactivatedCode        = 1026
+	UserNotDeactivatedMessage     = "user is not deactivated"
+	CannotModifySelfCode          = 1027
+	CannotModifySelfMessage       = "administrator cannot deactivate or delete own account"
+
+	PartnerAPIKeyInvalidCode         = 1028
+	PartnerAPIKeyInvalidMessage      = "api key is invalid, expired or revoked"
+	PartnerAPIKeyScopeMissingCode    = 1029
+	PartnerAPIKeyScopeMissingMessage = "api key does not allow this action"
+	PartnerAPIKeyNotFoundCode        = 1030
+	PartnerAPIKeyNotFoundMessage     = "api key not found"
+	InvalidAPIKeyScopeCode           = 1031
+	InvalidAPIKeyScopeMessage        = "invalid api key scope"
+	InvalidAPIKeyExpiryCode          = 1032
+	InvalidAPIKeyExpiryMessage       = "expires_at must be in the future"
+
+	VerificationDocumentNotFoundCode    = 1033
+	VerificationDocumentNotFoundMessage = "verification document not found"
+	VerificationDocumentReviewedCode    = 1034
+	VerificationDocumentReviewedMessage = "verification document already reviewed"
+	GroupNotAwaitingVerificationCode    = 1035
+	GroupNotAwaitingVerificationMessage = "group is not in the profile or already verified"
+	InvalidDocumentFileCode             = 1036
+	InvalidDocumentFileMessage          = "document must be a pdf, jpeg or png file"
+	DocumentTooLargeCode                = 1037
+	DocumentTooLargeMessage             = "document is too large"
+	RejectReasonRequiredCode            = 1038
+	RejectReasonRequiredMessage         = "reject reason is required"
+	InvalidGroupTypeCode                = 1039
+	InvalidGroupTypeMessage             = "invalid group type"
+	HouseholdMemberNotFoundCode         = 1040
+	HouseholdMemberNotFoundMessage      = "household member not found"
+	HouseholdMemberExistsCode           = 1041
+	HouseholdMemberExistsMessage        = "household member with this snils already added"
+	HouseholdMembersLimitCode           = 1042
+	HouseholdMembersLimitMessage        = "household members limit reached"
+	InvalidHouseholdRelationCode        = 1043
+	InvalidHouseholdRelationMessage     = "invalid relation. Valid values: spouse, child"
+	InvalidSNILSCode                    = 1044
+	InvalidSNILSMessage                 = "invalid snils"
+	HouseholdGroupNotVerifiedCode       = 1045
+	HouseholdGroupNotVerifiedMessage    = "group is not verified for household member"
+	UserDocumentNotFoundCode            = 1046
+	UserDocumentNotFoundMessage         = "user document not found"
+	UserDocumentExistsCode              = 1047
+	UserDocumentExistsMessage           = "user already has a document of this type"
+	InvalidUserDocumentTypeCode         = 1048
+	InvalidUserDocumentTypeMessage      = "invalid document type. Valid values: passport, snils, registration"
+	InvalidUserDocumentNumberCode       = 1049
+	InvalidUserDocumentNumberMessage    = "document number is required"
+	InvalidUserDocumentDatesCode        = 1050
+	InvalidUserDocumentDatesMessage     = "issue date must not be in the future and expiry date must be after issue date"
+	InvalidPassportCode                 = 1051
+	InvalidPassportMessage              = "invalid passport series or number"
+	InvalidDisabilityCategoryCode       = 1052
+	InvalidDisabilityCategoryMessage    = "invalid disability category. Valid values: none, group_1, group_2, group_3, child"
+	QuestionnaireDraftNotFoundCode      = 1053
+	QuestionnaireDraftNotFoundMessage   = "questionnaire draft not found or expired"
+	InvalidContactValueCode             = 1054
+	InvalidContactValueMessage          = "invalid email or phone number. Phone number must be 7XXXXXXXXXX"
+	ContactNotChangedCode               = 1055
+	ContactNotChangedMessage            = "new contact matches the current one"
+	ContactCodeResendTooEarlyCode       = 1056
+	ContactCodeResendTooEarlyMessage    = "confirmation code was sent recently, try again later"
+	ContactCodeRateLimitedCode          = 1057
+	ContactCodeRateLimitedMessage       = "too many confirmation codes requested, try again in an hour"
+	ContactCodeNotFoundCode             = 1058
+	ContactCodeNotFoundMessage          = "confirmation code not found or expired, request a new one"
+	InvalidContactCodeCode              = 1059
+	InvalidContactCodeMessage           = "invalid confirmation code"
+	ContactCodeAttemptsExceededCode     = 1060
+	ContactCodeAttemptsExceededMessage  = "too many invalid confirmation codes, request a new one"
+	BenefitRevisionNotFoundCode         = 1061
+	BenefitRevisionNotFoundMessage      = "benefit revision not found"
+	InvalidBenefitStatusCode            = 1062
+	InvalidBenefitStatusMessage         = "invalid benefit status. Allowed: draft, in_review, published, archived"
+	BenefitStatusTransitionCode         = 1063
+	BenefitStatusTransitionMessage      = "benefit status transition not allowed"
+	BenefitPublishForbiddenCode         = 1064
+	BenefitPublishForbiddenMessage      = "publishing and scheduling benefits requires publish permission"
+	InvalidBenefitScheduleCode          = 1065
+	InvalidBenefitScheduleMessage       = "invalid schedule: publish_at only when publishing a benefit in review, unpublish_at must be after publication"
+	BenefitStatusChangedCode            = 1066
+	BenefitStatusChangedMessage         = "benefit status was changed by someone else, reload the benefit"
+	BenefitImportTooLargeCode           = 1067
+	BenefitImportTooLargeMessage        = "import file is too large"
+	InvalidBenefitImportFormatCode      = 1068
+	InvalidBenefitImportFormatMessage   = "unsupported import format. Valid values: csv, xlsx, json"
+	HouseholdRelationNotVerifiedCode    = 1069
+	HouseholdRelationNotVerifiedMessage = "relation with household member is not confirmed"
+	HouseholdRelationNotAwaitingCode    = 1070
+	HouseholdRelationNotAwaitingMessage = "relation with household member is already confirmed or awaits review"
+	HouseholdMemberClaimedCode          = 1071
+	HouseholdMemberClaimedMessage       = "relation with this snils is already confirmed on other accounts"
 )
 
 type ErrorCode int
@@ -59,6 +198,204 @@ func getErrorStruct(code ErrorCode) *ErrorStruct {
 	case CityNotFoundErrorCode:
 		errorStruct.ErrorCode = CityNotFoundErrorCode
 		errorStruct.ErrorMessage = CityNotFoundErrorMessage
+	case UserRefreshTokenInvalidCode:
+		errorStruct.ErrorCode = UserRefreshTokenInvalidCode
+		errorStruct.ErrorMessage = UserRefreshTokenInvalidMessage
+	case UserRefreshTokenReusedCode:
+		errorStruct.ErrorCode = UserRefreshTokenReusedCode
+		errorStruct.ErrorMessage = UserRefreshTokenReusedMessage
+	case SessionNotFoundCode:
+		errorStruct.ErrorCode = SessionNotFoundCode
+		errorStruct.ErrorMessage = SessionNotFoundMessage
+	case AccessDeniedCode:
+		errorStruct.ErrorCode = AccessDeniedCode
+		errorStruct.ErrorMessage = AccessDeniedMessage
+	case InvalidRoleCode:
+		errorStruct.ErrorCode = InvalidRoleCode
+		errorStruct.ErrorMessage = InvalidRoleMessage
+	case RoleOrganizationMismatchCode:
+		errorStruct.ErrorCode = RoleOrganizationMismatchCode
+		errorStruct.ErrorMessage = RoleOrganizationMismatchMessage
+	case RoleAlreadyGrantedCode:
+		errorStruct.ErrorCode = RoleAlreadyGrantedCode
+		errorStruct.ErrorMessage = RoleAlreadyGrantedMessage
+	case RoleNotFoundCode:
+		errorStruct.ErrorCode = RoleNotFoundCode
+		errorStruct.ErrorMessage = RoleNotFoundMessage
+	case OrganizationNotFoundCode:
+		errorStruct.ErrorCode = OrganizationNotFoundCode
+		errorStruct.ErrorMessage = OrganizationNotFoundMessage
+	case StaffInvalidCredentialsCode:
+		errorStruct.ErrorCode = StaffInvalidCredentialsCode
+		errorStruct.ErrorMessage = StaffInvalidCredentialsMessage
+	case StaffAccountLockedCode:
+		errorStruct.ErrorCode = StaffAccountLockedCode
+		errorStruct.ErrorMessage = StaffAccountLockedMessage
+	case StaffMFAChallengeNotFoundCode:
+		errorStruct.ErrorCode = StaffMFAChallengeNotFoundCode
+		errorStruct.ErrorMessage = StaffMFAChallengeNotFoundMessage
+	case StaffInvalidTOTPCode:
+		errorStruct.ErrorCode = StaffInvalidTOTPCode
+		errorStruct.ErrorMessage = StaffInvalidTOTPMessage
+	case StaffInvalidRecoveryCodeCode:
+		errorStruct.ErrorCode = StaffInvalidRecoveryCodeCode
+		errorStruct.ErrorMessage = StaffInvalidRecoveryCodeMessage
+	case StaffTOTPAlreadyEnabledCode:
+		errorStruct.ErrorCode = StaffTOTPAlreadyEnabledCode
+		errorStruct.ErrorMessage = StaffTOTPAlreadyEnabledMessage
+	case StaffTOTPNotEnrolledCode:
+		errorStruct.ErrorCode = StaffTOTPNotEnrolledCode
+		errorStruct.ErrorMessage = StaffTOTPNotEnrolledMessage
+	case StaffNotFoundCode:
+		errorStruct.ErrorCode = StaffNotFoundCode
+		errorStruct.ErrorMessage = StaffNotFoundMessage
+	case StaffLoginTakenCode:
+		errorStruct.ErrorCode = StaffLoginTakenCode
+		errorStruct.ErrorMessage = StaffLoginTakenMessage
+	case UserDeactivatedCode:
+		errorStruct.ErrorCode = UserDeactivatedCode
+		errorStruct.ErrorMessage = UserDeactivatedMessage
+	case UserAlreadyDeactivatedCode:
+		errorStruct.ErrorCode = UserAlreadyDeactivatedCode
+		errorStruct.ErrorMessage = UserAlreadyDeactivatedMessage
+	case UserNotDeactivatedCode:
+		errorStruct.ErrorCode = UserNotDeactivatedCode
+		errorStruct.ErrorMessage = UserNotDeactivatedMessage
+	case CannotModifySelfCode:
+		errorStruct.ErrorCode = CannotModifySelfCode
+		errorStruct.ErrorMessage = CannotModifySelfMessage
+	case PartnerAPIKeyInvalidCode:
+		errorStruct.ErrorCode = PartnerAPIKeyInvalidCode
+		errorStruct.ErrorMessage = PartnerAPIKeyInvalidMessage
+	case PartnerAPIKeyScopeMissingCode:
+		errorStruct.ErrorCode = PartnerAPIKeyScopeMissingCode
+		errorStruct.ErrorMessage = PartnerAPIKeyScopeMissingMessage
+	case PartnerAPIKeyNotFoundCode:
+		errorStruct.ErrorCode = PartnerAPIKeyNotFoundCode
+		errorStruct.ErrorMessage = PartnerAPIKeyNotFoundMessage
+	case InvalidAPIKeyScopeCode:
+		errorStruct.ErrorCode = InvalidAPIKeyScopeCode
+		errorStruct.ErrorMessage = InvalidAPIKeyScopeMessage
+	case InvalidAPIKeyExpiryCode:
+		errorStruct.ErrorCode = InvalidAPIKeyExpiryCode
+		errorStruct.ErrorMessage = InvalidAPIKeyExpiryMessage
+	case VerificationDocumentNotFoundCode:
+		errorStruct.ErrorCode = VerificationDocumentNotFoundCode
+		errorStruct.ErrorMessage = VerificationDocumentNotFoundMessage
+	case VerificationDocumentReviewedCode:
+		errorStruct.ErrorCode = VerificationDocumentReviewedCode
+		errorStruct.ErrorMessage = VerificationDocumentReviewedMessage
+	case GroupNotAwaitingVerificationCode:
+		errorStruct.ErrorCode = GroupNotAwaitingVerificationCode
+		errorStruct.ErrorMessage = GroupNotAwaitingVerificationMessage
+	case InvalidDocumentFileCode:
+		errorStruct.ErrorCode = InvalidDocumentFileCode
+		errorStruct.ErrorMessage = InvalidDocumentFileMessage
+	case DocumentTooLargeCode:
+		errorStruct.ErrorCode = DocumentTooLargeCode
+		errorStruct.ErrorMessage = DocumentTooLargeMessage
+	case RejectReasonRequiredCode:
+		errorStruct.ErrorCode = RejectReasonRequiredCode
+		errorStruct.ErrorMessage = RejectReasonRequiredMessage
+	case InvalidGroupTypeCode:
+		errorStruct.ErrorCode = InvalidGroupTypeCode
+		errorStruct.ErrorMessage = InvalidGroupTypeMessage
+	case HouseholdMemberNotFoundCode:
+		errorStruct.ErrorCode = HouseholdMemberNotFoundCode
+		errorStruct.ErrorMessage = HouseholdMemberNotFoundMessage
+	case HouseholdMemberExistsCode:
+		errorStruct.ErrorCode = HouseholdMemberExistsCode
+		errorStruct.ErrorMessage = HouseholdMemberExistsMessage
+	case HouseholdMembersLimitCode:
+		errorStruct.ErrorCode = HouseholdMembersLimitCode
+		errorStruct.ErrorMessage = HouseholdMembersLimitMessage
+	case InvalidHouseholdRelationCode:
+		errorStruct.ErrorCode = InvalidHouseholdRelationCode
+		errorStruct.ErrorMessage = InvalidHouseholdRelationMessage
+	case InvalidSNILSCode:
+		errorStruct.ErrorCode = InvalidSNILSCode
+		errorStruct.ErrorMessage = InvalidSNILSMessage
+	case HouseholdGroupNotVerifiedCode:
+		errorStruct.ErrorCode = HouseholdGroupNotVerifiedCode
+		errorStruct.ErrorMessage = HouseholdGroupNotVerifiedMessage
+	case UserDocumentNotFoundCode:
+		errorStruct.ErrorCode = UserDocumentNotFoundCode
+		errorStruct.ErrorMessage = UserDocumentNotFoundMessage
+	case UserDocumentExistsCode:
+		errorStruct.ErrorCode = UserDocumentExistsCode
+		errorStruct.ErrorMessage = UserDocumentExistsMessage
+	case InvalidUserDocumentTypeCode:
+		errorStruct.ErrorCode = InvalidUserDocumentTypeCode
+		errorStruct.ErrorMessage = InvalidUserDocumentTypeMessage
+	case InvalidUserDocumentNumberCode:
+		errorStruct.ErrorCode = InvalidUserDocumentNumberCode
+		errorStruct.ErrorMessage = InvalidUserDocumentNumberMessage
+	case InvalidUserDocumentDatesCode:
+		errorStruct.ErrorCode = InvalidUserDocumentDatesCode
+		errorStruct.ErrorMessage = InvalidUserDocumentDatesMessage
+	case InvalidPassportCode:
+		errorStruct.ErrorCode = InvalidPassportCode
+		errorStruct.ErrorMessage = InvalidPassportMessage
+	case InvalidDisabilityCategoryCode:
+		errorStruct.ErrorCode = InvalidDisabilityCategoryCode
+		errorStruct.ErrorMessage = InvalidDisabilityCategoryMessage
+	case QuestionnaireDraftNotFoundCode:
+		errorStruct.ErrorCode = QuestionnaireDraftNotFoundCode
+		errorStruct.ErrorMessage = QuestionnaireDraftNotFoundMessage
+	case InvalidContactValueCode:
+		errorStruct.ErrorCode = InvalidContactValueCode
+		errorStruct.ErrorMessage = InvalidContactValueMessage
+	case ContactNotChangedCode:
+		errorStruct.ErrorCode = ContactNotChangedCode
+		errorStruct.ErrorMessage = ContactNotChangedMessage
+	case ContactCodeResendTooEarlyCode:
+		errorStruct.ErrorCode = ContactCodeResendTooEarlyCode
+		errorStruct.ErrorMessage = ContactCodeResendTooEarlyMessage
+	case ContactCodeRateLimitedCode:
+		errorStruct.ErrorCode = ContactCodeRateLimitedCode
+		errorStruct.ErrorMessage = ContactCodeRateLimitedMessage
+	case ContactCodeNotFoundCode:
+		errorStruct.ErrorCode = ContactCodeNotFoundCode
+		errorStruct.ErrorMessage = ContactCodeNotFoundMessage
+	case InvalidContactCodeCode:
+		errorStruct.ErrorCode = InvalidContactCodeCode
+		errorStruct.ErrorMessage = InvalidContactCodeMessage
+	case ContactCodeAttemptsExceededCode:
+		errorStruct.ErrorCode = ContactCodeAttemptsExceededCode
+		errorStruct.ErrorMessage = ContactCodeAttemptsExceededMessage
+	case BenefitRevisionNotFoundCode:
+		errorStruct.ErrorCode = BenefitRevisionNotFoundCode
+		errorStruct.ErrorMessage = BenefitRevisionNotFoundMessage
+	case InvalidBenefitStatusCode:
+		errorStruct.ErrorCode = InvalidBenefitStatusCode
+		errorStruct.ErrorMessage = InvalidBenefitStatusMessage
+	case BenefitStatusTransitionCode:
+		errorStruct.ErrorCode = BenefitStatusTransitionCode
+		errorStruct.ErrorMessage = BenefitStatusTransitionMessage
+	case BenefitPublishForbiddenCode:
+		errorStruct.ErrorCode = BenefitPublishForbiddenCode
+		errorStruct.ErrorMessage = BenefitPublishForbiddenMessage
+	case InvalidBenefitScheduleCode:
+		errorStruct.ErrorCode = InvalidBenefitScheduleCode
+		errorStruct.ErrorMessage = InvalidBenefitScheduleMessage
+	case BenefitStatusChangedCode:
+		errorStruct.ErrorCode = BenefitStatusChangedCode
+		errorStruct.ErrorMessage = BenefitStatusChangedMessage
+	case BenefitImportTooLargeCode:
+		errorStruct.ErrorCode = BenefitImportTooLargeCode
+		errorStruct.ErrorMessage = BenefitImportTooLargeMessage
+	case InvalidBenefitImportFormatCode:
+		errorStruct.ErrorCode = InvalidBenefitImportFormatCode
+		errorStruct.ErrorMessage = InvalidBenefitImportFormatMessage
+	case HouseholdRelationNotVerifiedCode:
+		errorStruct.ErrorCode = HouseholdRelationNotVerifiedCode
+		errorStruct.ErrorMessage = HouseholdRelationNotVerifiedMessage
+	case HouseholdRelationNotAwaitingCode:
+		errorStruct.ErrorCode = HouseholdRelationNotAwaitingCode
+		errorStruct.ErrorMessage = HouseholdRelationNotAwaitingMessage
+	case HouseholdMemberClaimedCode:
+		errorStruct.ErrorCode = HouseholdMemberClaimedCode
+		errorStruct.ErrorMessage = HouseholdMemberClaimedMessage
 	}
 
 	return errorStruct
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"github.com/vibe-gaming/backend/internal/authstate"
 	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/esia"
 	"github.com/vibe-gaming/backend/internal/service"
@@ -24,12 +25,17 @@ import (
 // @in header
 // @name Authorization
 
+// @securityDefinitions.apikey PartnerAuth
+// @in header
+// @name X-API-Key
+
 type Handler struct {
 	services       *service.Services
 	tokenManager   auth.TokenManager
 	config         *config.Config
 	esiaClient     *esia.Client
 	gigachatClient *gigachat.Client
+	authStateStore authstate.Store
 }
 
 func NewHandler(
@@ -38,6 +44,7 @@ func NewHandler(
 	config *config.Config,
 	esiaClient *esia.Client,
 	gigachatClient *gigachat.Client,
+	authStateStore authstate.Store,
 ) *Handler {
 	return &Handler{
 		services:       services,
@@ -45,6 +52,7 @@ func NewHandler(
 		config:         config,
 		esiaClient:     esiaClient,
 		gigachatClient: gigachatClient,
+		authStateStore: authStateStore,
 	}
 }
 
@@ -57,4 +65,7 @@ func (h *Handler) Init(api *gin.RouterGroup) {
 	h.initOrganizationsRoutes(v1)
 	h.initSpeechRoutes(v1)
 	h.initAdminRoutes(v1)
+	h.initStaffRoutes(v1)
+	h.initPartnerRoutes(v1)
+	h.initQuestionnaireRoutes(v1)
 }
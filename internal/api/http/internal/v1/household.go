@@ -0,0 +1,360 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type householdMemberResponse struct {
+	ID       uuid.UUID                `json:"id"`
+	Relation domain.HouseholdRelation `json:"relation"`
+	// RelationStatus - подтверждение родства документом: unconfirmed, pending, verified, rejected
+	RelationStatus     domain.HouseholdRelationStatus `json:"relation_status"`
+	RelationVerifiedAt *time.Time                     `json:"relation_verified_at,omitempty"`
+	FirstName          string                         `json:"first_name"`
+	LastName           string                         `json:"last_name"`
+	MiddleName         *string                        `json:"middle_name,omitempty"`
+	BirthDate          *string                        `json:"birth_date,omitempty"`
+	SNILS              string                         `json:"snils"`
+	Groups             domain.UserGroupList           `json:"groups"`
+	CreatedAt          time.Time                      `json:"created_at"`
+}
+
+type householdMembersResponse struct {
+	Members []householdMemberResponse `json:"members"`
+}
+
+type addHouseholdMemberRequest struct {
+	Relation   domain.HouseholdRelation `json:"relation" binding:"required"`
+	FirstName  string                   `json:"first_name" binding:"required,max=255"`
+	LastName   string                   `json:"last_name" binding:"required,max=255"`
+	MiddleName string                   `json:"middle_name" binding:"max=255"`
+	BirthDate  string                   `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+	SNILS      string                   `json:"snils" binding:"required,snils"`
+	Groups     domain.GroupTypeList     `json:"groups"`
+}
+
+type updateHouseholdMemberGroupsRequest struct {
+	Groups domain.GroupTypeList `json:"groups" binding:"required"`
+}
+
+// @Summary Get Household Members
+// @Tags Users
+// @Description Члены семьи пользователя (супруг(а), дети) и статусы проверки их социальных групп.
+// @Description Подтвержденные группы членов семьи учитываются в фильтре filter_by_user_groups, статистике льгот и удостоверениях
+// @Description только после того, как модератор подтвердит родство по документу (relation_status = verified)
+// @ModuleID getHouseholdMembers
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} householdMembersResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/household [get]
+func (h *Handler) getHouseholdMembers(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	members, err := h.services.Household.GetMembers(c.Request.Context(), userID)
+	if err != nil {
+		h.householdErrorResponse(c, err)
+		return
+	}
+
+	response := householdMembersResponse{
+		Members: make([]householdMemberResponse, 0, len(members)),
+	}
+	for i := range members {
+		response.Members = append(response.Members, newHouseholdMemberResponse(&members[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Add Household Member
+// @Tags Users
+// @Description Добавить члена семьи. Выбранные группы отправляются на проверку по СНИЛС члена семьи тем же сервисом, что и группы пользователя.
+// @Description relation: spouse (супруг(а)), child (ребенок). СНИЛС принимается с разделителями или без
+// @ModuleID addHouseholdMember
+// @Accept  json
+// @Produce  json
+// @Param input body addHouseholdMemberRequest true "Данные члена семьи"
+// @Success 201 {object} householdMemberResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/household [post]
+func (h *Handler) addHouseholdMember(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req addHouseholdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	input := service.HouseholdMemberInput{
+		Relation:   req.Relation,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		MiddleName: req.MiddleName,
+		SNILS:      req.SNILS,
+		Groups:     req.Groups,
+	}
+	if req.BirthDate != "" {
+		birthDate, err := time.Parse("2006-01-02", req.BirthDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid birth_date format. Use YYYY-MM-DD"})
+			return
+		}
+		input.BirthDate = &birthDate
+	}
+
+	member, err := h.services.Household.AddMember(c.Request.Context(), userID, input)
+	if err != nil {
+		h.householdErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("household member added",
+		zap.String("user_id", userID.String()),
+		zap.String("member_id", member.ID.String()),
+		zap.String("relation", string(member.Relation)))
+
+	c.JSON(http.StatusCreated, newHouseholdMemberResponse(member))
+}
+
+// @Summary Update Household Member Groups
+// @Tags Users
+// @Description Изменить группы члена семьи. Подтвержденные и ожидающие проверки группы сохраняются,
+// @Description новые, отклоненные, истекшие и непроверенные отправляются на проверку заново
+// @ModuleID updateHouseholdMemberGroups
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Household member ID (UUID)"
+// @Param input body updateHouseholdMemberGroupsRequest true "Группы члена семьи"
+// @Success 200 {object} householdMemberResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/household/{id}/groups [put]
+func (h *Handler) updateHouseholdMemberGroups(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid household member id"})
+		return
+	}
+
+	var req updateHouseholdMemberGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	member, err := h.services.Household.UpdateMemberGroups(c.Request.Context(), userID, memberID, req.Groups)
+	if err != nil {
+		h.householdErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newHouseholdMemberResponse(member))
+}
+
+// @Summary Upload Household Relation Document
+// @Tags Users
+// @Description Загрузить документ, подтверждающий родство с членом семьи: свидетельство о браке для супруга(и),
+// @Description свидетельство о рождении для ребенка. Группы члена семьи учитываются только после одобрения документа модератором.
+// @Description Родство с одним СНИЛС подтверждается не больше чем на одном аккаунте для супруга(и) и на двух для ребенка.
+// @Description Принимаются файлы pdf, jpeg и png
+// @ModuleID uploadHouseholdRelationDocument
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path string true "Household member ID (UUID)"
+// @Param file formData file true "Скан документа"
+// @Success 201 {object} verificationDocumentResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/household/{id}/relation-document [post]
+func (h *Handler) uploadHouseholdRelationDocument(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid household member id"})
+		return
+	}
+
+	maxSize := h.config.Storage.VerificationDocumentMaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, DocumentTooLargeCode)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("open uploaded file failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	defer file.Close()
+
+	document, err := h.services.VerificationDocuments.UploadRelationProof(c.Request.Context(), service.HouseholdRelationProofInput{
+		UserID:   userID,
+		MemberID: memberID,
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		File:     file,
+	})
+	if err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("household relation document uploaded",
+		zap.String("user_id", userID.String()),
+		zap.String("member_id", memberID.String()),
+		zap.String("document_id", document.ID.String()))
+
+	c.JSON(http.StatusCreated, newVerificationDocumentResponse(document))
+}
+
+// @Summary Delete Household Member
+// @Tags Users
+// @Description Удалить члена семьи. Его группы перестают учитываться при подборе льгот
+// @ModuleID deleteHouseholdMember
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Household member ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/household/{id} [delete]
+func (h *Handler) deleteHouseholdMember(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid household member id"})
+		return
+	}
+
+	if err := h.services.Household.DeleteMember(c.Request.Context(), userID, memberID); err != nil {
+		h.householdErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) householdErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrHouseholdMemberNotFound):
+		errorResponse(c, HouseholdMemberNotFoundCode)
+	case errors.Is(err, service.ErrHouseholdMemberExists):
+		errorResponse(c, HouseholdMemberExistsCode)
+	case errors.Is(err, service.ErrHouseholdMembersLimit):
+		errorResponse(c, HouseholdMembersLimitCode)
+	case errors.Is(err, service.ErrInvalidHouseholdRelation):
+		errorResponse(c, InvalidHouseholdRelationCode)
+	case errors.Is(err, service.ErrInvalidSNILS):
+		errorResponse(c, InvalidSNILSCode)
+	case errors.Is(err, service.ErrHouseholdGroupNotVerified):
+		errorResponse(c, HouseholdGroupNotVerifiedCode)
+	case errors.Is(err, service.ErrHouseholdRelationNotVerified):
+		errorResponse(c, HouseholdRelationNotVerifiedCode)
+	case errors.Is(err, service.ErrHouseholdMemberClaimed):
+		errorResponse(c, HouseholdMemberClaimedCode)
+	case errors.Is(err, service.ErrInvalidGroupType):
+		errorResponse(c, InvalidGroupTypeCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	default:
+		logger.Error("household request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func newHouseholdMemberResponse(member *domain.HouseholdMember) householdMemberResponse {
+	response := householdMemberResponse{
+		ID:                 member.ID,
+		Relation:           member.Relation,
+		RelationStatus:     member.RelationStatus,
+		RelationVerifiedAt: member.RelationVerifiedAt,
+		FirstName:          member.FirstName,
+		LastName:           member.LastName,
+		SNILS:              member.SNILS,
+		Groups:             member.GroupType,
+		CreatedAt:          member.CreatedAt,
+	}
+	if response.Groups == nil {
+		response.Groups = domain.UserGroupList{}
+	}
+	if member.MiddleName.Valid {
+		response.MiddleName = &member.MiddleName.String
+	}
+	if member.BirthDate != nil {
+		birthDate := member.BirthDate.Format("2006-01-02")
+		response.BirthDate = &birthDate
+	}
+	return response
+}
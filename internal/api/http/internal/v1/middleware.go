@@ -2,6 +2,7 @@ package v1
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -9,6 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/auth"
 	"github.com/vibe-gaming/backend/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -16,18 +20,30 @@ import (
 const (
 	authorizationHeader = "Authorization"
 	userCtx             = "userId"
+	sessionCtx          = "sessionId"
+	rolesCtx            = "roles"
+	claimsCtx           = "claims"
+
+	apiKeyHeader  = "X-API-Key"
+	partnerKeyCtx = "partnerKey"
 )
 
+var errAccessTokenRevoked = errors.New("access token revoked")
+
 func (h *Handler) userIdentityMiddleware(c *gin.Context) {
-	id, err := h.parseAuthHeader(c)
+	claims, err := h.parseAuthHeader(c)
 	if err != nil {
-		if !errors.Is(err, jwt.ErrTokenExpired) {
+		if !errors.Is(err, jwt.ErrTokenExpired) && !errors.Is(err, errAccessTokenRevoked) {
 			logger.Error("parse auth header failed", zap.Error(err))
 		}
 		c.AbortWithStatus(http.StatusUnauthorized)
+		return
 	}
 
-	c.Set(userCtx, id)
+	c.Set(userCtx, claims.Subject)
+	c.Set(sessionCtx, claims.SessionID)
+	c.Set(rolesCtx, claims.Roles)
+	c.Set(claimsCtx, claims)
 }
 
 // optionalUserIdentityMiddleware пытается авторизовать пользователя, но не требует обязательной авторизации
@@ -38,32 +54,164 @@ func (h *Handler) optionalUserIdentityMiddleware(c *gin.Context) {
 		zap.String("path", c.Request.URL.Path),
 		zap.Bool("has_auth_header", authHeader != ""))
 
-	id, err := h.parseAuthHeader(c)
+	claims, err := h.parseAuthHeader(c)
 	if err == nil {
 		// Если токен валидный - устанавливаем userId в контекст
-		c.Set(userCtx, id)
+		c.Set(userCtx, claims.Subject)
+		c.Set(sessionCtx, claims.SessionID)
+		c.Set(rolesCtx, claims.Roles)
+		c.Set(claimsCtx, claims)
 	}
 	// Если ошибка - просто продолжаем без установки userId
 	c.Next()
 }
 
-func (h *Handler) parseAuthHeader(c *gin.Context) (string, error) {
+// requirePermission пропускает запрос, если хотя бы одна роль пользователя дает право permission.
+// Ставится после userIdentityMiddleware. Для ролей, выданных на организацию, принадлежность
+// конкретного объекта организации проверяет обработчик через canManageOrganization
+func (h *Handler) requirePermission(permission domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.hasPermission(c, permission) {
+			c.Next()
+			return
+		}
+
+		forbiddenErrorResponse(c, AccessDeniedCode)
+	}
+}
+
+// hasPermission - хотя бы одна роль пользователя дает право permission
+func (h *Handler) hasPermission(c *gin.Context, permission domain.Permission) bool {
+	for _, role := range h.getRoles(c) {
+		if domain.Role(role.Role).HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// canManageOrganization проверяет право permission на объект организации organizationID.
+// Объекты без организации доступны только ролям, не ограниченным организацией
+func (h *Handler) canManageOrganization(c *gin.Context, permission domain.Permission, organizationID *uuid.UUID) bool {
+	for _, claim := range h.getRoles(c) {
+		role := domain.Role(claim.Role)
+		if !role.HasPermission(permission) {
+			continue
+		}
+
+		if !role.IsOrganizationScoped() {
+			return true
+		}
+
+		if organizationID != nil && claim.OrganizationID == organizationID.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// partnerAPIKeyMiddleware авторизует систему организации-партнера по ключу из заголовка X-API-Key
+func (h *Handler) partnerAPIKeyMiddleware(c *gin.Context) {
+	rawKey := c.GetHeader(apiKeyHeader)
+	if rawKey == "" {
+		unauthorizedErrorResponse(c, PartnerAPIKeyInvalidCode)
+		return
+	}
+
+	key, err := h.services.PartnerKeys.Authenticate(c.Request.Context(), rawKey, c.ClientIP())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			logger.Warn("partner api key rejected", zap.String("ip", c.ClientIP()))
+			unauthorizedErrorResponse(c, PartnerAPIKeyInvalidCode)
+			return
+		}
+		logger.Error("authenticate partner api key failed", zap.Error(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Set(partnerKeyCtx, key)
+}
+
+// requireAPIKeyScope пропускает запрос, если ключу партнера выдано право scope.
+// Ставится после partnerAPIKeyMiddleware
+func (h *Handler) requireAPIKeyScope(scope domain.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := h.getPartnerKey(c)
+		if key == nil || !key.Scopes.Has(scope) {
+			forbiddenErrorResponse(c, PartnerAPIKeyScopeMissingCode)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *Handler) getPartnerKey(c *gin.Context) *domain.PartnerAPIKey {
+	value, ok := c.Get(partnerKeyCtx)
+	if !ok {
+		return nil
+	}
+
+	key, _ := value.(*domain.PartnerAPIKey)
+
+	return key
+}
+
+func (h *Handler) getRoles(c *gin.Context) []auth.RoleClaim {
+	roles, ok := c.Get(rolesCtx)
+	if !ok {
+		return nil
+	}
+
+	return roles.([]auth.RoleClaim)
+}
+
+func (h *Handler) parseAuthHeader(c *gin.Context) (*auth.Claims, error) {
 	header := c.GetHeader(authorizationHeader)
 	slog.String("header", header)
 	if header == "" {
-		return "", errors.New("empty auth header")
+		return nil, errors.New("empty auth header")
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		return "", errors.New("invalid auth header")
+		return nil, errors.New("invalid auth header")
 	}
 
 	if len(headerParts[1]) == 0 {
-		return "", errors.New("token is empty")
+		return nil, errors.New("token is empty")
+	}
+
+	claims, err := h.tokenManager.Parse(headerParts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// Токен мог быть отозван при выходе, завершении сессии или блокировке пользователя.
+	// Если проверить это нельзя, токен не принимается
+	revoked, err := h.services.Users.IsAccessTokenRevoked(c.Request.Context(), claims)
+	if err != nil {
+		return nil, fmt.Errorf("check access token revocation failed: %w", err)
+	}
+	if revoked {
+		return nil, errAccessTokenRevoked
+	}
+
+	return claims, nil
+}
+
+// getClaims возвращает claims access токена, с которым пришел запрос
+func (h *Handler) getClaims(c *gin.Context) (*auth.Claims, bool) {
+	value, ok := c.Get(claimsCtx)
+	if !ok {
+		return nil, false
 	}
 
-	return h.tokenManager.Parse(headerParts[1])
+	claims, ok := value.(*auth.Claims)
+
+	return claims, ok
 }
 
 func (h *Handler) getUserUUID(c *gin.Context) (uuid.UUID, error) {
@@ -76,3 +224,19 @@ func (h *Handler) getUserUUID(c *gin.Context) (uuid.UUID, error) {
 
 	return uuid.MustParse(id.(string)), nil
 }
+
+// getSessionID возвращает идентификатор сессии (цепочки refresh токенов), в рамках которой выпущен access токен.
+// У токенов, выпущенных до появления claim sid, идентификатора нет
+func (h *Handler) getSessionID(c *gin.Context) (uuid.UUID, bool) {
+	value, ok := c.Get(sessionCtx)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(value.(string))
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
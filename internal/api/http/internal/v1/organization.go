@@ -15,11 +15,14 @@ import (
 func (h *Handler) initOrganizationsRoutes(api *gin.RouterGroup) {
 	organizations := api.Group("/organizations")
 	{
-		organizations.POST("", h.createOrganization)
+		organizations.POST("", h.userIdentityMiddleware, h.requirePermission(domain.PermissionOrganizationsCreate), h.createOrganization)
 		organizations.GET("", h.getOrganizations)
 		organizations.GET("/:id", h.getOrganizationByID)
-		organizations.PUT("/:id", h.updateOrganization)
-		organizations.DELETE("/:id", h.deleteOrganization)
+		organizations.PUT("/:id", h.userIdentityMiddleware, h.requirePermission(domain.PermissionOrganizationsManage), h.updateOrganization)
+		organizations.DELETE("/:id", h.userIdentityMiddleware, h.requirePermission(domain.PermissionOrganizationsManage), h.deleteOrganization)
+		organizations.GET("/:id/api-keys", h.userIdentityMiddleware, h.requirePermission(domain.PermissionAPIKeysManage), h.getPartnerAPIKeys)
+		organizations.POST("/:id/api-keys", h.userIdentityMiddleware, h.requirePermission(domain.PermissionAPIKeysManage), h.createPartnerAPIKey)
+		organizations.DELETE("/:id/api-keys/:keyId", h.userIdentityMiddleware, h.requirePermission(domain.PermissionAPIKeysManage), h.revokePartnerAPIKey)
 	}
 }
 
@@ -73,7 +76,10 @@ func (h *Handler) getOrganizations(c *gin.Context) {
 // @Param input body createOrganizationRequest true "Данные организации"
 // @Success 201 {object} createOrganizationResponse
 // @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
 // @Router /organizations [post]
 func (h *Handler) createOrganization(c *gin.Context) {
 	var req createOrganizationRequest
@@ -150,8 +156,11 @@ func (h *Handler) getOrganizationByID(c *gin.Context) {
 // @Param input body createOrganizationRequest true "Данные организации"
 // @Success 200 {object} createOrganizationResponse
 // @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
 // @Failure 404 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
 // @Router /organizations/{id} [put]
 func (h *Handler) updateOrganization(c *gin.Context) {
 	id := c.Param("id")
@@ -161,6 +170,17 @@ func (h *Handler) updateOrganization(c *gin.Context) {
 		return
 	}
 
+	// Менеджер организации может изменять только свою организацию
+	organizationID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+	if !h.canManageOrganization(c, domain.PermissionOrganizationsManage, &organizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
 	// Получаем существующую организацию
 	existingOrganization, err := h.services.Organizations.GetByID(c.Request.Context(), id)
 	if err != nil {
@@ -209,8 +229,11 @@ func (h *Handler) updateOrganization(c *gin.Context) {
 // @Param id path string true "Organization ID (UUID)"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
 // @Failure 404 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
+// @Security AdminAuth
 // @Router /organizations/{id} [delete]
 func (h *Handler) deleteOrganization(c *gin.Context) {
 	id := c.Param("id")
@@ -220,7 +243,18 @@ func (h *Handler) deleteOrganization(c *gin.Context) {
 		return
 	}
 
-	err := h.services.Organizations.Delete(c.Request.Context(), id)
+	// Менеджер организации может удалять только свою организацию
+	organizationID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+	if !h.canManageOrganization(c, domain.PermissionOrganizationsManage, &organizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+
+	err = h.services.Organizations.Delete(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			logger.Error("organization not found", zap.String("id", id))
@@ -0,0 +1,587 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// initPartnerRoutes - API для систем организаций-партнеров. Запросы авторизуются ключом из заголовка X-API-Key,
+// ключ дает доступ только к льготам и зданиям своей организации
+func (h *Handler) initPartnerRoutes(api *gin.RouterGroup) {
+	partner := api.Group("/partner", h.partnerAPIKeyMiddleware)
+	{
+		benefits := partner.Group("/benefits")
+		benefits.GET("", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsRead), h.getPartnerBenefits)
+		benefits.GET("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsRead), h.getPartnerBenefitByID)
+		benefits.POST("", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsWrite), h.createPartnerBenefit)
+		benefits.PUT("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsWrite), h.updatePartnerBenefit)
+		benefits.DELETE("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsWrite), h.deletePartnerBenefit)
+		benefits.POST("/:id/status", h.requireAPIKeyScope(domain.APIKeyScopeBenefitsWrite), h.changePartnerBenefitStatus)
+
+		buildings := partner.Group("/buildings")
+		buildings.GET("", h.requireAPIKeyScope(domain.APIKeyScopeBuildingsRead), h.getPartnerBuildings)
+		buildings.GET("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBuildingsRead), h.getPartnerBuildingByID)
+		buildings.POST("", h.requireAPIKeyScope(domain.APIKeyScopeBuildingsWrite), h.createPartnerBuilding)
+		buildings.PUT("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBuildingsWrite), h.updatePartnerBuilding)
+		buildings.DELETE("/:id", h.requireAPIKeyScope(domain.APIKeyScopeBuildingsWrite), h.deletePartnerBuilding)
+	}
+}
+
+type partnerBuildingRequest struct {
+	Address     string   `json:"address" binding:"required,max=255"`
+	Latitude    float64  `json:"latitude" binding:"required"`
+	Longitude   float64  `json:"longitude" binding:"required"`
+	PhoneNumber string   `json:"phone_number" binding:"required,max=20"`
+	StartTime   string   `json:"start_time" binding:"required"`
+	EndTime     string   `json:"end_time" binding:"required"`
+	IsOpen      bool     `json:"is_open"`
+	Type        string   `json:"type" binding:"required,max=50"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+type partnerBuildingsResponse struct {
+	Buildings []organizationBuildingResponse `json:"buildings"`
+}
+
+// @Summary Partner Benefits
+// @Tags Partner
+// @Description Льготы организации, которой выдан ключ
+// @ModuleID getPartnerBenefits
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество на странице (до 100)"
+// @Success 200 {object} benefitsListResponse
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits [get]
+func (h *Handler) getPartnerBenefits(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	page := 1
+	limit := 10
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	organizationID := key.OrganizationID.String()
+	filters := &service.BenefitFilters{
+		OrganizationID: &organizationID,
+		Statuses:       domain.BenefitStatuses(),
+	}
+
+	benefits, total, err := h.services.Benefits.GetAll(c.Request.Context(), page, limit, filters)
+	if err != nil {
+		logger.Error("failed to get partner benefits", zap.Error(err), zap.String("organization_id", organizationID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get benefits"})
+		return
+	}
+
+	response := benefitsListResponse{
+		Benefits: make([]benefitResponse, 0, len(benefits)),
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}
+	for _, benefit := range benefits {
+		response.Benefits = append(response.Benefits, newBenefitResponse(benefit))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Partner Benefit By ID
+// @Tags Partner
+// @Description Льгота организации, которой выдан ключ. Просмотр не увеличивает счетчик просмотров
+// @ModuleID getPartnerBenefitByID
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Success 200 {object} benefitResponse
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits/{id} [get]
+func (h *Handler) getPartnerBenefitByID(c *gin.Context) {
+	benefit, ok := h.getPartnerBenefit(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, newBenefitResponse(benefit))
+}
+
+// @Summary Create Partner Benefit
+// @Tags Partner
+// @Description Создать льготу организации, которой выдан ключ. organization_id можно не передавать.
+// @Description Льгота создается черновиком, для публикации ее нужно отправить на проверку через POST /partner/benefits/{id}/status
+// @ModuleID createPartnerBenefit
+// @Accept  json
+// @Produce  json
+// @Param input body createBenefitRequest true "Данные льготы"
+// @Success 201 {object} createBenefitResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits [post]
+func (h *Handler) createPartnerBenefit(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	var req createBenefitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	benefit, err := benefitFromRequest(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !partnerOwns(key, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+	benefit.OrganizationID = &key.OrganizationID
+
+	if benefit.Region == nil {
+		benefit.Region = domain.RegionList{}
+	}
+
+	if err := h.services.Benefits.Create(c.Request.Context(), benefit, partnerBenefitAuthor(key)); err != nil {
+		logger.Error("failed to create partner benefit", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create benefit"})
+		return
+	}
+
+	logger.Info("partner benefit created",
+		zap.String("benefit_id", benefit.ID.String()),
+		zap.String("key_id", key.ID.String()))
+
+	c.JSON(http.StatusCreated, createBenefitResponse{
+		ID:        benefit.ID.String(),
+		CreatedAt: benefit.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Status:    benefit.Status,
+	})
+}
+
+// @Summary Update Partner Benefit
+// @Tags Partner
+// @Description Обновить льготу организации, которой выдан ключ. Опубликованная льгота возвращается на проверку (in_review)
+// @Description и снова видна гражданам после одобрения редакцией
+// @ModuleID updatePartnerBenefit
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Param input body createBenefitRequest true "Данные льготы"
+// @Success 200 {object} createBenefitResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits/{id} [put]
+func (h *Handler) updatePartnerBenefit(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	existingBenefit, ok := h.getPartnerBenefit(c)
+	if !ok {
+		return
+	}
+
+	var req createBenefitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	benefit, err := benefitFromRequest(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Льготу нельзя передать другой организации
+	if !partnerOwns(key, benefit.OrganizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return
+	}
+	benefit.OrganizationID = &key.OrganizationID
+
+	applyBenefitChanges(existingBenefit, benefit)
+
+	if err := h.services.Benefits.Update(c.Request.Context(), existingBenefit, partnerBenefitAuthor(key)); err != nil {
+		logger.Error("failed to update partner benefit", zap.Error(err), zap.String("benefit_id", existingBenefit.ID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update benefit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, createBenefitResponse{
+		ID:        existingBenefit.ID.String(),
+		CreatedAt: existingBenefit.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Status:    existingBenefit.Status,
+	})
+}
+
+// @Summary Delete Partner Benefit
+// @Tags Partner
+// @Description Удалить льготу организации, которой выдан ключ (soft delete)
+// @ModuleID deletePartnerBenefit
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Benefit ID (UUID)"
+// @Success 204
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/benefits/{id} [delete]
+func (h *Handler) deletePartnerBenefit(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	benefit, ok := h.getPartnerBenefit(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Benefits.Delete(c.Request.Context(), benefit.ID.String(), partnerBenefitAuthor(key)); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+			return
+		}
+		logger.Error("failed to delete partner benefit", zap.Error(err), zap.String("benefit_id", benefit.ID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete benefit"})
+		return
+	}
+
+	logger.Info("partner benefit deleted",
+		zap.String("benefit_id", benefit.ID.String()),
+		zap.String("key_id", key.ID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Partner Buildings
+// @Tags Partner
+// @Description Здания организации, которой выдан ключ
+// @ModuleID getPartnerBuildings
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} partnerBuildingsResponse
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/buildings [get]
+func (h *Handler) getPartnerBuildings(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	organization, err := h.services.Organizations.GetByID(c.Request.Context(), key.OrganizationID.String())
+	if err != nil {
+		logger.Error("failed to get partner organization", zap.Error(err), zap.String("organization_id", key.OrganizationID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get buildings"})
+		return
+	}
+
+	response := partnerBuildingsResponse{
+		Buildings: make([]organizationBuildingResponse, 0, len(organization.Buildings)),
+	}
+	for i := range organization.Buildings {
+		response.Buildings = append(response.Buildings, newOrganizationBuildingResponse(&organization.Buildings[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Partner Building By ID
+// @Tags Partner
+// @Description Здание организации, которой выдан ключ
+// @ModuleID getPartnerBuildingByID
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Building ID (UUID)"
+// @Success 200 {object} organizationBuildingResponse
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/buildings/{id} [get]
+func (h *Handler) getPartnerBuildingByID(c *gin.Context) {
+	building, ok := h.getPartnerBuilding(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationBuildingResponse(building))
+}
+
+// @Summary Create Partner Building
+// @Tags Partner
+// @Description Добавить здание организации, которой выдан ключ. start_time и end_time в формате RFC 3339
+// @ModuleID createPartnerBuilding
+// @Accept  json
+// @Produce  json
+// @Param input body partnerBuildingRequest true "Данные здания"
+// @Success 201 {object} organizationBuildingResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/buildings [post]
+func (h *Handler) createPartnerBuilding(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	building := &domain.OrganizationBuilding{
+		OrganizationID: key.OrganizationID,
+	}
+	if !bindPartnerBuilding(c, building) {
+		return
+	}
+
+	if err := h.services.Organizations.CreateBuilding(c.Request.Context(), building); err != nil {
+		logger.Error("failed to create partner building", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create building"})
+		return
+	}
+
+	logger.Info("partner building created",
+		zap.String("building_id", building.ID.String()),
+		zap.String("key_id", key.ID.String()))
+
+	c.JSON(http.StatusCreated, newOrganizationBuildingResponse(building))
+}
+
+// @Summary Update Partner Building
+// @Tags Partner
+// @Description Обновить здание организации, которой выдан ключ. start_time и end_time в формате RFC 3339
+// @ModuleID updatePartnerBuilding
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Building ID (UUID)"
+// @Param input body partnerBuildingRequest true "Данные здания"
+// @Success 200 {object} organizationBuildingResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/buildings/{id} [put]
+func (h *Handler) updatePartnerBuilding(c *gin.Context) {
+	building, ok := h.getPartnerBuilding(c)
+	if !ok {
+		return
+	}
+
+	if !bindPartnerBuilding(c, building) {
+		return
+	}
+
+	if err := h.services.Organizations.UpdateBuilding(c.Request.Context(), building); err != nil {
+		logger.Error("failed to update partner building", zap.Error(err), zap.String("building_id", building.ID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update building"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationBuildingResponse(building))
+}
+
+// @Summary Delete Partner Building
+// @Tags Partner
+// @Description Удалить здание организации, которой выдан ключ (soft delete)
+// @ModuleID deletePartnerBuilding
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Building ID (UUID)"
+// @Success 204
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 404 {object} ErrorStruct
+// @Failure 500
+// @Security PartnerAuth
+// @Router /partner/buildings/{id} [delete]
+func (h *Handler) deletePartnerBuilding(c *gin.Context) {
+	key := h.getPartnerKey(c)
+
+	building, ok := h.getPartnerBuilding(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.Organizations.DeleteBuilding(c.Request.Context(), building.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "building not found"})
+			return
+		}
+		logger.Error("failed to delete partner building", zap.Error(err), zap.String("building_id", building.ID.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete building"})
+		return
+	}
+
+	logger.Info("partner building deleted",
+		zap.String("building_id", building.ID.String()),
+		zap.String("key_id", key.ID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// getPartnerBenefit загружает льготу из пути и проверяет, что она принадлежит организации ключа.
+// Чужие льготы выглядят для партнера как несуществующие. При ошибке ответ уже записан
+func (h *Handler) getPartnerBenefit(c *gin.Context) (*domain.Benefit, bool) {
+	key := h.getPartnerKey(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benefit id"})
+		return nil, false
+	}
+
+	benefit, err := h.services.Benefits.GetByIDWithoutIncrement(c.Request.Context(), id.String(), nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+			return nil, false
+		}
+		logger.Error("failed to get partner benefit", zap.Error(err), zap.String("benefit_id", id.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get benefit"})
+		return nil, false
+	}
+
+	if benefit.OrganizationID == nil || *benefit.OrganizationID != key.OrganizationID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "benefit not found"})
+		return nil, false
+	}
+
+	return benefit, true
+}
+
+// getPartnerBuilding загружает здание из пути и проверяет, что оно принадлежит организации ключа.
+// При ошибке ответ уже записан
+func (h *Handler) getPartnerBuilding(c *gin.Context) (*domain.OrganizationBuilding, bool) {
+	key := h.getPartnerKey(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid building id"})
+		return nil, false
+	}
+
+	building, err := h.services.Organizations.GetBuildingByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "building not found"})
+			return nil, false
+		}
+		logger.Error("failed to get partner building", zap.Error(err), zap.String("building_id", id.String()))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get building"})
+		return nil, false
+	}
+
+	if building.OrganizationID != key.OrganizationID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "building not found"})
+		return nil, false
+	}
+
+	return building, true
+}
+
+// bindPartnerBuilding разбирает запрос и переносит данные в building. При ошибке ответ уже записан
+func bindPartnerBuilding(c *gin.Context, building *domain.OrganizationBuilding) bool {
+	var req partnerBuildingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return false
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_time format. Use RFC 3339"})
+		return false
+	}
+
+	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_time format. Use RFC 3339"})
+		return false
+	}
+
+	tags := make(domain.OrganizationTagList, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		switch tag {
+		case domain.OrganizationTagIsHaveRamp, domain.OrganizationTagIsHaveLift:
+			tags = append(tags, domain.OrganizationTag(tag))
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid building tag: %s", tag)})
+			return false
+		}
+	}
+
+	building.Address = req.Address
+	building.Latitude = req.Latitude
+	building.Longitude = req.Longitude
+	building.PhoneNumber = req.PhoneNumber
+	building.StartTime = startTime
+	building.EndTime = endTime
+	building.IsOpen = req.IsOpen
+	building.Type = req.Type
+	building.Tags = tags
+
+	return true
+}
+
+// partnerOwns - объект с organizationID можно сохранить от имени ключа. Пустая организация
+// означает организацию ключа
+func partnerOwns(key *domain.PartnerAPIKey, organizationID *uuid.UUID) bool {
+	return organizationID == nil || *organizationID == key.OrganizationID
+}
+
+func newOrganizationBuildingResponse(building *domain.OrganizationBuilding) organizationBuildingResponse {
+	tags := make([]string, 0, len(building.Tags))
+	for _, tag := range building.Tags {
+		tags = append(tags, string(tag))
+	}
+
+	return organizationBuildingResponse{
+		ID:          building.ID.String(),
+		Address:     building.Address,
+		Latitude:    building.Latitude,
+		Longitude:   building.Longitude,
+		PhoneNumber: building.PhoneNumber,
+		GisDeeplink: building.GetGisDeeplink(),
+		StartTime:   building.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		EndTime:     building.EndTime.Format("2006-01-02T15:04:05Z07:00"),
+		IsOpen:      building.IsOpen,
+		Tags:        tags,
+		Type:        building.Type,
+	}
+}
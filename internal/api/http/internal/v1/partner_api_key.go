@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type partnerAPIKeyResponse struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	KeyPrefix  string               `json:"key_prefix"`
+	Scopes     []domain.APIKeyScope `json:"scopes"`
+	CreatedBy  *uuid.UUID           `json:"created_by,omitempty"`
+	ExpiresAt  *string              `json:"expires_at,omitempty"`
+	LastUsedAt *string              `json:"last_used_at,omitempty"`
+	LastUsedIP *string              `json:"last_used_ip,omitempty"`
+	RevokedAt  *string              `json:"revoked_at,omitempty"`
+	CreatedAt  string               `json:"created_at"`
+	IsActive   bool                 `json:"is_active"`
+}
+
+type getPartnerAPIKeysResponse struct {
+	Keys []partnerAPIKeyResponse `json:"keys"`
+}
+
+type createPartnerAPIKeyRequest struct {
+	Name      string               `json:"name" binding:"required,max=100"`
+	Scopes    []domain.APIKeyScope `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time           `json:"expires_at,omitempty"`
+}
+
+type createPartnerAPIKeyResponse struct {
+	partnerAPIKeyResponse
+	// Key - ключ целиком. Показывается только в этом ответе
+	Key string `json:"key"`
+}
+
+// @Summary Get Partner API Keys
+// @Tags Organizations
+// @Description Ключи доступа к API партнера, включая отозванные. Сами ключи не возвращаются, только префиксы
+// @ModuleID getPartnerAPIKeys
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID (UUID)"
+// @Success 200 {object} getPartnerAPIKeysResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /organizations/{id}/api-keys [get]
+func (h *Handler) getPartnerAPIKeys(c *gin.Context) {
+	organizationID, ok := h.parseManagedOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.services.PartnerKeys.GetByOrganizationID(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.Error("get partner api keys failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	response := getPartnerAPIKeysResponse{
+		Keys: make([]partnerAPIKeyResponse, 0, len(keys)),
+	}
+	for i := range keys {
+		response.Keys = append(response.Keys, newPartnerAPIKeyResponse(&keys[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Create Partner API Key
+// @Tags Organizations
+// @Description Выпустить ключ доступа к API партнера (заголовок X-API-Key). Ключ возвращается один раз и больше
+// @Description нигде не показывается. Доступные scopes: benefits:read, benefits:write, buildings:read, buildings:write
+// @ModuleID createPartnerAPIKey
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID (UUID)"
+// @Param input body createPartnerAPIKeyRequest true "Ключ"
+// @Success 201 {object} createPartnerAPIKeyResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /organizations/{id}/api-keys [post]
+func (h *Handler) createPartnerAPIKey(c *gin.Context) {
+	organizationID, ok := h.parseManagedOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req createPartnerAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	key, rawKey, err := h.services.PartnerKeys.Create(c.Request.Context(), service.PartnerAPIKeyCreateInput{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		Scopes:         req.Scopes,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAPIKeyScope):
+			errorResponse(c, InvalidAPIKeyScopeCode)
+		case errors.Is(err, service.ErrInvalidAPIKeyExpiry):
+			errorResponse(c, InvalidAPIKeyExpiryCode)
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			errorResponse(c, OrganizationNotFoundCode)
+		default:
+			logger.Error("create partner api key failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	logger.Info("partner api key created",
+		zap.String("key_id", key.ID.String()),
+		zap.String("organization_id", organizationID.String()),
+		zap.String("created_by", userID.String()))
+
+	c.JSON(http.StatusCreated, createPartnerAPIKeyResponse{
+		partnerAPIKeyResponse: newPartnerAPIKeyResponse(key),
+		Key:                   rawKey,
+	})
+}
+
+// @Summary Revoke Partner API Key
+// @Tags Organizations
+// @Description Отозвать ключ доступа к API партнера. Запросы с ключом сразу перестают приниматься
+// @ModuleID revokePartnerAPIKey
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID (UUID)"
+// @Param keyId path string true "API Key ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /organizations/{id}/api-keys/{keyId} [delete]
+func (h *Handler) revokePartnerAPIKey(c *gin.Context) {
+	organizationID, ok := h.parseManagedOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("keyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	if err := h.services.PartnerKeys.Revoke(c.Request.Context(), organizationID, keyID); err != nil {
+		if errors.Is(err, service.ErrPartnerAPIKeyNotFound) {
+			errorResponse(c, PartnerAPIKeyNotFoundCode)
+			return
+		}
+		logger.Error("revoke partner api key failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	logger.Info("partner api key revoked",
+		zap.String("key_id", keyID.String()),
+		zap.String("organization_id", organizationID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseManagedOrganizationID разбирает id организации из пути и проверяет, что пользователь
+// может управлять ключами этой организации. При ошибке ответ уже записан
+func (h *Handler) parseManagedOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, false
+	}
+
+	if !h.canManageOrganization(c, domain.PermissionAPIKeysManage, &organizationID) {
+		forbiddenErrorResponse(c, AccessDeniedCode)
+		return uuid.Nil, false
+	}
+
+	return organizationID, true
+}
+
+func newPartnerAPIKeyResponse(key *domain.PartnerAPIKey) partnerAPIKeyResponse {
+	return partnerAPIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		Scopes:     key.Scopes,
+		CreatedBy:  key.CreatedBy,
+		ExpiresAt:  formatOptionalTime(key.ExpiresAt),
+		LastUsedAt: formatOptionalTime(key.LastUsedAt),
+		LastUsedIP: key.LastUsedIP,
+		RevokedAt:  formatOptionalTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		IsActive:   key.IsActive(),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+
+	return &formatted
+}
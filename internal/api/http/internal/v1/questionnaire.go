@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) initQuestionnaireRoutes(api *gin.RouterGroup) {
+	questionnaire := api.Group("/questionnaire")
+	{
+		questionnaire.POST("", h.evaluateQuestionnaire)
+		questionnaire.POST("/drafts", h.saveQuestionnaireDraft)
+	}
+}
+
+type questionnaireBenefitResponse struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Type         string   `json:"type"`
+	Category     *string  `json:"category,omitempty"`
+	TargetGroups []string `json:"target_groups"`
+	CityID       *string  `json:"city_id,omitempty"`
+	ValidTo      string   `json:"valid_to"`
+}
+
+type questionnaireResponse struct {
+	Answers      domain.QuestionnaireAnswers `json:"answers"`
+	NextQuestion *domain.Question            `json:"next_question"`
+	Completed    bool                        `json:"completed"`
+	// Groups - группы, под которые подходит гражданин по ответам
+	Groups   domain.GroupTypeList           `json:"groups"`
+	Benefits []questionnaireBenefitResponse `json:"benefits"`
+	Total    int64                          `json:"total"`
+	Page     int                            `json:"page"`
+	Limit    int                            `json:"limit"`
+}
+
+type questionnaireDraftResponse struct {
+	DraftID   uuid.UUID `json:"draft_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type applyQuestionnaireDraftRequest struct {
+	DraftID uuid.UUID `json:"draft_id" binding:"required"`
+}
+
+// @Summary Evaluate Questionnaire
+// @Tags Questionnaire
+// @Description Анкета "На что я имею право?" без входа через Госуслуги. Клиент присылает все ответы, данные на текущий момент,
+// @Description и получает следующий вопрос. Набор вопросов и вариантов ответа зависит от предыдущих ответов.
+// @Description Когда вопросов не осталось (completed=true), возвращаются льготы для групп из ответов, доступные в выбранном городе
+// @ModuleID evaluateQuestionnaire
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Номер страницы (по умолчанию 1)"
+// @Param limit query int false "Количество элементов на странице (по умолчанию 10, максимум 100)"
+// @Param input body domain.QuestionnaireAnswers true "Ответы"
+// @Success 200 {object} questionnaireResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 500
+// @Router /questionnaire [post]
+func (h *Handler) evaluateQuestionnaire(c *gin.Context) {
+	var answers domain.QuestionnaireAnswers
+	if err := c.ShouldBindJSON(&answers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	result, err := h.services.Questionnaire.Evaluate(c.Request.Context(), answers, page, limit)
+	if err != nil {
+		h.questionnaireErrorResponse(c, err)
+		return
+	}
+
+	response := questionnaireResponse{
+		Answers:      result.Answers,
+		NextQuestion: result.NextQuestion,
+		Completed:    result.Completed(),
+		Groups:       result.Groups,
+		Benefits:     make([]questionnaireBenefitResponse, 0, len(result.Benefits)),
+		Total:        result.Total,
+		Page:         page,
+		Limit:        limit,
+	}
+	if response.Groups == nil {
+		response.Groups = domain.GroupTypeList{}
+	}
+	for _, benefit := range result.Benefits {
+		response.Benefits = append(response.Benefits, newQuestionnaireBenefitResponse(benefit))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Save Questionnaire Draft
+// @Tags Questionnaire
+// @Description Сохранить ответы анкеты на 7 дней. После входа draft_id передается в POST /users/questionnaire/apply,
+// @Description и ответы переносятся в профиль
+// @ModuleID saveQuestionnaireDraft
+// @Accept  json
+// @Produce  json
+// @Param input body domain.QuestionnaireAnswers true "Ответы"
+// @Success 201 {object} questionnaireDraftResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 500
+// @Router /questionnaire/drafts [post]
+func (h *Handler) saveQuestionnaireDraft(c *gin.Context) {
+	var answers domain.QuestionnaireAnswers
+	if err := c.ShouldBindJSON(&answers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	draft, err := h.services.Questionnaire.SaveDraft(c.Request.Context(), answers)
+	if err != nil {
+		h.questionnaireErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, questionnaireDraftResponse{
+		DraftID:   draft.ID,
+		ExpiresAt: draft.ExpiresAt,
+	})
+}
+
+// @Summary Apply Questionnaire Draft
+// @Tags Users
+// @Description Перенести в профиль ответы анкеты, заполненной до входа. Город, количество детей и категория инвалидности
+// @Description заполняются, только если их еще нет в профиле. Группы из анкеты добавляются к группам пользователя и отправляются на проверку
+// @ModuleID applyQuestionnaireDraft
+// @Accept  json
+// @Produce  json
+// @Param input body applyQuestionnaireDraftRequest true "ID черновика"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/questionnaire/apply [post]
+func (h *Handler) applyQuestionnaireDraft(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req applyQuestionnaireDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.services.Questionnaire.ApplyDraft(c.Request.Context(), userID, req.DraftID); err != nil {
+		h.questionnaireErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) questionnaireErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidQuestionnaireAnswers):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrQuestionnaireDraftNotFound):
+		errorResponse(c, QuestionnaireDraftNotFoundCode)
+	case errors.Is(err, service.ErrCityNotFound):
+		errorResponse(c, CityNotFoundErrorCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	default:
+		logger.Error("questionnaire request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func newQuestionnaireBenefitResponse(benefit *domain.Benefit) questionnaireBenefitResponse {
+	response := questionnaireBenefitResponse{
+		ID:           benefit.ID.String(),
+		Title:        benefit.Title,
+		Type:         string(benefit.Type),
+		TargetGroups: make([]string, 0, len(benefit.TargetGroupIDs)),
+		ValidTo:      benefit.GetValidTo(),
+	}
+	for _, group := range benefit.TargetGroupIDs {
+		response.TargetGroups = append(response.TargetGroups, string(group))
+	}
+	if benefit.Category != nil {
+		category := string(*benefit.Category)
+		response.Category = &category
+	}
+	if benefit.CityID != nil {
+		cityID := benefit.CityID.String()
+		response.CityID = &cityID
+	}
+	return response
+}
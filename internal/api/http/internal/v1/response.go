@@ -3,7 +3,10 @@ package v1
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -13,6 +16,20 @@ func errorResponse(c *gin.Context, code ErrorCode) {
 	c.AbortWithStatusJSON(http.StatusBadRequest, getErrorStruct(code))
 }
 
+func unauthorizedErrorResponse(c *gin.Context, code ErrorCode) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, getErrorStruct(code))
+}
+
+func forbiddenErrorResponse(c *gin.Context, code ErrorCode) {
+	c.AbortWithStatusJSON(http.StatusForbidden, getErrorStruct(code))
+}
+
+// tooManyRequestsErrorResponse отвечает 429, в Retry-After - через сколько секунд можно повторить запрос
+func tooManyRequestsErrorResponse(c *gin.Context, code ErrorCode, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, getErrorStruct(code))
+}
+
 func validationErrorResponse(c *gin.Context, err error) {
 	var verr validator.ValidationErrors
 	if errors.As(err, &verr) {
@@ -43,6 +60,12 @@ func msgForTag(tag string, value string) string {
 		return fmt.Sprintf("Максимальное количество символов в поле - %v", value)
 	case "phonenumber":
 		return "Номер должен начинаться с 7 и иметь 11 символов"
+	case "snils":
+		return "Неверный СНИЛС: нужно 11 цифр с верным контрольным числом"
+	case "passport":
+		return "Неверные серия и номер паспорта: нужно 4 цифры серии и 6 цифр номера"
+	case "datetime":
+		return fmt.Sprintf("Дата должна быть в формате %v", value)
 	}
 	return tag
 }
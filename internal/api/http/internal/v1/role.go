@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type userRoleResponse struct {
+	Role           domain.Role `json:"role"`
+	OrganizationID *uuid.UUID  `json:"organization_id,omitempty"`
+	GrantedBy      *uuid.UUID  `json:"granted_by,omitempty"`
+	CreatedAt      string      `json:"created_at"`
+}
+
+type getUserRolesResponse struct {
+	Roles []userRoleResponse `json:"roles"`
+}
+
+// @Summary Get User Roles
+// @Tags Admin
+// @Description Роли пользователя. Роль citizen есть у всех пользователей и не возвращается
+// @ModuleID getUserRoles
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} getUserRolesResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/roles [get]
+func (h *Handler) getUserRoles(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	roles, err := h.services.Roles.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("get user roles failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	response := getUserRolesResponse{
+		Roles: make([]userRoleResponse, 0, len(roles)),
+	}
+	for _, role := range roles {
+		response.Roles = append(response.Roles, userRoleResponse{
+			Role:           role.Role,
+			OrganizationID: role.OrganizationID,
+			GrantedBy:      role.GrantedBy,
+			CreatedAt:      role.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type grantUserRoleRequest struct {
+	Role           domain.Role `json:"role" binding:"required"`
+	OrganizationID *uuid.UUID  `json:"organization_id,omitempty"`
+}
+
+// @Summary Grant User Role
+// @Tags Admin
+// @Description Выдать роль пользователю. Для organization_manager обязателен organization_id.
+// @Description Роль попадает в access токен пользователя при следующем обновлении токенов
+// @ModuleID grantUserRole
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Param input body grantUserRoleRequest true "Роль"
+// @Success 201
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/roles [post]
+func (h *Handler) grantUserRole(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req grantUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	adminID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	role := &domain.UserRole{
+		UserID:         userID,
+		Role:           req.Role,
+		OrganizationID: req.OrganizationID,
+		GrantedBy:      &adminID,
+	}
+	if err := h.services.Roles.Grant(c.Request.Context(), role); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRole):
+			errorResponse(c, InvalidRoleCode)
+		case errors.Is(err, service.ErrRoleOrganizationMismatch):
+			errorResponse(c, RoleOrganizationMismatchCode)
+		case errors.Is(err, service.ErrRoleAlreadyGranted):
+			errorResponse(c, RoleAlreadyGrantedCode)
+		case errors.Is(err, service.ErrUserNotFound):
+			errorResponse(c, UserNotFoundCode)
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			errorResponse(c, OrganizationNotFoundCode)
+		default:
+			logger.Error("grant user role failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	logger.Info("user role granted",
+		zap.String("user_id", userID.String()),
+		zap.String("role", string(req.Role)),
+		zap.String("granted_by", adminID.String()))
+
+	c.Status(http.StatusCreated)
+}
+
+// @Summary Revoke User Role
+// @Tags Admin
+// @Description Отозвать роль пользователя. Для organization_manager указывается organization_id
+// @ModuleID revokeUserRole
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Param role path string true "Роль"
+// @Param organization_id query string false "Organization ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/roles/{role} [delete]
+func (h *Handler) revokeUserRole(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var organizationID *uuid.UUID
+	if value := c.Query("organization_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization_id"})
+			return
+		}
+		organizationID = &parsed
+	}
+
+	role := domain.Role(c.Param("role"))
+	if err := h.services.Roles.Revoke(c.Request.Context(), userID, role, organizationID); err != nil {
+		if errors.Is(err, service.ErrRoleNotFound) {
+			errorResponse(c, RoleNotFoundCode)
+			return
+		}
+		logger.Error("revoke user role failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
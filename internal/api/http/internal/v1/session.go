@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type sessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	SignedInAt time.Time `json:"signed_in_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type getSessionsResponse struct {
+	Sessions []sessionResponse `json:"sessions"`
+}
+
+// @Summary Get Sessions
+// @Tags Sessions
+// @Description Активные сессии пользователя на всех устройствах. Текущая сессия помечена флагом current
+// @ModuleID getSessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getSessionsResponse
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/sessions [get]
+func (h *Handler) getSessions(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	sessions, err := h.services.Users.GetSessions(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("get sessions failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	currentSessionID, _ := h.getSessionID(c)
+
+	response := getSessionsResponse{
+		Sessions: make([]sessionResponse, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, sessionResponse{
+			ID:         session.ID,
+			Device:     deviceName(session.UserAgent),
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			SignedInAt: session.SignedInAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Revoke Session
+// @Tags Sessions
+// @Description Завершение одной сессии пользователя. Refresh токены сессии перестают обмениваться сразу
+// @ModuleID revokeSession
+// @Accept  json
+// @Produce  json
+// @Param id path string true "ID сессии"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/sessions/{id} [delete]
+func (h *Handler) revokeSession(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	h.revokeUserSession(c, userID, sessionID)
+}
+
+// @Summary Logout
+// @Tags Sessions
+// @Description Выход из текущей сессии
+// @ModuleID logoutCurrentSession
+// @Accept  json
+// @Produce  json
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/sessions/current [delete]
+func (h *Handler) logoutCurrentSession(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	sessionID, ok := h.getSessionID(c)
+	if !ok {
+		// Токен выпущен до появления идентификатора сессии, определить текущую сессию нельзя
+		errorResponse(c, SessionNotFoundCode)
+		return
+	}
+
+	h.revokeUserSession(c, userID, sessionID)
+}
+
+// @Summary Logout Everywhere
+// @Tags Sessions
+// @Description Выход из всех сессий пользователя на всех устройствах, включая текущую
+// @ModuleID logoutAllSessions
+// @Accept  json
+// @Produce  json
+// @Success 204
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/sessions [delete]
+func (h *Handler) logoutAllSessions(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if err := h.services.Users.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		logger.Error("revoke all sessions failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	h.clearRefreshTokenCookie(c)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) revokeUserSession(c *gin.Context, userID uuid.UUID, sessionID uuid.UUID) {
+	if err := h.services.Users.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			errorResponse(c, SessionNotFoundCode)
+			return
+		}
+		logger.Error("revoke session failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if currentSessionID, ok := h.getSessionID(c); ok && currentSessionID == sessionID {
+		h.clearRefreshTokenCookie(c)
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) clearRefreshTokenCookie(c *gin.Context) {
+	c.SetCookie(refreshTokenCookie, "", -1, refreshTokenCookiePath, "", h.config.Env != "local", true)
+}
+
+// deviceName строит короткое описание устройства по User-Agent, например "Chrome, Android"
+func deviceName(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	var browser string
+	switch {
+	case strings.Contains(ua, "yabrowser"):
+		browser = "Яндекс Браузер"
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"):
+		os = "iOS"
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "mac os"):
+		os = "macOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + ", " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+
+	return "Неизвестное устройство"
+}
@@ -0,0 +1,391 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) initStaffRoutes(api *gin.RouterGroup) {
+	staff := api.Group("/staff")
+
+	staff.POST("/auth/login", h.staffLogin)
+	staff.POST("/auth/mfa", h.staffVerifyMFA)
+
+	totp := staff.Group("/totp", h.userIdentityMiddleware)
+	{
+		totp.POST("/enroll", h.staffEnrollTOTP)
+		totp.POST("/confirm", h.staffConfirmTOTP)
+	}
+
+	recoveryCodes := staff.Group("/recovery-codes", h.userIdentityMiddleware)
+	{
+		recoveryCodes.GET("", h.staffGetRecoveryCodes)
+		recoveryCodes.POST("", h.staffRegenerateRecoveryCodes)
+	}
+}
+
+type staffLoginRequest struct {
+	Login    string `json:"login" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type staffLoginResponse struct {
+	AccessToken  string     `json:"access_token,omitempty"`
+	RefreshToken *uuid.UUID `json:"refresh_token,omitempty"`
+	MFARequired  bool       `json:"mfa_required"`
+	MFAToken     string     `json:"mfa_token,omitempty"`
+}
+
+// @Summary Staff Login
+// @Tags Staff Auth
+// @Description Вход сотрудника по логину и паролю. Если у сотрудника включен TOTP, токены не выдаются:
+// @Description возвращается mfa_token, который нужно подтвердить кодом в /staff/auth/mfa.
+// @Description После нескольких неудачных попыток подряд вход временно блокируется
+// @ModuleID staffLogin
+// @Accept  json
+// @Produce  json
+// @Param input body staffLoginRequest true "Логин и пароль"
+// @Success 200 {object} staffLoginResponse
+// @Failure 400
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Router /staff/auth/login [post]
+func (h *Handler) staffLogin(c *gin.Context) {
+	var req staffLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	result, err := h.services.Staff.Login(c.Request.Context(), req.Login, req.Password, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrStaffInvalidCredentials):
+			unauthorizedErrorResponse(c, StaffInvalidCredentialsCode)
+		case errors.Is(err, service.ErrStaffAccountLocked):
+			forbiddenErrorResponse(c, StaffAccountLockedCode)
+		case errors.Is(err, service.ErrUserDeactivated):
+			forbiddenErrorResponse(c, UserDeactivatedCode)
+		default:
+			logger.Error("staff login failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	if result.MFAToken != "" {
+		c.JSON(http.StatusOK, staffLoginResponse{
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+		})
+		return
+	}
+
+	h.setRefreshTokenCookie(c, result.Tokens)
+	c.JSON(http.StatusOK, staffLoginResponse{
+		AccessToken:  result.Tokens.AccessToken,
+		RefreshToken: &result.Tokens.RefreshToken,
+	})
+}
+
+type staffVerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code" binding:"required_without=Code"`
+}
+
+// @Summary Staff MFA
+// @Tags Staff Auth
+// @Description Подтверждение входа сотрудника TOTP кодом из приложения или одноразовым кодом восстановления
+// @ModuleID staffVerifyMFA
+// @Accept  json
+// @Produce  json
+// @Param input body staffVerifyMFARequest true "mfa_token и код"
+// @Success 200 {object} exchangeTokenResponse
+// @Failure 400
+// @Failure 401 {object} ErrorStruct
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Router /staff/auth/mfa [post]
+func (h *Handler) staffVerifyMFA(c *gin.Context) {
+	var req staffVerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	tokens, err := h.services.Staff.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code, req.RecoveryCode, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrStaffMFAChallengeNotFound):
+			unauthorizedErrorResponse(c, StaffMFAChallengeNotFoundCode)
+		case errors.Is(err, service.ErrStaffInvalidTOTPCode):
+			unauthorizedErrorResponse(c, StaffInvalidTOTPCode)
+		case errors.Is(err, service.ErrStaffInvalidRecoveryCode):
+			unauthorizedErrorResponse(c, StaffInvalidRecoveryCodeCode)
+		case errors.Is(err, service.ErrStaffAccountLocked):
+			forbiddenErrorResponse(c, StaffAccountLockedCode)
+		case errors.Is(err, service.ErrUserDeactivated):
+			forbiddenErrorResponse(c, UserDeactivatedCode)
+		default:
+			logger.Error("staff mfa verification failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	h.setRefreshTokenCookie(c, tokens)
+	c.JSON(http.StatusOK, exchangeTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+type staffEnrollTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// @Summary Enroll TOTP
+// @Tags Staff Auth
+// @Description Выпуск секрета TOTP. provisioning_uri показывается QR кодом для приложения-аутентификатора.
+// @Description Второй фактор включается после подтверждения кодом в /staff/totp/confirm
+// @ModuleID staffEnrollTOTP
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} staffEnrollTOTPResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security AdminAuth
+// @Router /staff/totp/enroll [post]
+func (h *Handler) staffEnrollTOTP(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	enrollment, err := h.services.Staff.EnrollTOTP(c.Request.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrStaffNotFound):
+			errorResponse(c, StaffNotFoundCode)
+		case errors.Is(err, service.ErrStaffTOTPAlreadyEnabled):
+			errorResponse(c, StaffTOTPAlreadyEnabledCode)
+		default:
+			logger.Error("enroll totp failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, staffEnrollTOTPResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+type staffTOTPCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type staffRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// @Summary Confirm TOTP
+// @Tags Staff Auth
+// @Description Включение второго фактора кодом из приложения. В ответе коды восстановления, они показываются только один раз
+// @ModuleID staffConfirmTOTP
+// @Accept  json
+// @Produce  json
+// @Param input body staffTOTPCodeRequest true "TOTP код"
+// @Success 200 {object} staffRecoveryCodesResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security AdminAuth
+// @Router /staff/totp/confirm [post]
+func (h *Handler) staffConfirmTOTP(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req staffTOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	codes, err := h.services.Staff.ConfirmTOTP(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.staffTOTPErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, staffRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+type staffRecoveryCodesCountResponse struct {
+	Remaining int `json:"remaining"`
+}
+
+// @Summary Recovery Codes Count
+// @Tags Staff Auth
+// @Description Количество неиспользованных кодов восстановления
+// @ModuleID staffGetRecoveryCodes
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} staffRecoveryCodesCountResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security AdminAuth
+// @Router /staff/recovery-codes [get]
+func (h *Handler) staffGetRecoveryCodes(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	count, err := h.services.Staff.CountRecoveryCodes(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrStaffNotFound) {
+			errorResponse(c, StaffNotFoundCode)
+			return
+		}
+		logger.Error("count recovery codes failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, staffRecoveryCodesCountResponse{Remaining: count})
+}
+
+// @Summary Regenerate Recovery Codes
+// @Tags Staff Auth
+// @Description Замена кодов восстановления новыми. Старые коды перестают действовать
+// @ModuleID staffRegenerateRecoveryCodes
+// @Accept  json
+// @Produce  json
+// @Param input body staffTOTPCodeRequest true "TOTP код"
+// @Success 200 {object} staffRecoveryCodesResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security AdminAuth
+// @Router /staff/recovery-codes [post]
+func (h *Handler) staffRegenerateRecoveryCodes(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req staffTOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	codes, err := h.services.Staff.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.staffTOTPErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, staffRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *Handler) staffTOTPErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrStaffNotFound):
+		errorResponse(c, StaffNotFoundCode)
+	case errors.Is(err, service.ErrStaffTOTPAlreadyEnabled):
+		errorResponse(c, StaffTOTPAlreadyEnabledCode)
+	case errors.Is(err, service.ErrStaffTOTPNotEnrolled):
+		errorResponse(c, StaffTOTPNotEnrolledCode)
+	case errors.Is(err, service.ErrStaffInvalidTOTPCode):
+		errorResponse(c, StaffInvalidTOTPCode)
+	default:
+		logger.Error("staff totp operation failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+type createStaffRequest struct {
+	Login      string `json:"login" binding:"required,min=3,max=64"`
+	Password   string `json:"password" binding:"required,min=12,max=128"`
+	FirstName  string `json:"first_name" binding:"required"`
+	LastName   string `json:"last_name" binding:"required"`
+	MiddleName string `json:"middle_name"`
+	Email      string `json:"email" binding:"omitempty,email"`
+}
+
+type createStaffResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// @Summary Create Staff Account
+// @Tags Admin
+// @Description Создание учетной записи сотрудника для входа по логину и паролю.
+// @Description Роли выдаются отдельно через /admin/users/{id}/roles
+// @ModuleID createStaff
+// @Accept  json
+// @Produce  json
+// @Param input body createStaffRequest true "Данные сотрудника"
+// @Success 201 {object} createStaffResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/staff [post]
+func (h *Handler) createStaff(c *gin.Context) {
+	var req createStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.services.Staff.Create(c.Request.Context(), service.StaffCreateInput{
+		Login:      req.Login,
+		Password:   req.Password,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		MiddleName: req.MiddleName,
+		Email:      req.Email,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrStaffLoginTaken) {
+			errorResponse(c, StaffLoginTakenCode)
+			return
+		}
+		logger.Error("create staff failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createStaffResponse{ID: userID})
+}
@@ -6,10 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/authstate"
 	"github.com/vibe-gaming/backend/internal/domain"
 	"github.com/vibe-gaming/backend/internal/esia"
 	"github.com/vibe-gaming/backend/internal/service"
@@ -19,19 +19,6 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// Хранилище для CSRF state токенов (в продакшене использовать Redis)
-var stateStore = make(map[string]bool)
-
-// Хранилище для authorization codes с привязкой к state
-type authCodeData struct {
-	Code      string
-	State     string
-	CreatedAt time.Time
-}
-
-var codeStore = make(map[string]*authCodeData)
-var codeStoreMutex sync.RWMutex
-
 func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 	users := api.Group("/users")
 
@@ -39,11 +26,41 @@ func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 	users.GET("/profile", h.userIdentityMiddleware, h.getProfile)
 	users.GET("/pdfdownload", h.userIdentityMiddleware, h.getUserPensionerCertificatePDF)
 	users.POST("/update-info", h.userIdentityMiddleware, h.userUpdateInfo)
-	users.POST("/:id/add-mock-documents", h.addMockDocuments)
 	// auth routes
 	users.GET("/auth/login", h.authLogin)
 	users.GET("/auth/callback", h.authCallback)
 	users.POST("/auth/token", h.exchangeToken)
+	users.POST("/auth/refresh", h.refreshToken)
+	users.POST("/auth/logout", h.userIdentityMiddleware, h.logout)
+	// sessions routes
+	users.GET("/sessions", h.userIdentityMiddleware, h.getSessions)
+	users.DELETE("/sessions", h.userIdentityMiddleware, h.logoutAllSessions)
+	users.DELETE("/sessions/current", h.userIdentityMiddleware, h.logoutCurrentSession)
+	users.DELETE("/sessions/:id", h.userIdentityMiddleware, h.revokeSession)
+	users.GET("/groups/history", h.userIdentityMiddleware, h.getUserGroupHistory)
+	// verification documents routes
+	users.POST("/verification-documents", h.userIdentityMiddleware, h.uploadVerificationDocument)
+	users.GET("/verification-documents", h.userIdentityMiddleware, h.getVerificationDocuments)
+	users.GET("/verification-documents/:id/file", h.userIdentityMiddleware, h.getVerificationDocumentFile)
+
+	users.GET("/household", h.userIdentityMiddleware, h.getHouseholdMembers)
+	users.POST("/household", h.userIdentityMiddleware, h.addHouseholdMember)
+	users.PUT("/household/:id/groups", h.userIdentityMiddleware, h.updateHouseholdMemberGroups)
+	users.POST("/household/:id/relation-document", h.userIdentityMiddleware, h.uploadHouseholdRelationDocument)
+	users.DELETE("/household/:id", h.userIdentityMiddleware, h.deleteHouseholdMember)
+	// documents routes
+	users.GET("/documents", h.userIdentityMiddleware, h.getUserDocuments)
+	users.POST("/documents", h.userIdentityMiddleware, h.createUserDocument)
+	users.PUT("/documents/:id", h.userIdentityMiddleware, h.updateUserDocument)
+	users.DELETE("/documents/:id", h.userIdentityMiddleware, h.deleteUserDocument)
+
+	users.PUT("/eligibility-profile", h.userIdentityMiddleware, h.updateEligibilityProfile)
+	users.POST("/questionnaire/apply", h.userIdentityMiddleware, h.applyQuestionnaireDraft)
+	// contacts routes
+	users.POST("/contacts/email", h.userIdentityMiddleware, h.requestEmailChange)
+	users.POST("/contacts/email/confirm", h.userIdentityMiddleware, h.confirmEmailChange)
+	users.POST("/contacts/phone", h.userIdentityMiddleware, h.requestPhoneChange)
+	users.POST("/contacts/phone/confirm", h.userIdentityMiddleware, h.confirmPhoneChange)
 }
 
 // @Summary Pong
@@ -77,16 +94,35 @@ type userAuthResponse struct {
 func (h *Handler) authLogin(c *gin.Context) {
 	// Генерируем state для защиты от CSRF
 	state := generateState()
-	stateStore[state] = true
+
+	// PKCE защищает от перехвата code при редиректе на фронтенд, nonce связывает id_token с этим входом
+	pkce, err := esia.NewPKCE()
+	if err != nil {
+		logger.Error("generate pkce failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	nonce := generateState()
+
+	stateData := &authstate.StateData{
+		CodeVerifier: pkce.CodeVerifier,
+		Nonce:        nonce,
+		CreatedAt:    time.Now(),
+	}
+	if err := h.authStateStore.SaveState(c.Request.Context(), state, stateData); err != nil {
+		logger.Error("save auth state failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
 
 	// Сохраняем state в cookie для проверки при callback
-	c.SetCookie("esia_state", state, 600, "/", "", false, true)
+	c.SetCookie("esia_state", state, int(authstate.DefaultStateTTL.Seconds()), "/", "", false, true)
 
 	// Создаем ESIA клиент
 	esiaClient := esia.NewClient(h.config.ESIA)
 
 	// Получаем URL авторизации
-	authURL := esiaClient.GetAuthorizationURL(state)
+	authURL := esiaClient.GetAuthorizationURL(state, pkce.CodeChallenge, nonce)
 
 	logger.Info("Redirecting to ESIA", zap.String("url", authURL))
 
@@ -128,7 +164,10 @@ func (h *Handler) authCallback(c *gin.Context) {
 	}
 
 	// Проверяем, что state существует в нашем хранилище
-	if !stateStore[state] {
+	if _, err := h.authStateStore.GetState(c.Request.Context(), state); err != nil {
+		if !errors.Is(err, authstate.ErrNotFound) {
+			logger.Error("get auth state failed", zap.Error(err))
+		}
 		logger.Error("state not found in store")
 		frontendURL := fmt.Sprintf("%s/%s/error?error=state_not_found", h.config.FrontendURL, frontendRedirectPath)
 		c.Redirect(http.StatusFound, frontendURL)
@@ -136,13 +175,12 @@ func (h *Handler) authCallback(c *gin.Context) {
 	}
 
 	// Сохраняем code для последующего обмена на токены
-	codeStoreMutex.Lock()
-	codeStore[code] = &authCodeData{
-		Code:      code,
-		State:     state,
-		CreatedAt: time.Now(),
+	if err := h.authStateStore.SaveCode(c.Request.Context(), code, &authstate.CodeData{State: state, CreatedAt: time.Now()}); err != nil {
+		logger.Error("save auth code failed", zap.Error(err))
+		frontendURL := fmt.Sprintf("%s/%s/error?error=internal_error", h.config.FrontendURL, frontendRedirectPath)
+		c.Redirect(http.StatusFound, frontendURL)
+		return
 	}
-	codeStoreMutex.Unlock()
 
 	logger.Info("Authorization code received", zap.String("code", code[:10]+"..."))
 
@@ -164,9 +202,15 @@ type exchangeTokenRequest struct {
 }
 
 type exchangeTokenResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken uuid.UUID `json:"refresh_token"`
 }
 
+const (
+	refreshTokenCookie     = "refresh_token"
+	refreshTokenCookiePath = "/api/v1/users/auth"
+)
+
 // @Summary Exchange Code for Tokens
 // @Tags Auth
 // @Description Обмен authorization code на access и refresh токены (как в Keycloak)
@@ -187,12 +231,15 @@ func (h *Handler) exchangeToken(c *gin.Context) {
 
 	logger.Info("Token exchange request", zap.String("code", req.Code[:10]+"..."))
 
-	// Проверяем, что code существует и валиден
-	codeStoreMutex.RLock()
-	codeData, exists := codeStore[req.Code]
-	codeStoreMutex.RUnlock()
-
-	if !exists {
+	// Потребляем code: он одноразовый, и из параллельных запросов с одним code пройдет только один.
+	// Просроченные code удаляются хранилищем по TTL
+	codeData, err := h.authStateStore.ConsumeCode(c.Request.Context(), req.Code)
+	if err != nil {
+		if !errors.Is(err, authstate.ErrNotFound) {
+			logger.Error("consume auth code failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
 		logger.Error("code not found")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_code"})
 		return
@@ -207,53 +254,149 @@ func (h *Handler) exchangeToken(c *gin.Context) {
 		return
 	}
 
-	// Проверяем, что state еще валиден
-	if !stateStore[req.State] {
+	// Проверяем, что state еще валиден, и сразу удаляем его (одноразовое использование)
+	stateData, err := h.authStateStore.ConsumeState(c.Request.Context(), req.State)
+	if err != nil {
+		if !errors.Is(err, authstate.ErrNotFound) {
+			logger.Error("consume auth state failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
 		logger.Error("state expired or invalid")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "state_expired"})
 		return
 	}
 
-	// Проверяем срок действия code (10 минут)
-	if time.Since(codeData.CreatedAt) > 10*time.Minute {
-		logger.Error("code expired")
-		codeStoreMutex.Lock()
-		delete(codeStore, req.Code)
-		codeStoreMutex.Unlock()
-		c.JSON(http.StatusBadRequest, gin.H{"error": "code_expired"})
-		return
-	}
-
 	// Обменять code на токены через ESIA
 	result, err := h.services.Users.Auth(
 		c.Request.Context(),
 		req.Code,
+		stateData.CodeVerifier,
+		stateData.Nonce,
 		c.Request.UserAgent(),
 		c.ClientIP(),
 	)
 	if err != nil {
+		if errors.Is(err, service.ErrUserDeactivated) {
+			forbiddenErrorResponse(c, UserDeactivatedCode)
+			return
+		}
 		logger.Error("auth failed", zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "authorization_failed"})
 		return
 	}
 
-	// Удаляем использованные code и state (одноразовое использование)
-	codeStoreMutex.Lock()
-	delete(codeStore, req.Code)
-	codeStoreMutex.Unlock()
-
-	delete(stateStore, req.State)
-
 	logger.Info("Token exchange successful")
 
 	// Возвращаем токены
+	h.setRefreshTokenCookie(c, result)
 	response := exchangeTokenResponse{
-		AccessToken: result.AccessToken,
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// @Summary Refresh Tokens
+// @Tags Auth
+// @Description Обмен refresh токена на новую пару токенов. Refresh токен берется из cookie refresh_token или из тела запроса.
+// @Description Каждый refresh токен одноразовый: при повторном использовании отзываются все токены этого входа.
+// @ModuleID refreshToken
+// @Accept  json
+// @Produce  json
+// @Param input body refreshTokenRequest false "Refresh токен (если не передан в cookie)"
+// @Success 200 {object} exchangeTokenResponse
+// @Failure 401 {object} ErrorStruct
+// @Failure 500
+// @Router /users/auth/refresh [post]
+func (h *Handler) refreshToken(c *gin.Context) {
+	rawToken, err := c.Cookie(refreshTokenCookie)
+	if err != nil || rawToken == "" {
+		var req refreshTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+			unauthorizedErrorResponse(c, UserRefreshTokenCookieNotFoundCode)
+			return
+		}
+		rawToken = req.RefreshToken
+	}
+
+	refreshToken, err := h.tokenManager.ValidateRefreshToken(rawToken)
+	if err != nil {
+		unauthorizedErrorResponse(c, UserRefreshTokenInvalidCode)
+		return
+	}
+
+	result, err := h.services.Users.RefreshTokens(c.Request.Context(), *refreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefreshTokenExpired):
+			unauthorizedErrorResponse(c, UserRefreshTokenExpiredCode)
+		case errors.Is(err, service.ErrRefreshTokenReused):
+			unauthorizedErrorResponse(c, UserRefreshTokenReusedCode)
+		case errors.Is(err, service.ErrRefreshTokenNotFound), errors.Is(err, service.ErrRefreshSessionMismatch):
+			unauthorizedErrorResponse(c, UserRefreshTokenInvalidCode)
+		case errors.Is(err, service.ErrUserDeactivated):
+			h.clearRefreshTokenCookie(c)
+			forbiddenErrorResponse(c, UserDeactivatedCode)
+		default:
+			logger.Error("refresh tokens failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	h.setRefreshTokenCookie(c, result)
+	c.JSON(http.StatusOK, exchangeTokenResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	})
+}
+
+// @Summary Logout
+// @Tags Auth
+// @Description Выход: access токен из заголовка перестает приниматься сразу, refresh токены его сессии отзываются
+// @ModuleID logout
+// @Accept  json
+// @Produce  json
+// @Success 204
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/auth/logout [post]
+func (h *Handler) logout(c *gin.Context) {
+	claims, ok := h.getClaims(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.services.Users.Logout(c.Request.Context(), claims); err != nil {
+		logger.Error("logout failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	h.clearRefreshTokenCookie(c)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) setRefreshTokenCookie(c *gin.Context, tokens *service.Tokens) {
+	c.SetCookie(
+		refreshTokenCookie,
+		tokens.RefreshToken.String(),
+		int(tokens.RefreshTTL.Seconds()),
+		refreshTokenCookiePath,
+		"",
+		h.config.Env != "local",
+		true,
+	)
+}
+
 // generateState генерирует случайный state для OAuth
 func generateState() string {
 	b := make([]byte, 32)
@@ -262,18 +405,31 @@ func generateState() string {
 }
 
 type getProfileResponse struct {
-	ID           uuid.UUID             `json:"id"`
-	Documents    []domain.UserDocument `json:"documents" binding:"omitempty"`
-	ExternalID   *string               `json:"external_id" binding:"omitempty"`
-	FirstName    *string               `json:"first_name" binding:"omitempty"`
-	LastName     *string               `json:"last_name" binding:"omitempty"`
-	MiddleName   *string               `json:"middle_name" binding:"omitempty"`
-	SNILS        *string               `json:"snils" binding:"omitempty"`
-	Email        *string               `json:"email" binding:"omitempty"`
-	PhoneNumber  *string               `json:"phone_number" binding:"omitempty"`
-	CityID       *uuid.UUID            `json:"city_id" binding:"omitempty"`
-	Groups       domain.UserGroupList  `json:"groups" binding:"omitempty"`
-	RegisteredAt *time.Time            `json:"registered_at" binding:"omitempty"`
+	ID           uuid.UUID              `json:"id"`
+	Documents    []userDocumentResponse `json:"documents" binding:"omitempty"`
+	ExternalID   *string                `json:"external_id" binding:"omitempty"`
+	FirstName    *string                `json:"first_name" binding:"omitempty"`
+	LastName     *string                `json:"last_name" binding:"omitempty"`
+	MiddleName   *string                `json:"middle_name" binding:"omitempty"`
+	SNILS        *string                `json:"snils" binding:"omitempty"`
+	Email        *string                `json:"email" binding:"omitempty"`
+	PhoneNumber  *string                `json:"phone_number" binding:"omitempty"`
+	CityID       *uuid.UUID             `json:"city_id" binding:"omitempty"`
+	Groups       domain.UserGroupList   `json:"groups" binding:"omitempty"`
+	RegisteredAt *time.Time             `json:"registered_at" binding:"omitempty"`
+	BirthDate    *string                `json:"birth_date" binding:"omitempty"`
+	Gender       *string                `json:"gender" binding:"omitempty"`
+	INN          *string                `json:"inn" binding:"omitempty"`
+	Citizenship  *string                `json:"citizenship" binding:"omitempty"`
+	Trusted      bool                   `json:"trusted"`
+	// Когда пользователь сменил контакт с подтверждением кодом, null - контакт получен из ЕСИА
+	EmailConfirmedAt *time.Time `json:"email_confirmed_at"`
+	PhoneConfirmedAt *time.Time `json:"phone_confirmed_at"`
+
+	// Данные для проверки права на льготы, null - не указано
+	MonthlyIncome      *int64                     `json:"monthly_income"`
+	ChildrenCount      *int                       `json:"children_count"`
+	DisabilityCategory *domain.DisabilityCategory `json:"disability_category"`
 }
 
 // @Summary Get Profile
@@ -314,7 +470,25 @@ func (h *Handler) getProfile(c *gin.Context) {
 		CityID:       user.CityID,
 		Groups:       user.GroupType,
 		RegisteredAt: user.RegisteredAt,
-		Documents:    user.Documents,
+		Documents:    make([]userDocumentResponse, 0, len(user.Documents)),
+		Gender:       &user.Gender.String,
+		INN:          &user.INN.String,
+		Citizenship:  &user.Citizenship.String,
+		Trusted:      user.Trusted,
+
+		EmailConfirmedAt: user.EmailConfirmedAt,
+		PhoneConfirmedAt: user.PhoneConfirmedAt,
+
+		MonthlyIncome:      user.MonthlyIncome,
+		ChildrenCount:      user.ChildrenCount,
+		DisabilityCategory: user.DisabilityCategory,
+	}
+	if user.BirthDate != nil {
+		birthDate := user.BirthDate.Format("2006-01-02")
+		response.BirthDate = &birthDate
+	}
+	for i := range user.Documents {
+		response.Documents = append(response.Documents, newUserDocumentResponse(&user.Documents[i]))
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -364,72 +538,6 @@ func (h *Handler) userUpdateInfo(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
-// @Summary Add Mock Documents
-// @Tags Users
-// @Description Add mock documents to user
-// @ModuleID addMockDocuments
-// @Accept  json
-// @Produce  json
-// @Param id path string true "User ID"
-// @Success 200
-// @Failure 400 {object} ErrorStruct
-// @Router /users/{id}/add-mock-documents [post]
-func (h *Handler) addMockDocuments(c *gin.Context) {
-
-	id := c.Param("id")
-	if id == "" {
-		logger.Error("user id is empty")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user id is required"})
-		return
-	}
-
-	userID, err := uuid.Parse(id)
-	if err != nil {
-		logger.Error("invalid user id", zap.String("id", id), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
-		return
-	}
-	logger.Info("adding mock documents to user", zap.String("user id", userID.String()))
-
-	documents := []domain.UserDocument{
-		{
-			ID:             uuid.New(),
-			UserID:         userID,
-			DocumentType:   "passport",
-			DocumentNumber: "9800 123456\n выдан МВД по РС(Я) в г. Якутске, 01.01.2014, 140-002",
-			CreatedAt:      time.Now(),
-			UpdatedAt:      time.Now(),
-		},
-		{
-			ID:             uuid.New(),
-			UserID:         userID,
-			DocumentType:   "snils",
-			DocumentNumber: "1234567890",
-			CreatedAt:      time.Now(),
-			UpdatedAt:      time.Now(),
-		},
-		{
-			ID:             uuid.New(),
-			UserID:         userID,
-			DocumentType:   "registration",
-			DocumentNumber: "Республика Саха (Якутия), Якутск, ул. Петра-Алексеева, д. 100, кв.100",
-			CreatedAt:      time.Now(),
-			UpdatedAt:      time.Now(),
-		},
-	}
-
-	for _, document := range documents {
-		err := h.services.Users.CreateDocument(c.Request.Context(), &document)
-		if err != nil {
-			logger.Error("create document failed", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-			return
-		}
-	}
-
-	c.Status(http.StatusOK)
-}
-
 // @Summary Get User Certificate PDF
 // @Tags Users
 // @Description Скачать удостоверение/справку социальной группы в формате PDF. По умолчанию генерируется удостоверение пенсионера.
@@ -444,11 +552,14 @@ func (h *Handler) addMockDocuments(c *gin.Context) {
 // @Description - children (дети)
 // @Description - veterans (ветераны)
 // @Description
+// @Description Если группа подтверждена не у пользователя, а у члена семьи с подтвержденным родством, удостоверение выдается на этого члена семьи.
+// @Description Конкретного члена семьи можно выбрать параметром member_id.
 // @Description Если у пользователя нет реальных данных для указанной группы, будут использованы моковые данные.
 // @ModuleID getUserPensionerCertificatePDF
 // @Accept  json
 // @Produce  application/pdf
 // @Param group_type query string false "Тип социальной группы (по умолчанию: pensioners)"
+// @Param member_id query string false "ID члена семьи (UUID), на которого выдается удостоверение"
 // @Success 200 {file} binary "PDF файл удостоверения/справки"
 // @Failure 400 {object} ErrorStruct
 // @Failure 500 {object} ErrorStruct
@@ -488,6 +599,38 @@ func (h *Handler) getUserPensionerCertificatePDF(c *gin.Context) {
 		return
 	}
 
+	// Член семьи, на которого выдается удостоверение: указанный в запросе или тот, у кого подтверждена группа,
+	// если у самого пользователя она не подтверждена
+	var memberID *uuid.UUID
+	if memberIDStr := c.Query("member_id"); memberIDStr != "" {
+		parsed, err := uuid.Parse(memberIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid member_id"})
+			return
+		}
+		memberID = &parsed
+	} else {
+		householdGroups, err := h.services.Household.GetHouseholdGroups(c.Request.Context(), userID)
+		if err != nil {
+			h.householdErrorResponse(c, err)
+			return
+		}
+		memberID = certificateHolder(householdGroups, groupType)
+	}
+
+	if memberID != nil {
+		pdfBytes, err := h.services.Household.GenerateMemberCertificatePDF(c.Request.Context(), userID, *memberID, groupType)
+		if err != nil {
+			h.householdErrorResponse(c, err)
+			return
+		}
+
+		filename := fmt.Sprintf("%s_certificate_%s.pdf", groupType, memberID.String()[:8])
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+		c.Data(http.StatusOK, "application/pdf", pdfBytes)
+		return
+	}
+
 	logger.Info("Generating user certificate PDF",
 		zap.String("user_id", userID.String()),
 		zap.String("group_type", string(groupType)))
@@ -519,3 +662,21 @@ func (h *Handler) getUserPensionerCertificatePDF(c *gin.Context) {
 		zap.String("group_type", string(groupType)),
 		zap.Int("size", len(pdfBytes)))
 }
+
+// certificateHolder возвращает члена семьи, на которого выдается удостоверение группы groupType.
+// nil - удостоверение выдается на самого пользователя: группа подтверждена у него или ни у кого в семье
+func certificateHolder(groups domain.HouseholdGroups, groupType domain.GroupType) *uuid.UUID {
+	var memberID *uuid.UUID
+	for _, group := range groups {
+		if group.GroupType != groupType {
+			continue
+		}
+		if group.MemberID == nil {
+			return nil
+		}
+		if memberID == nil {
+			memberID = group.MemberID
+		}
+	}
+	return memberID
+}
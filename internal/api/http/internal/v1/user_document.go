@@ -0,0 +1,289 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type userDocumentResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	DocumentType   domain.UserDocumentType `json:"document_type"`
+	DocumentNumber string                  `json:"document_number"`
+	IssuedAt       *string                 `json:"issued_at,omitempty"`
+	IssuedBy       *string                 `json:"issued_by,omitempty"`
+	ExpiresAt      *string                 `json:"expires_at,omitempty"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+}
+
+type userDocumentsResponse struct {
+	Documents []userDocumentResponse `json:"documents"`
+}
+
+type createUserDocumentRequest struct {
+	DocumentType domain.UserDocumentType `json:"document_type" binding:"required"`
+	userDocumentRequest
+}
+
+type userDocumentRequest struct {
+	DocumentNumber string `json:"document_number" binding:"required,max=1000"`
+	IssuedAt       string `json:"issued_at" binding:"omitempty,datetime=2006-01-02"`
+	IssuedBy       string `json:"issued_by" binding:"max=255"`
+	ExpiresAt      string `json:"expires_at" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// @Summary Get User Documents
+// @Tags Users
+// @Description Документы пользователя: паспорт, СНИЛС, регистрация
+// @ModuleID getUserDocuments
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} userDocumentsResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/documents [get]
+func (h *Handler) getUserDocuments(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	documents, err := h.services.UserDocuments.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		h.userDocumentErrorResponse(c, err)
+		return
+	}
+
+	response := userDocumentsResponse{
+		Documents: make([]userDocumentResponse, 0, len(documents)),
+	}
+	for i := range documents {
+		response.Documents = append(response.Documents, newUserDocumentResponse(&documents[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Create User Document
+// @Tags Users
+// @Description Добавить документ. У пользователя может быть один документ каждого типа.
+// @Description document_type: passport (серия и номер: 4 + 6 цифр), snils (11 цифр, проверяется контрольное число), registration (адрес).
+// @Description Номера паспорта и СНИЛС сохраняются в виде 9800 123456 и 123-456-789 00
+// @ModuleID createUserDocument
+// @Accept  json
+// @Produce  json
+// @Param input body createUserDocumentRequest true "Документ"
+// @Success 201 {object} userDocumentResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/documents [post]
+func (h *Handler) createUserDocument(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var req createUserDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	input, ok := userDocumentInputFromRequest(c, &req.userDocumentRequest)
+	if !ok {
+		return
+	}
+	input.DocumentType = req.DocumentType
+
+	document, err := h.services.UserDocuments.Create(c.Request.Context(), userID, input)
+	if err != nil {
+		h.userDocumentErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newUserDocumentResponse(document))
+}
+
+// @Summary Update User Document
+// @Tags Users
+// @Description Изменить номер и реквизиты документа. Тип документа не меняется
+// @ModuleID updateUserDocument
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Document ID (UUID)"
+// @Param input body userDocumentRequest true "Документ"
+// @Success 200 {object} userDocumentResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/documents/{id} [put]
+func (h *Handler) updateUserDocument(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	var req userDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	input, ok := userDocumentInputFromRequest(c, &req)
+	if !ok {
+		return
+	}
+
+	document, err := h.services.UserDocuments.Update(c.Request.Context(), userID, id, input)
+	if err != nil {
+		h.userDocumentErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newUserDocumentResponse(document))
+}
+
+// @Summary Delete User Document
+// @Tags Users
+// @Description Удалить документ
+// @ModuleID deleteUserDocument
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Document ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/documents/{id} [delete]
+func (h *Handler) deleteUserDocument(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	if err := h.services.UserDocuments.Delete(c.Request.Context(), userID, id); err != nil {
+		h.userDocumentErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// userDocumentInputFromRequest разбирает даты документа. При ошибке отвечает клиенту и возвращает false
+func userDocumentInputFromRequest(c *gin.Context, req *userDocumentRequest) (service.UserDocumentInput, bool) {
+	input := service.UserDocumentInput{
+		DocumentNumber: req.DocumentNumber,
+		IssuedBy:       req.IssuedBy,
+	}
+
+	if req.IssuedAt != "" {
+		issuedAt, err := time.Parse("2006-01-02", req.IssuedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid issued_at format. Use YYYY-MM-DD"})
+			return input, false
+		}
+		input.IssuedAt = &issuedAt
+	}
+
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid expires_at format. Use YYYY-MM-DD"})
+			return input, false
+		}
+		input.ExpiresAt = &expiresAt
+	}
+
+	return input, true
+}
+
+func (h *Handler) userDocumentErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserDocumentNotFound):
+		errorResponse(c, UserDocumentNotFoundCode)
+	case errors.Is(err, service.ErrUserDocumentExists):
+		errorResponse(c, UserDocumentExistsCode)
+	case errors.Is(err, service.ErrInvalidUserDocumentType):
+		errorResponse(c, InvalidUserDocumentTypeCode)
+	case errors.Is(err, service.ErrInvalidUserDocumentNumber):
+		errorResponse(c, InvalidUserDocumentNumberCode)
+	case errors.Is(err, service.ErrInvalidUserDocumentDates):
+		errorResponse(c, InvalidUserDocumentDatesCode)
+	case errors.Is(err, service.ErrInvalidPassport):
+		errorResponse(c, InvalidPassportCode)
+	case errors.Is(err, service.ErrInvalidSNILS):
+		errorResponse(c, InvalidSNILSCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	default:
+		logger.Error("user document request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func newUserDocumentResponse(document *domain.UserDocument) userDocumentResponse {
+	response := userDocumentResponse{
+		ID:             document.ID,
+		DocumentType:   document.DocumentType,
+		DocumentNumber: document.DocumentNumber,
+		CreatedAt:      document.CreatedAt,
+		UpdatedAt:      document.UpdatedAt,
+	}
+	if document.IssuedAt != nil {
+		issuedAt := document.IssuedAt.Format("2006-01-02")
+		response.IssuedAt = &issuedAt
+	}
+	if document.IssuedBy.Valid {
+		response.IssuedBy = &document.IssuedBy.String
+	}
+	if document.ExpiresAt != nil {
+		expiresAt := document.ExpiresAt.Format("2006-01-02")
+		response.ExpiresAt = &expiresAt
+	}
+	return response
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type userGroupEventResponse struct {
+	// HouseholdMemberID - член семьи, которому принадлежит группа. Пусто для групп самого пользователя
+	HouseholdMemberID *uuid.UUID                  `json:"household_member_id,omitempty"`
+	GroupType         domain.GroupType            `json:"group_type"`
+	FromStatus        *domain.VerificationStatus  `json:"from_status"`
+	ToStatus          *domain.VerificationStatus  `json:"to_status"`
+	Source            domain.UserGroupEventSource `json:"source"`
+	Message           *string                     `json:"message,omitempty"`
+	CreatedAt         time.Time                   `json:"created_at"`
+}
+
+// adminUserGroupEventResponse - событие с данными для поддержки: кто принял решение, ID запроса
+// к сервису проверки и его ответ
+type adminUserGroupEventResponse struct {
+	ID uuid.UUID `json:"id"`
+	userGroupEventResponse
+	ActorID           *uuid.UUID      `json:"actor_id,omitempty"`
+	ExternalRequestID *string         `json:"external_request_id,omitempty"`
+	Payload           json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+}
+
+// @Summary Get User Group History
+// @Tags Users
+// @Description История статусов социальных групп пользователя, новые события первыми.
+// @Description Источник изменения: user, checker (проверка по СНИЛС), moderator (решение по документу), expiry (истек срок), profile_sync (сменился СНИЛС в ЕСИА).
+// @Description Пустой from_status - группа добавлена в профиль, пустой to_status - убрана из профиля.
+// @Description События групп членов семьи отмечены household_member_id
+// @ModuleID getUserGroupHistory
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} userGroupEventResponse
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/groups/history [get]
+func (h *Handler) getUserGroupHistory(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	events, err := h.services.Users.GetGroupEvents(c.Request.Context(), userID)
+	if err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	response := make([]userGroupEventResponse, 0, len(events))
+	for i := range events {
+		response = append(response, newUserGroupEventResponse(&events[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Get User Group History For Support
+// @Tags Admin
+// @Description История статусов социальных групп пользователя с ID запросов к сервису проверки, его ответами и решениями модераторов
+// @ModuleID getAdminUserGroupHistory
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {array} adminUserGroupEventResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/users/{id}/group-history [get]
+func (h *Handler) getAdminUserGroupHistory(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	events, err := h.services.Users.GetGroupEvents(c.Request.Context(), userID)
+	if err != nil {
+		h.userStateErrorResponse(c, err)
+		return
+	}
+
+	response := make([]adminUserGroupEventResponse, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		item := adminUserGroupEventResponse{
+			ID:                     event.ID,
+			userGroupEventResponse: newUserGroupEventResponse(event),
+			ActorID:                event.ActorID,
+		}
+		if event.ExternalRequestID.Valid {
+			item.ExternalRequestID = &event.ExternalRequestID.String
+		}
+		if event.Payload.Valid {
+			item.Payload = json.RawMessage(event.Payload.String)
+		}
+		response = append(response, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func newUserGroupEventResponse(event *domain.UserGroupEvent) userGroupEventResponse {
+	response := userGroupEventResponse{
+		HouseholdMemberID: event.HouseholdMemberID,
+		GroupType:         event.GroupType,
+		FromStatus:        event.FromStatus,
+		ToStatus:          event.ToStatus,
+		Source:            event.Source,
+		CreatedAt:         event.CreatedAt,
+	}
+	if event.Message.Valid {
+		response.Message = &event.Message.String
+	}
+	return response
+}
@@ -0,0 +1,421 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead - запас на заголовки multipart сверх максимального размера файла
+const multipartOverhead = 64 << 10
+
+type verificationDocumentResponse struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	// HouseholdMemberID - член семьи, родство с которым подтверждает документ. Пусто для документов о группах
+	HouseholdMemberID *uuid.UUID                        `json:"household_member_id,omitempty"`
+	GroupType         domain.GroupType                  `json:"group_type,omitempty"`
+	FileName          string                            `json:"file_name"`
+	ContentType       string                            `json:"content_type"`
+	Size              int64                             `json:"size"`
+	Status            domain.VerificationDocumentStatus `json:"status"`
+	RejectReason      *string                           `json:"reject_reason,omitempty"`
+	ReviewedBy        *uuid.UUID                        `json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time                        `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time                         `json:"created_at"`
+	// Member - член семьи для сверки с документом о родстве, только в очереди модерации
+	Member *domain.VerificationDocumentMember `json:"member,omitempty"`
+}
+
+type verificationDocumentsResponse struct {
+	Documents []verificationDocumentResponse `json:"documents"`
+}
+
+type verificationDocumentsQueueResponse struct {
+	Documents []verificationDocumentResponse `json:"documents"`
+	Total     int64                          `json:"total"`
+	Page      int                            `json:"page"`
+	Limit     int                            `json:"limit"`
+}
+
+type rejectVerificationDocumentRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+// @Summary Upload Verification Document
+// @Tags Users
+// @Description Загрузить скан документа, подтверждающего социальную группу, если проверка по СНИЛС ее не подтвердила.
+// @Description Группа должна быть в профиле и не быть подтвержденной. После загрузки группа ожидает решения модератора.
+// @Description Принимаются файлы pdf, jpeg и png
+// @ModuleID uploadVerificationDocument
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param group_type formData string true "Тип социальной группы"
+// @Param file formData file true "Скан документа"
+// @Success 201 {object} verificationDocumentResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/verification-documents [post]
+func (h *Handler) uploadVerificationDocument(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	maxSize := h.config.Storage.VerificationDocumentMaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, DocumentTooLargeCode)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("open uploaded file failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	defer file.Close()
+
+	document, err := h.services.VerificationDocuments.Upload(c.Request.Context(), service.VerificationDocumentUploadInput{
+		UserID:    userID,
+		GroupType: domain.GroupType(c.PostForm("group_type")),
+		FileName:  fileHeader.Filename,
+		Size:      fileHeader.Size,
+		File:      file,
+	})
+	if err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+
+	logger.Info("verification document uploaded",
+		zap.String("user_id", userID.String()),
+		zap.String("document_id", document.ID.String()),
+		zap.String("group_type", string(document.GroupType)))
+
+	c.JSON(http.StatusCreated, newVerificationDocumentResponse(document))
+}
+
+// @Summary Get Verification Documents
+// @Tags Users
+// @Description Загруженные пользователем документы и решения модераторов, новые первыми
+// @ModuleID getVerificationDocuments
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} verificationDocumentsResponse
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/verification-documents [get]
+func (h *Handler) getVerificationDocuments(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	documents, err := h.services.VerificationDocuments.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("get verification documents failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, verificationDocumentsResponse{Documents: newVerificationDocumentsResponse(documents)})
+}
+
+// @Summary Get Verification Document File
+// @Tags Users
+// @Description Скачать загруженный пользователем документ
+// @ModuleID getVerificationDocumentFile
+// @Accept  json
+// @Produce  application/octet-stream
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 500
+// @Security UserAuth
+// @Router /users/verification-documents/{id}/file [get]
+func (h *Handler) getVerificationDocumentFile(c *gin.Context) {
+	userID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	document, file, err := h.services.VerificationDocuments.GetUserDocumentFile(c.Request.Context(), userID, id)
+	if err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	sendVerificationDocumentFile(c, document, file)
+}
+
+// @Summary Get Verification Documents Queue
+// @Tags Admin
+// @Description Очередь документов на проверку, старые первыми. По умолчанию возвращаются документы, ожидающие решения.
+// @Description У документов о родстве в member - член семьи для сверки, snils_collisions > 0 означает, что тот же СНИЛС
+// @Description добавлен членом семьи на других аккаунтах
+// @ModuleID getVerificationDocumentsQueue
+// @Accept  json
+// @Produce  json
+// @Param status query string false "Статус: pending (по умолчанию), approved, rejected"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество на странице (до 100)"
+// @Success 200 {object} verificationDocumentsQueueResponse
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/verification-documents [get]
+func (h *Handler) getVerificationDocumentsQueue(c *gin.Context) {
+	status := domain.VerificationDocumentStatusPending
+	if s := c.Query("status"); s != "" {
+		status = domain.VerificationDocumentStatus(s)
+		if !status.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+	}
+
+	page := 1
+	limit := 20
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	documents, total, err := h.services.VerificationDocuments.GetQueue(c.Request.Context(), status, page, limit)
+	if err != nil {
+		logger.Error("get verification documents queue failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	response := newVerificationDocumentsResponse(documents)
+	for i := range response {
+		response[i].Member = documents[i].Member
+	}
+
+	c.JSON(http.StatusOK, verificationDocumentsQueueResponse{
+		Documents: response,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	})
+}
+
+// @Summary Get Verification Document File For Review
+// @Tags Admin
+// @Description Скачать документ пользователя для проверки
+// @ModuleID getVerificationDocumentFileForReview
+// @Accept  json
+// @Produce  application/octet-stream
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/verification-documents/{id}/file [get]
+func (h *Handler) getVerificationDocumentFileForReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	document, file, err := h.services.VerificationDocuments.GetFile(c.Request.Context(), id)
+	if err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	sendVerificationDocumentFile(c, document, file)
+}
+
+// @Summary Approve Verification Document
+// @Tags Admin
+// @Description Одобрить документ. Группа пользователя становится подтвержденной на тот же срок, что и при проверке по СНИЛС.
+// @Description Документ о родстве подтверждает родство с членом семьи, если тот же СНИЛС не подтвержден на допустимом числе других аккаунтов
+// @ModuleID approveVerificationDocument
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Document ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/verification-documents/{id}/approve [post]
+func (h *Handler) approveVerificationDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	moderatorID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if err := h.services.VerificationDocuments.Approve(c.Request.Context(), id, moderatorID); err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reject Verification Document
+// @Tags Admin
+// @Description Отклонить документ с указанием причины. Причина показывается пользователю в статусе группы
+// @ModuleID rejectVerificationDocument
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Document ID (UUID)"
+// @Param input body rejectVerificationDocumentRequest true "Причина отказа"
+// @Success 204
+// @Failure 400 {object} ErrorStruct
+// @Failure 401
+// @Failure 403 {object} ErrorStruct
+// @Failure 500
+// @Security AdminAuth
+// @Router /admin/verification-documents/{id}/reject [post]
+func (h *Handler) rejectVerificationDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid document id"})
+		return
+	}
+
+	var req rejectVerificationDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			validationErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	moderatorID, err := h.getUserUUID(c)
+	if err != nil {
+		logger.Error("get user id failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if err := h.services.VerificationDocuments.Reject(c.Request.Context(), id, moderatorID, req.Reason); err != nil {
+		h.verificationDocumentErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) verificationDocumentErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVerificationDocumentNotFound):
+		errorResponse(c, VerificationDocumentNotFoundCode)
+	case errors.Is(err, service.ErrVerificationDocumentReviewed):
+		errorResponse(c, VerificationDocumentReviewedCode)
+	case errors.Is(err, service.ErrGroupNotAwaitingVerification):
+		errorResponse(c, GroupNotAwaitingVerificationCode)
+	case errors.Is(err, service.ErrInvalidGroupType):
+		errorResponse(c, InvalidGroupTypeCode)
+	case errors.Is(err, service.ErrInvalidDocumentFile):
+		errorResponse(c, InvalidDocumentFileCode)
+	case errors.Is(err, service.ErrDocumentTooLarge):
+		errorResponse(c, DocumentTooLargeCode)
+	case errors.Is(err, service.ErrRejectReasonRequired):
+		errorResponse(c, RejectReasonRequiredCode)
+	case errors.Is(err, service.ErrUserNotFound):
+		errorResponse(c, UserNotFoundCode)
+	case errors.Is(err, service.ErrHouseholdMemberNotFound):
+		errorResponse(c, HouseholdMemberNotFoundCode)
+	case errors.Is(err, service.ErrHouseholdRelationNotAwaitingDoc):
+		errorResponse(c, HouseholdRelationNotAwaitingCode)
+	case errors.Is(err, service.ErrHouseholdMemberClaimed):
+		errorResponse(c, HouseholdMemberClaimedCode)
+	default:
+		logger.Error("verification document request failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func sendVerificationDocumentFile(c *gin.Context, document *domain.VerificationDocument, file io.Reader) {
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName})
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, file, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+func newVerificationDocumentsResponse(documents []domain.VerificationDocument) []verificationDocumentResponse {
+	response := make([]verificationDocumentResponse, 0, len(documents))
+	for i := range documents {
+		response = append(response, newVerificationDocumentResponse(&documents[i]))
+	}
+	return response
+}
+
+func newVerificationDocumentResponse(document *domain.VerificationDocument) verificationDocumentResponse {
+	return verificationDocumentResponse{
+		ID:                document.ID,
+		UserID:            document.UserID,
+		HouseholdMemberID: document.HouseholdMemberID,
+		GroupType:         document.GroupType,
+		FileName:          document.FileName,
+		ContentType:       document.ContentType,
+		Size:              document.Size,
+		Status:            document.Status,
+		RejectReason:      document.RejectReason,
+		ReviewedBy:        document.ReviewedBy,
+		ReviewedAt:        document.ReviewedAt,
+		CreatedAt:         document.CreatedAt,
+	}
+}
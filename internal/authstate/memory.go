@@ -0,0 +1,139 @@
+package authstate
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry[T any] struct {
+	data      T
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	sync.Mutex
+
+	states   map[string]memoryEntry[StateData]
+	codes    map[string]memoryEntry[CodeData]
+	stateTTL time.Duration
+	codeTTL  time.Duration
+	// nextCleanup - когда при следующей записи удалить просроченные записи
+	nextCleanup time.Time
+}
+
+// NewMemoryStore создает хранилище в памяти процесса. Подходит только для тестов и локального запуска
+// в одном экземпляре. Просроченные записи удаляются при чтении и при записи, не чаще раза в codeTTL
+func NewMemoryStore(stateTTL time.Duration, codeTTL time.Duration) Store {
+	return &memoryStore{
+		states:   make(map[string]memoryEntry[StateData]),
+		codes:    make(map[string]memoryEntry[CodeData]),
+		stateTTL: stateTTL,
+		codeTTL:  codeTTL,
+	}
+}
+
+func (s *memoryStore) SaveState(_ context.Context, state string, data *StateData) error {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	s.cleanupExpired(now)
+	s.states[state] = memoryEntry[StateData]{data: *data, expiresAt: now.Add(s.stateTTL)}
+
+	return nil
+}
+
+func (s *memoryStore) GetState(_ context.Context, state string) (*StateData, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.states[state]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.states, state)
+		return nil, ErrNotFound
+	}
+
+	return &entry.data, nil
+}
+
+func (s *memoryStore) ConsumeState(_ context.Context, state string) (*StateData, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.states[state]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(s.states, state)
+
+	if time.Now().After(entry.expiresAt) {
+		return nil, ErrNotFound
+	}
+
+	return &entry.data, nil
+}
+
+func (s *memoryStore) SaveCode(_ context.Context, code string, data *CodeData) error {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	s.cleanupExpired(now)
+	s.codes[code] = memoryEntry[CodeData]{data: *data, expiresAt: now.Add(s.codeTTL)}
+
+	return nil
+}
+
+func (s *memoryStore) ConsumeCode(_ context.Context, code string) (*CodeData, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.codes[code]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(s.codes, code)
+
+	if time.Now().After(entry.expiresAt) {
+		return nil, ErrNotFound
+	}
+
+	return &entry.data, nil
+}
+
+func (s *memoryStore) Cleanup(_ context.Context) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.deleteExpired(time.Now())
+
+	return nil
+}
+
+// cleanupExpired удаляет просроченные записи, если с прошлой очистки прошло больше codeTTL.
+// Вызывается под блокировкой
+func (s *memoryStore) cleanupExpired(now time.Time) {
+	if now.Before(s.nextCleanup) {
+		return
+	}
+	s.deleteExpired(now)
+}
+
+func (s *memoryStore) deleteExpired(now time.Time) {
+	s.nextCleanup = now.Add(s.codeTTL)
+
+	for state, entry := range s.states {
+		if now.After(entry.expiresAt) {
+			delete(s.states, state)
+		}
+	}
+	for code, entry := range s.codes {
+		if now.After(entry.expiresAt) {
+			delete(s.codes, code)
+		}
+	}
+}
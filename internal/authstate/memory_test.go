@@ -0,0 +1,60 @@
+package authstate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreDeletesExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10*time.Millisecond, 10*time.Millisecond).(*memoryStore)
+
+	if err := store.SaveState(ctx, "abandoned", &StateData{}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+	if err := store.SaveCode(ctx, "abandoned", &CodeData{}); err != nil {
+		t.Fatalf("SaveCode() error = %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// Запись после истечения удаляет брошенные state и code без вызова Cleanup
+	if err := store.SaveState(ctx, "fresh", &StateData{}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	store.Lock()
+	_, stateLeft := store.states["abandoned"]
+	_, codeLeft := store.codes["abandoned"]
+	store.Unlock()
+	if stateLeft || codeLeft {
+		t.Fatalf("expired entries are kept: state %v, code %v", stateLeft, codeLeft)
+	}
+
+	if _, err := store.GetState(ctx, "fresh"); err != nil {
+		t.Fatalf("GetState() error = %v", err)
+	}
+}
+
+func TestMemoryStoreGetStateDeletesExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10*time.Millisecond, time.Hour).(*memoryStore)
+
+	if err := store.SaveState(ctx, "state", &StateData{}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := store.GetState(ctx, "state"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetState() error = %v, want %v", err, ErrNotFound)
+	}
+
+	store.Lock()
+	defer store.Unlock()
+	if len(store.states) != 0 {
+		t.Fatalf("expired state is kept after GetState")
+	}
+}
@@ -0,0 +1,99 @@
+package authstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	stateKeyPrefix = "oauth:state:"
+	codeKeyPrefix  = "oauth:code:"
+)
+
+type redisStore struct {
+	client   redis.UniversalClient
+	stateTTL time.Duration
+	codeTTL  time.Duration
+}
+
+// NewRedisStore создает хранилище в Redis. Просроченные записи удаляет сам Redis по TTL
+func NewRedisStore(client redis.UniversalClient, stateTTL time.Duration, codeTTL time.Duration) Store {
+	return &redisStore{
+		client:   client,
+		stateTTL: stateTTL,
+		codeTTL:  codeTTL,
+	}
+}
+
+func (s *redisStore) SaveState(ctx context.Context, state string, data *StateData) error {
+	return s.set(ctx, stateKeyPrefix+state, data, s.stateTTL)
+}
+
+func (s *redisStore) GetState(ctx context.Context, state string) (*StateData, error) {
+	var data StateData
+	if err := s.get(s.client.Get(ctx, stateKeyPrefix+state), &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (s *redisStore) ConsumeState(ctx context.Context, state string) (*StateData, error) {
+	var data StateData
+	if err := s.get(s.client.GetDel(ctx, stateKeyPrefix+state), &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (s *redisStore) SaveCode(ctx context.Context, code string, data *CodeData) error {
+	return s.set(ctx, codeKeyPrefix+code, data, s.codeTTL)
+}
+
+func (s *redisStore) ConsumeCode(ctx context.Context, code string) (*CodeData, error) {
+	var data CodeData
+	if err := s.get(s.client.GetDel(ctx, codeKeyPrefix+code), &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (s *redisStore) Cleanup(_ context.Context) error {
+	return nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal auth state failed: %w", err)
+	}
+
+	if err := s.client.Set(ctx, key, payload, ttl).Err(); err != nil {
+		return fmt.Errorf("redis set auth state failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisStore) get(cmd *redis.StringCmd, value any) error {
+	payload, err := cmd.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("redis get auth state failed: %w", err)
+	}
+
+	if err := json.Unmarshal(payload, value); err != nil {
+		return fmt.Errorf("unmarshal auth state failed: %w", err)
+	}
+
+	return nil
+}
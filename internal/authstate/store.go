@@ -0,0 +1,47 @@
+// Package authstate хранит промежуточное состояние OAuth авторизации через ESIA:
+// state, выданный при редиректе на ESIA, и authorization code, полученный в callback.
+// Записи живут ограниченное время и потребляются однократно, поэтому хранилище
+// должно быть общим для всех реплик сервиса
+package authstate
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("auth state not found")
+
+const (
+	DefaultStateTTL = 10 * time.Minute
+	DefaultCodeTTL  = 10 * time.Minute
+)
+
+// StateData - данные, сохраняемые при редиректе пользователя на ESIA
+type StateData struct {
+	// CodeVerifier - PKCE verifier, передается в ESIA при обмене code на токены
+	CodeVerifier string `json:"code_verifier"`
+	// Nonce - значение, которое ESIA должна вернуть в id_token
+	Nonce     string    `json:"nonce"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CodeData - authorization code, полученный от ESIA, с привязкой к state
+type CodeData struct {
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Store interface {
+	SaveState(ctx context.Context, state string, data *StateData) error
+	// GetState возвращает данные state, не потребляя его
+	GetState(ctx context.Context, state string) (*StateData, error)
+	// ConsumeState атомарно возвращает и удаляет state: из параллельных запросов его получит только один
+	ConsumeState(ctx context.Context, state string) (*StateData, error)
+	SaveCode(ctx context.Context, code string, data *CodeData) error
+	// ConsumeCode атомарно возвращает и удаляет code
+	ConsumeCode(ctx context.Context, code string) (*CodeData, error)
+	// Cleanup удаляет просроченные записи
+	Cleanup(ctx context.Context) error
+}
+
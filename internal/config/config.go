@@ -17,9 +17,12 @@ type Config struct {
 	Auth               AuthConfig
 	SMTP               SMTPConfig
 	Email              EmailConfig
+	SMS                SMSConfig
 	Cache              Cache
 	ESIA               ESIAConfig
 	SocialGroupChecker SocialGroupCheckerConfig
+	Benefits           BenefitsConfig
+	Storage            StorageConfig
 	Gigachat           GigachatConfig
 	Yandex             YandexConfig
 }
@@ -53,12 +56,44 @@ type AuthConfig struct {
 	JWT                    JWTConfig
 	PasswordSalt           string `env:"AUTH_PASSWORD_SALT" env-required:"true"`
 	VerificationCodeLength int    `env:"AUTH_VERIFICATION_CODE_LENGTH" env-default:"6"`
+	// AdminExternalIDs - ESIA OID пользователей, которые получают роль administrator при входе
+	AdminExternalIDs []string `env:"AUTH_ADMIN_EXTERNAL_IDS" env-separator:"," env-default:""`
+	Staff            StaffAuthConfig
+	ContactChange    ContactChangeConfig
+}
+
+// ContactChangeConfig - смена email и телефона пользователем по одноразовому коду.
+// Длина кода задается AUTH_VERIFICATION_CODE_LENGTH
+type ContactChangeConfig struct {
+	CodeTTL time.Duration `env:"AUTH_CONTACT_CODE_TTL" env-default:"10m"`
+	// ResendInterval - через сколько можно запросить новый код
+	ResendInterval time.Duration `env:"AUTH_CONTACT_CODE_RESEND_INTERVAL" env-default:"1m"`
+	// MaxAttempts - сколько неверных кодов можно ввести, после этого код сбрасывается
+	MaxAttempts int `env:"AUTH_CONTACT_CODE_MAX_ATTEMPTS" env-default:"5"`
+	// MaxCodesPerHour - сколько кодов на один вид контакта пользователь может запросить за час
+	MaxCodesPerHour int `env:"AUTH_CONTACT_CODE_MAX_PER_HOUR" env-default:"5"`
+}
+
+// StaffAuthConfig - вход сотрудников по логину и паролю
+type StaffAuthConfig struct {
+	// После MaxFailedAttempts неверных паролей или TOTP кодов подряд вход блокируется на LockoutDuration
+	MaxFailedAttempts int           `env:"AUTH_STAFF_MAX_FAILED_ATTEMPTS" env-default:"5"`
+	LockoutDuration   time.Duration `env:"AUTH_STAFF_LOCKOUT_DURATION" env-default:"15m"`
+	// MFAChallengeTTL - сколько действует вход по паролю в ожидании TOTP кода
+	MFAChallengeTTL time.Duration `env:"AUTH_STAFF_MFA_CHALLENGE_TTL" env-default:"5m"`
+	TOTPIssuer      string        `env:"AUTH_STAFF_TOTP_ISSUER" env-default:"Hack The Ice"`
 }
 
 type JWTConfig struct {
 	AccessTokenTTL  time.Duration `env:"JWT_ACCESS_TOKEN_TTL" env-default:"1m"`
 	RefreshTokenTTL time.Duration `env:"JWT_REFRESH_TOKEN_TTL" env-default:"240h"`
-	SigningKey      string        `env:"JWT_SIGNING_KEY" env-required:"true"`
+	// SigningMethod - HS256 (общий секрет JWT_SIGNING_KEY), RS256 или EdDSA (ключи с ротацией, публикуются в JWKS)
+	SigningMethod       string        `env:"JWT_SIGNING_METHOD" env-default:"HS256"`
+	SigningKey          string        `env:"JWT_SIGNING_KEY" env-default:""`
+	KeyRotationInterval time.Duration `env:"JWT_KEY_ROTATION_INTERVAL" env-default:"720h"`
+	KeyGracePeriod      time.Duration `env:"JWT_KEY_GRACE_PERIOD" env-default:"24h"`
+	// KeyEncryptionKey - ключ AES-256 (32 байта в base64), которым ключи подписи RS256/EdDSA шифруются в Redis
+	KeyEncryptionKey string `env:"JWT_KEY_ENCRYPTION_KEY" env-default:""`
 }
 
 type SMTPConfig struct {
@@ -74,7 +109,14 @@ type EmailConfig struct {
 }
 
 type EmailTemplates struct {
-	Verification string `env:"EMAIL_TEMPLATE_VERIFICATION" env-required:"true"`
+	Verification  string `env:"EMAIL_TEMPLATE_VERIFICATION" env-default:"verification_email.html"`
+	GroupExpired  string `env:"EMAIL_TEMPLATE_GROUP_EXPIRED" env-default:"group_expired.html"`
+	BenefitEnding string `env:"EMAIL_TEMPLATE_BENEFIT_ENDING" env-default:"benefit_ending.html"`
+}
+
+type SMSConfig struct {
+	// Provider - реализация отправки SMS, сейчас только mock: сообщения пишутся в лог
+	Provider string `env:"SMS_PROVIDER" env-default:"mock"`
 }
 
 type Cache struct {
@@ -97,10 +139,44 @@ type ESIAConfig struct {
 	ClientID    string `env:"ESIA_CLIENT_ID" env-default:"test_client"`
 	RedirectURI string `env:"ESIA_REDIRECT_URI" env-default:"http://localhost:8080/api/v1/users/auth/callback"`
 	Scope       string `env:"ESIA_SCOPE" env-default:"openid profile email"`
+	// Issuer - ожидаемый iss в id_token, по умолчанию BaseURL
+	Issuer string `env:"ESIA_ISSUER" env-default:""`
+	// Ключи для проверки подписи id_token: JWKS по URL или PEM файл с сертификатами.
+	// Без ключей приложение не запускается
+	JWKSURL   string `env:"ESIA_JWKS_URL" env-default:""`
+	CertsPath string `env:"ESIA_CERTS_PATH" env-default:""`
+	// InsecureSkipVerify отключает проверку подписи id_token, если ключи не заданы. Допустимо только при ENV=local
+	InsecureSkipVerify bool `env:"ESIA_INSECURE_SKIP_ID_TOKEN_VERIFY" env-default:"false"`
 }
 
 type SocialGroupCheckerConfig struct {
 	BaseURL string `env:"SOCIAL_GROUP_CHECKER_BASE_URL" env-default:"https://social-group-checker-mock-production.up.railway.app"`
+	// ValidityPeriod - сколько действует подтверждение группы
+	ValidityPeriod time.Duration `env:"SOCIAL_GROUP_VALIDITY_PERIOD" env-default:"8760h"`
+	// ReverifyBefore - за сколько до истечения подтвержденная группа отправляется на повторную проверку
+	ReverifyBefore time.Duration `env:"SOCIAL_GROUP_REVERIFY_BEFORE" env-default:"336h"`
+	// ExpirySchedule - cron расписание задачи, которая переводит группы в expired и запускает повторные проверки
+	ExpirySchedule string `env:"SOCIAL_GROUP_EXPIRY_SCHEDULE" env-default:"@every 1h"`
+}
+
+type BenefitsConfig struct {
+	// PublicationSchedule - cron расписание задачи, которая публикует и снимает с публикации льготы по publish_at и unpublish_at
+	PublicationSchedule string `env:"BENEFIT_PUBLICATION_SCHEDULE" env-default:"@every 1m"`
+	// LifecycleSchedule - cron расписание задачи, которая архивирует истекшие льготы и предупреждает об их окончании
+	LifecycleSchedule string `env:"BENEFIT_LIFECYCLE_SCHEDULE" env-default:"@every 1h"`
+	// EndingNoticeBefore - за сколько до окончания срока действия льготы предупредить добавивших ее в избранное
+	EndingNoticeBefore time.Duration `env:"BENEFIT_ENDING_NOTICE_BEFORE" env-default:"168h"`
+	// ImportMaxSize - максимальный размер файла импорта льгот, байт
+	ImportMaxSize int64 `env:"BENEFIT_IMPORT_MAX_SIZE" env-default:"10485760"`
+}
+
+// StorageConfig - хранилище загруженных файлов
+type StorageConfig struct {
+	// Type - реализация хранилища, сейчас только local
+	Type      string `env:"STORAGE_TYPE" env-default:"local"`
+	LocalPath string `env:"STORAGE_LOCAL_PATH" env-default:"./data/uploads"`
+	// VerificationDocumentMaxSize - максимальный размер скана документа для подтверждения группы, байт
+	VerificationDocumentMaxSize int64 `env:"STORAGE_VERIFICATION_DOCUMENT_MAX_SIZE" env-default:"10485760"`
 }
 
 type GigachatConfig struct {
@@ -31,6 +31,22 @@ const (
 	Commercial BenefitLevel = "commercial"
 )
 
+func (t TargetGroup) IsValid() bool {
+	switch t {
+	case Pensioners, Disabled, YoungFamilies, LowIncome, Students, LargeFamilies, Children, Veterans:
+		return true
+	}
+	return false
+}
+
+func (l BenefitLevel) IsValid() bool {
+	switch l {
+	case Regional, Federal, Commercial:
+		return true
+	}
+	return false
+}
+
 // TargetGroupList - кастомный тип для работы с JSON в БД
 type TargetGroupList []TargetGroup
 
@@ -119,6 +135,14 @@ const (
 	Top         BenefitTag = "top"
 )
 
+func (t BenefitTag) IsValid() bool {
+	switch t {
+	case MostPopular, New, Hot, Best, Recommended, Popular, Top:
+		return true
+	}
+	return false
+}
+
 type BenefitTagList []BenefitTag
 
 // Scan implements sql.Scanner interface
@@ -156,6 +180,14 @@ const (
 	Other     Category = "other"
 )
 
+func (c Category) IsValid() bool {
+	switch c {
+	case Medicine, Transport, Food, Clothing, Education, Payments, Other:
+		return true
+	}
+	return false
+}
+
 type Benefit struct {
 	ID          uuid.UUID  `db:"id"`
 	Title       string     `db:"title"`
@@ -181,9 +213,19 @@ type Benefit struct {
 	SourceURL   string         `db:"source_url"`
 	Tags        BenefitTagList `db:"tags"` // stored as JSON array of tags
 
+	EligibilityRules *EligibilityRules `db:"eligibility_rules"` // nullable, stored as JSON
+
 	Views int `db:"views"` // количество просмотров
 
 	OrganizationID *uuid.UUID `db:"organization_id"` // nullable
+	// ExternalID - идентификатор льготы во внешнем источнике, по нему импорт обновляет льготу
+	ExternalID *string `db:"external_id"` // nullable
+
+	Status BenefitStatus `db:"status"`
+	// PublishAt - когда планировщик опубликует одобренную льготу, находящуюся на проверке
+	PublishAt *time.Time `db:"publish_at"` // nullable
+	// UnpublishAt - когда планировщик переведет опубликованную льготу в архив
+	UnpublishAt *time.Time `db:"unpublish_at"` // nullable
 
 	Organization *Organization
 
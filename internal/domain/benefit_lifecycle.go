@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BenefitExpiryBoundary - начало дня now. valid_to хранит дату окончания, льгота действует весь этот день,
+// поэтому истекшей считается льгота с valid_to раньше границы
+func BenefitExpiryBoundary(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// IsActiveAt - срок действия льготы включает момент now. Пустые valid_from и valid_to срок не ограничивают
+func (b *Benefit) IsActiveAt(now time.Time) bool {
+	if b.ValidFrom != nil && b.ValidFrom.After(now) {
+		return false
+	}
+	if b.ValidTo != nil && b.ValidTo.Before(BenefitExpiryBoundary(now)) {
+		return false
+	}
+	return true
+}
+
+// BenefitEndingNotice - уведомление пользователя о скором окончании льготы из его избранного
+type BenefitEndingNotice struct {
+	BenefitID uuid.UUID `db:"benefit_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Email     string    `db:"email"`
+	Title     string    `db:"title"`
+	ValidTo   time.Time `db:"valid_to"`
+}
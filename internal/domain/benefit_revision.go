@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Действие, после которого сохранена версия льготы
+type BenefitRevisionAction string
+
+const (
+	BenefitRevisionActionCreate  BenefitRevisionAction = "create"
+	BenefitRevisionActionUpdate  BenefitRevisionAction = "update"
+	BenefitRevisionActionDelete  BenefitRevisionAction = "delete"
+	BenefitRevisionActionRestore BenefitRevisionAction = "restore" // Восстановлена одна из прежних версий
+)
+
+// BenefitAuthor - кто изменил льготу: сотрудник или система партнера по API ключу
+type BenefitAuthor struct {
+	UserID   *uuid.UUID
+	APIKeyID *uuid.UUID
+	// CanPublish - у автора есть право публикации льгот
+	CanPublish bool
+}
+
+// BenefitSnapshot - редактируемые поля льготы на момент сохранения версии.
+// Просмотры и даты создания и изменения не версионируются
+type BenefitSnapshot struct {
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	ValidFrom        *time.Time        `json:"valid_from"`
+	ValidTo          *time.Time        `json:"valid_to"`
+	Type             BenefitLevel      `json:"type"`
+	TargetGroupIDs   TargetGroupList   `json:"target_groups"`
+	Longitude        *float64          `json:"longitude"`
+	Latitude         *float64          `json:"latitude"`
+	CityID           *uuid.UUID        `json:"city_id"`
+	Region           RegionList        `json:"region"`
+	Category         *Category         `json:"category"`
+	Requirement      string            `json:"requirement"`
+	HowToUse         *string           `json:"how_to_use"`
+	SourceURL        string            `json:"source_url"`
+	Tags             BenefitTagList    `json:"tags"`
+	OrganizationID   *uuid.UUID        `json:"organization_id"`
+	EligibilityRules *EligibilityRules `json:"eligibility_rules"`
+}
+
+// NewBenefitSnapshot снимает копию редактируемых полей льготы
+func NewBenefitSnapshot(b *Benefit) BenefitSnapshot {
+	return BenefitSnapshot{
+		Title:            b.Title,
+		Description:      b.Description,
+		ValidFrom:        b.ValidFrom,
+		ValidTo:          b.ValidTo,
+		Type:             b.Type,
+		TargetGroupIDs:   b.TargetGroupIDs,
+		Longitude:        b.Longitude,
+		Latitude:         b.Latitude,
+		CityID:           b.CityID,
+		Region:           b.Region,
+		Category:         b.Category,
+		Requirement:      b.Requirement,
+		HowToUse:         b.HowToUse,
+		SourceURL:        b.SourceURL,
+		Tags:             b.Tags,
+		OrganizationID:   b.OrganizationID,
+		EligibilityRules: b.EligibilityRules,
+	}
+}
+
+// ApplyTo возвращает льготе поля из версии
+func (s *BenefitSnapshot) ApplyTo(b *Benefit) {
+	b.Title = s.Title
+	b.Description = s.Description
+	b.ValidFrom = s.ValidFrom
+	b.ValidTo = s.ValidTo
+	b.Type = s.Type
+	b.TargetGroupIDs = s.TargetGroupIDs
+	b.Longitude = s.Longitude
+	b.Latitude = s.Latitude
+	b.CityID = s.CityID
+	b.Region = s.Region
+	b.Category = s.Category
+	b.Requirement = s.Requirement
+	b.HowToUse = s.HowToUse
+	b.SourceURL = s.SourceURL
+	b.Tags = s.Tags
+	b.OrganizationID = s.OrganizationID
+	b.EligibilityRules = s.EligibilityRules
+}
+
+// Value реализует интерфейс driver.Valuer для записи в БД
+func (s BenefitSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan реализует интерфейс sql.Scanner для чтения из БД
+func (s *BenefitSnapshot) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for BenefitSnapshot: %T", value)
+	}
+
+	return json.Unmarshal(data, s)
+}
+
+// BenefitRevision - сохраненная версия льготы. Версии нумеруются с 1 отдельно для каждой льготы,
+// записи не изменяются и не удаляются
+type BenefitRevision struct {
+	ID        uuid.UUID             `db:"id" json:"id"`
+	BenefitID uuid.UUID             `db:"benefit_id" json:"benefit_id"`
+	Version   int                   `db:"version" json:"version"`
+	Action    BenefitRevisionAction `db:"action" json:"action"`
+	// RestoredFrom - номер восстановленной версии, только для action=restore
+	RestoredFrom   *int            `db:"restored_from" json:"restored_from,omitempty"`
+	Snapshot       BenefitSnapshot `db:"snapshot" json:"snapshot"`
+	AuthorUserID   *uuid.UUID      `db:"author_user_id" json:"author_user_id,omitempty"`
+	AuthorAPIKeyID *uuid.UUID      `db:"author_api_key_id" json:"author_api_key_id,omitempty"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+}
+
+// NewBenefitRevision готовит версию льготы после действия action. Номер версии назначается при сохранении
+func NewBenefitRevision(b *Benefit, action BenefitRevisionAction, author BenefitAuthor) (*BenefitRevision, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate benefit revision id failed: %w", err)
+	}
+
+	return &BenefitRevision{
+		ID:             id,
+		BenefitID:      b.ID,
+		Action:         action,
+		Snapshot:       NewBenefitSnapshot(b),
+		AuthorUserID:   author.UserID,
+		AuthorAPIKeyID: author.APIKeyID,
+	}, nil
+}
+
+// BenefitFieldChange - различие одного поля между двумя версиями льготы
+type BenefitFieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+// DiffBenefitSnapshots сравнивает версии по полям в порядке их объявления в BenefitSnapshot.
+// Значения возвращаются в том же JSON виде, в котором хранятся в версии
+func DiffBenefitSnapshots(from, to BenefitSnapshot) ([]BenefitFieldChange, error) {
+	fromFields, err := snapshotFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := snapshotFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []BenefitFieldChange{}
+	snapshotType := reflect.TypeOf(BenefitSnapshot{})
+	for i := 0; i < snapshotType.NumField(); i++ {
+		field, _, _ := strings.Cut(snapshotType.Field(i).Tag.Get("json"), ",")
+		if equalJSON(fromFields[field], toFields[field]) {
+			continue
+		}
+		changes = append(changes, BenefitFieldChange{
+			Field: field,
+			From:  fromFields[field],
+			To:    toFields[field],
+		})
+	}
+
+	return changes, nil
+}
+
+func snapshotFields(s BenefitSnapshot) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshal benefit snapshot failed: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal benefit snapshot failed: %w", err)
+	}
+
+	return fields, nil
+}
+
+// equalJSON считает пустой список и null одинаковыми: старые льготы хранят пустые списки по-разному
+func equalJSON(a, b json.RawMessage) bool {
+	normalize := func(v json.RawMessage) []byte {
+		if bytes.Equal(v, []byte("[]")) {
+			return []byte("null")
+		}
+		return v
+	}
+
+	return bytes.Equal(normalize(a), normalize(b))
+}
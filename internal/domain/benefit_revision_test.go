@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDiffBenefitSnapshots(t *testing.T) {
+	validTo := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	organizationID := uuid.New()
+	category := Medicine
+	howToUse := "Обратиться в МФЦ"
+
+	base := func() BenefitSnapshot {
+		return BenefitSnapshot{
+			Title:       "Бесплатный проезд",
+			Description: "Проезд в городском транспорте",
+			Type:        Federal,
+			Region:      RegionList{14},
+			Requirement: "Пенсионное удостоверение",
+			SourceURL:   "https://example.test",
+			Tags:        BenefitTagList{New},
+		}
+	}
+
+	type change struct {
+		field    string
+		from, to string
+	}
+
+	tests := []struct {
+		name   string
+		change func(s *BenefitSnapshot)
+		want   []change
+	}{
+		{name: "no changes", change: func(*BenefitSnapshot) {}},
+		{
+			name:   "empty list and null are equal",
+			change: func(s *BenefitSnapshot) { s.TargetGroupIDs = TargetGroupList{} },
+		},
+		{
+			name:   "pointer set",
+			change: func(s *BenefitSnapshot) { s.HowToUse = &howToUse },
+			want:   []change{{field: "how_to_use", from: `null`, to: `"Обратиться в МФЦ"`}},
+		},
+		{
+			name:   "list cleared",
+			change: func(s *BenefitSnapshot) { s.Tags = nil },
+			want:   []change{{field: "tags", from: `["new"]`, to: `null`}},
+		},
+		{
+			name: "fields in declaration order",
+			change: func(s *BenefitSnapshot) {
+				s.OrganizationID = &organizationID
+				s.Category = &category
+				s.ValidTo = &validTo
+				s.Title = "Льготный проезд"
+			},
+			want: []change{
+				{field: "title", from: `"Бесплатный проезд"`, to: `"Льготный проезд"`},
+				{field: "valid_to", from: `null`, to: `"2026-12-31T00:00:00Z"`},
+				{field: "category", from: `null`, to: `"medicine"`},
+				{field: "organization_id", from: `null`, to: `"` + organizationID.String() + `"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := base()
+			tt.change(&to)
+
+			changes, err := DiffBenefitSnapshots(base(), to)
+			if err != nil {
+				t.Fatalf("DiffBenefitSnapshots() error = %v", err)
+			}
+			// Пустой список, а не nil: в ответе API должен быть [], а не null
+			if changes == nil {
+				t.Fatal("DiffBenefitSnapshots() = nil")
+			}
+			if len(changes) != len(tt.want) {
+				t.Fatalf("DiffBenefitSnapshots() = %+v, want %d changes", changes, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := changes[i]
+				if got.Field != want.field || string(got.From) != want.from || string(got.To) != want.to {
+					t.Errorf("change %d = %s %s -> %s, want %s %s -> %s", i,
+						got.Field, got.From, got.To, want.field, want.from, want.to)
+				}
+			}
+		})
+	}
+}
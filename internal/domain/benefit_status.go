@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// BenefitStatus - статус публикации льготы. Гражданам видны только опубликованные льготы
+type BenefitStatus string
+
+const (
+	BenefitStatusDraft     BenefitStatus = "draft"
+	BenefitStatusInReview  BenefitStatus = "in_review"
+	BenefitStatusPublished BenefitStatus = "published"
+	BenefitStatusArchived  BenefitStatus = "archived"
+)
+
+// benefitStatusTransitions - разрешенные переходы и нужно ли для перехода право публикации
+var benefitStatusTransitions = map[BenefitStatus]map[BenefitStatus]bool{
+	BenefitStatusDraft: {
+		BenefitStatusInReview: false,
+	},
+	BenefitStatusInReview: {
+		BenefitStatusDraft:     false,
+		BenefitStatusPublished: true,
+	},
+	BenefitStatusPublished: {
+		BenefitStatusArchived: false,
+	},
+	BenefitStatusArchived: {
+		BenefitStatusDraft:     false,
+		BenefitStatusPublished: true,
+	},
+}
+
+// BenefitStatuses - все статусы публикации, для выборок редакторов и партнеров
+func BenefitStatuses() []BenefitStatus {
+	return []BenefitStatus{BenefitStatusDraft, BenefitStatusInReview, BenefitStatusPublished, BenefitStatusArchived}
+}
+
+func (s BenefitStatus) IsValid() bool {
+	_, ok := benefitStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo - можно ли перевести льготу из статуса s в to
+func (s BenefitStatus) CanTransitionTo(to BenefitStatus) bool {
+	_, ok := benefitStatusTransitions[s][to]
+	return ok
+}
+
+// TransitionRequiresPublish - для перехода нужно право публикации, а не только редактирования
+func (s BenefitStatus) TransitionRequiresPublish(to BenefitStatus) bool {
+	return benefitStatusTransitions[s][to]
+}
+
+// ResetReviewAfterEdit применяет к льготе правку содержимого автором без права публикации: опубликованная льгота
+// возвращается на проверку, а у льготы на проверке отменяется одобренная публикация. Так изменения
+// не попадают к гражданам без одобрения редакции
+func (b *Benefit) ResetReviewAfterEdit(author BenefitAuthor) {
+	if author.CanPublish {
+		return
+	}
+
+	switch b.Status {
+	case BenefitStatusPublished:
+		b.Status = BenefitStatusInReview
+		b.PublishAt = nil
+	case BenefitStatusInReview:
+		b.PublishAt = nil
+	}
+}
+
+// IsVisibleToCitizens - льгота опубликована, не удалена и действует в момент now
+func (b *Benefit) IsVisibleToCitizens(now time.Time) bool {
+	return b.Status == BenefitStatusPublished && b.DeletedAt == nil && b.IsActiveAt(now)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBenefitResetReviewAfterEdit(t *testing.T) {
+	publishAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		status        BenefitStatus
+		canPublish    bool
+		wantStatus    BenefitStatus
+		wantPublishAt bool
+	}{
+		{name: "published edited by manager", status: BenefitStatusPublished, wantStatus: BenefitStatusInReview},
+		{name: "published edited by editor", status: BenefitStatusPublished, canPublish: true, wantStatus: BenefitStatusPublished, wantPublishAt: true},
+		{name: "scheduled in review edited by manager", status: BenefitStatusInReview, wantStatus: BenefitStatusInReview},
+		{name: "scheduled in review edited by editor", status: BenefitStatusInReview, canPublish: true, wantStatus: BenefitStatusInReview, wantPublishAt: true},
+		{name: "draft", status: BenefitStatusDraft, wantStatus: BenefitStatusDraft, wantPublishAt: true},
+		{name: "archived", status: BenefitStatusArchived, wantStatus: BenefitStatusArchived, wantPublishAt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := publishAt
+			benefit := &Benefit{Status: tt.status, PublishAt: &value}
+
+			benefit.ResetReviewAfterEdit(BenefitAuthor{CanPublish: tt.canPublish})
+
+			if benefit.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", benefit.Status, tt.wantStatus)
+			}
+			if (benefit.PublishAt != nil) != tt.wantPublishAt {
+				t.Errorf("publish_at = %v, want kept %v", benefit.PublishAt, tt.wantPublishAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+// ContactType - контакт пользователя, который можно сменить по одноразовому коду
+type ContactType string
+
+const (
+	ContactTypeEmail ContactType = "email"
+	ContactTypePhone ContactType = "phone"
+)
+
+// ProfileField - поле профиля, в котором хранится контакт
+func (t ContactType) ProfileField() string {
+	if t == ContactTypePhone {
+		return ProfileFieldPhoneNumber
+	}
+	return ProfileFieldEmail
+}
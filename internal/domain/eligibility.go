@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Категория инвалидности из профиля пользователя
+type DisabilityCategory string
+
+const (
+	DisabilityCategoryNone   DisabilityCategory = "none"    // Инвалидности нет
+	DisabilityCategoryGroup1 DisabilityCategory = "group_1" // I группа
+	DisabilityCategoryGroup2 DisabilityCategory = "group_2" // II группа
+	DisabilityCategoryGroup3 DisabilityCategory = "group_3" // III группа
+	DisabilityCategoryChild  DisabilityCategory = "child"   // Ребенок-инвалид
+)
+
+func (c DisabilityCategory) IsValid() bool {
+	switch c {
+	case DisabilityCategoryNone, DisabilityCategoryGroup1, DisabilityCategoryGroup2,
+		DisabilityCategoryGroup3, DisabilityCategoryChild:
+		return true
+	}
+	return false
+}
+
+// Title - категория инвалидности для пояснений пользователю
+func (c DisabilityCategory) Title() string {
+	switch c {
+	case DisabilityCategoryNone:
+		return "нет инвалидности"
+	case DisabilityCategoryGroup1:
+		return "I группа"
+	case DisabilityCategoryGroup2:
+		return "II группа"
+	case DisabilityCategoryGroup3:
+		return "III группа"
+	case DisabilityCategoryChild:
+		return "ребенок-инвалид"
+	default:
+		return string(c)
+	}
+}
+
+// EligibilityRules - условия получения льготы сверх целевых групп. Пустое поле означает, что условие не проверяется
+type EligibilityRules struct {
+	MinAge *int `json:"min_age,omitempty"`
+	MaxAge *int `json:"max_age,omitempty"`
+	// CityIDs, RegionIDs - города и регионы проживания. Достаточно совпадения с любым из списков
+	CityIDs   []uuid.UUID `json:"city_ids,omitempty"`
+	RegionIDs []uuid.UUID `json:"region_ids,omitempty"`
+	// MaxMonthlyIncome - предельный среднедушевой доход семьи в месяц, руб.
+	MaxMonthlyIncome *int64 `json:"max_monthly_income,omitempty"`
+	MinChildren      *int   `json:"min_children,omitempty"`
+	// DisabilityCategories - подходящие категории инвалидности, хотя бы одна должна совпадать
+	DisabilityCategories []DisabilityCategory `json:"disability_categories,omitempty"`
+}
+
+// Validate проверяет, что условия не противоречат друг другу. Текст ошибки показывается администратору
+func (r *EligibilityRules) Validate() error {
+	if r.MinAge != nil && *r.MinAge < 0 || r.MaxAge != nil && *r.MaxAge < 0 {
+		return errors.New("eligibility age must not be negative")
+	}
+	if r.MinAge != nil && r.MaxAge != nil && *r.MinAge > *r.MaxAge {
+		return errors.New("eligibility min_age must not be greater than max_age")
+	}
+	if r.MaxMonthlyIncome != nil && *r.MaxMonthlyIncome < 0 {
+		return errors.New("eligibility max_monthly_income must not be negative")
+	}
+	if r.MinChildren != nil && *r.MinChildren < 0 {
+		return errors.New("eligibility min_children must not be negative")
+	}
+	for _, category := range r.DisabilityCategories {
+		if !category.IsValid() {
+			return fmt.Errorf("invalid eligibility disability category: %s", category)
+		}
+	}
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (r EligibilityRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner interface
+func (r *EligibilityRules) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for EligibilityRules: %T", value)
+	}
+
+	return json.Unmarshal(bytes, r)
+}
+
+// Итог проверки права на льготу, он же итог проверки отдельного условия
+type EligibilityStatus string
+
+const (
+	EligibilityStatusEligible    EligibilityStatus = "eligible"     // Условие выполнено
+	EligibilityStatusNotEligible EligibilityStatus = "not_eligible" // Условие не выполнено
+	EligibilityStatusMissingData EligibilityStatus = "missing_data" // В профиле не хватает данных
+)
+
+// Условие получения льготы
+type EligibilityCriterion string
+
+const (
+	EligibilityCriterionTargetGroup EligibilityCriterion = "target_group"
+	EligibilityCriterionAge         EligibilityCriterion = "age"
+	EligibilityCriterionResidence   EligibilityCriterion = "residence"
+	EligibilityCriterionIncome      EligibilityCriterion = "income"
+	EligibilityCriterionChildren    EligibilityCriterion = "children"
+	EligibilityCriterionDisability  EligibilityCriterion = "disability"
+)
+
+// EligibilityCheck - результат проверки одного условия с пояснением для пользователя
+type EligibilityCheck struct {
+	Criterion EligibilityCriterion `json:"criterion"`
+	Status    EligibilityStatus    `json:"status"`
+	Reason    string               `json:"reason"`
+}
+
+// EligibilityResult - право пользователя на льготу. Льгота недоступна, если не выполнено хотя бы одно условие,
+// и требует заполнить профиль, если остальные условия выполнены, но для части не хватает данных
+type EligibilityResult struct {
+	BenefitID uuid.UUID          `json:"benefit_id"`
+	Title     string             `json:"title"`
+	Status    EligibilityStatus  `json:"status"`
+	Checks    []EligibilityCheck `json:"checks"`
+}
+
+// NewEligibilityResult подводит итог по результатам проверки условий
+func NewEligibilityResult(benefit *Benefit, checks []EligibilityCheck) EligibilityResult {
+	status := EligibilityChecksStatus(checks)
+
+	if checks == nil {
+		checks = []EligibilityCheck{}
+	}
+
+	return EligibilityResult{
+		BenefitID: benefit.ID,
+		Title:     benefit.Title,
+		Status:    status,
+		Checks:    checks,
+	}
+}
+
+// EligibilityChecksStatus - итог по условиям: not_eligible, если не выполнено хотя бы одно,
+// missing_data, если для части не хватает данных, иначе eligible
+func EligibilityChecksStatus(checks []EligibilityCheck) EligibilityStatus {
+	status := EligibilityStatusEligible
+	for _, check := range checks {
+		if check.Status == EligibilityStatusNotEligible {
+			return EligibilityStatusNotEligible
+		}
+		if check.Status == EligibilityStatusMissingData {
+			status = EligibilityStatusMissingData
+		}
+	}
+	return status
+}
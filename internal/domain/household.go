@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Кем член семьи приходится пользователю
+type HouseholdRelation string
+
+const (
+	// HouseholdRelationSelf - сам пользователь, в таблице членов семьи не хранится
+	HouseholdRelationSelf   HouseholdRelation = "self"
+	HouseholdRelationSpouse HouseholdRelation = "spouse"
+	HouseholdRelationChild  HouseholdRelation = "child"
+)
+
+// IsValid - отношение, с которым можно добавить члена семьи
+func (r HouseholdRelation) IsValid() bool {
+	return r == HouseholdRelationSpouse || r == HouseholdRelationChild
+}
+
+// Title - отношение для справок
+func (r HouseholdRelation) Title() string {
+	switch r {
+	case HouseholdRelationSpouse:
+		return "супруг(а)"
+	case HouseholdRelationChild:
+		return "ребенок"
+	default:
+		return string(r)
+	}
+}
+
+// AccountsLimit - на скольких аккаунтах можно подтвердить родство с одним СНИЛС:
+// супруг(а) у одного пользователя, ребенок у обоих родителей
+func (r HouseholdRelation) AccountsLimit() int {
+	if r == HouseholdRelationChild {
+		return 2
+	}
+	return 1
+}
+
+// Статус подтверждения родства документом
+type HouseholdRelationStatus string
+
+const (
+	// HouseholdRelationStatusUnconfirmed - документ о родстве еще не загружен
+	HouseholdRelationStatusUnconfirmed HouseholdRelationStatus = "unconfirmed"
+	HouseholdRelationStatusPending     HouseholdRelationStatus = "pending"
+	HouseholdRelationStatusVerified    HouseholdRelationStatus = "verified"
+	HouseholdRelationStatusRejected    HouseholdRelationStatus = "rejected"
+)
+
+// AwaitsProof - для члена семьи можно загрузить документ о родстве
+func (s HouseholdRelationStatus) AwaitsProof() bool {
+	return s == HouseholdRelationStatusUnconfirmed || s == HouseholdRelationStatusRejected
+}
+
+// HouseholdMember - член семьи пользователя. Группы члена семьи проверяются по его СНИЛС
+// тем же сервисом, что и группы пользователя, но учитываются только после того,
+// как модератор подтвердит родство по документу
+type HouseholdMember struct {
+	ID                 uuid.UUID               `db:"id" json:"id"`
+	UserID             uuid.UUID               `db:"user_id" json:"user_id"`
+	Relation           HouseholdRelation       `db:"relation" json:"relation"`
+	RelationStatus     HouseholdRelationStatus `db:"relation_status" json:"relation_status"`
+	RelationVerifiedAt *time.Time              `db:"relation_verified_at" json:"relation_verified_at,omitempty"`
+	FirstName          string                  `db:"first_name" json:"first_name"`
+	LastName           string                  `db:"last_name" json:"last_name"`
+	MiddleName         sql.NullString          `db:"middle_name" json:"middle_name"`
+	BirthDate          *time.Time              `db:"birth_date" json:"birth_date,omitempty"`
+	SNILS              string                  `db:"snils" json:"snils"`
+	GroupType          UserGroupList           `db:"group_type" json:"group_type"`
+	CreatedAt          time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time               `db:"updated_at" json:"updated_at"`
+}
+
+// RelationVerified - родство подтверждено документом, группы члена семьи можно учитывать
+func (m *HouseholdMember) RelationVerified() bool {
+	return m.RelationStatus == HouseholdRelationStatusVerified
+}
+
+func (m *HouseholdMember) FullName() string {
+	return joinName(m.LastName, m.FirstName, m.MiddleName.String)
+}
+
+// HouseholdGroup - подтвержденная группа и член семьи, которому она принадлежит
+type HouseholdGroup struct {
+	GroupType GroupType `json:"group_type"`
+	// MemberID - член семьи, пусто для самого пользователя
+	MemberID *uuid.UUID        `json:"member_id,omitempty"`
+	Relation HouseholdRelation `json:"relation"`
+	Name     string            `json:"name"`
+}
+
+// HouseholdGroups - подтвержденные группы всей семьи пользователя
+type HouseholdGroups []HouseholdGroup
+
+// NewHouseholdGroups собирает группы пользователя и членов его семьи, подтвержденные на момент now.
+// Группы членов семьи без подтвержденного родства не учитываются
+func NewHouseholdGroups(user *User, members []HouseholdMember, now time.Time) HouseholdGroups {
+	var groups HouseholdGroups
+
+	userName := joinName(user.LastName.String, user.FirstName.String, user.MiddleName.String)
+	for _, group := range user.GroupType {
+		if group.IsVerified(now) {
+			groups = append(groups, HouseholdGroup{
+				GroupType: group.Type,
+				Relation:  HouseholdRelationSelf,
+				Name:      userName,
+			})
+		}
+	}
+
+	for i := range members {
+		member := &members[i]
+		if !member.RelationVerified() {
+			continue
+		}
+		for _, group := range member.GroupType {
+			if group.IsVerified(now) {
+				groups = append(groups, HouseholdGroup{
+					GroupType: group.Type,
+					MemberID:  &member.ID,
+					Relation:  member.Relation,
+					Name:      member.FullName(),
+				})
+			}
+		}
+	}
+
+	return groups
+}
+
+// Types - подтвержденные группы семьи без повторов
+func (h HouseholdGroups) Types() []string {
+	types := make([]string, 0, len(h))
+	seen := make(map[GroupType]bool, len(h))
+	for _, group := range h {
+		if !seen[group.GroupType] {
+			seen[group.GroupType] = true
+			types = append(types, string(group.GroupType))
+		}
+	}
+	return types
+}
+
+// Qualifying - члены семьи, чьи группы входят в целевые группы льготы
+func (h HouseholdGroups) Qualifying(targetGroups TargetGroupList) HouseholdGroups {
+	var qualifying HouseholdGroups
+	for _, group := range h {
+		for _, target := range targetGroups {
+			if string(target) == string(group.GroupType) {
+				qualifying = append(qualifying, group)
+				break
+			}
+		}
+	}
+	return qualifying
+}
+
+// Holders - подтвержденные группы, сгруппированные по членам семьи в порядке первого появления
+func (h HouseholdGroups) Holders() []HouseholdGroups {
+	var holders []HouseholdGroups
+	index := make(map[uuid.UUID]int)
+	for _, group := range h {
+		key := uuid.Nil
+		if group.MemberID != nil {
+			key = *group.MemberID
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(holders)
+			index[key] = i
+			holders = append(holders, nil)
+		}
+		holders[i] = append(holders[i], group)
+	}
+	return holders
+}
+
+func joinName(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewHouseholdGroupsSkipsUnconfirmedRelations(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(24 * time.Hour)
+	verified := UserGroupList{{Type: UserGroupDisabled, Status: VerificationStatusVerified, ExpiresAt: &expiresAt}}
+
+	members := []HouseholdMember{
+		{ID: uuid.New(), Relation: HouseholdRelationChild, RelationStatus: HouseholdRelationStatusVerified, GroupType: verified},
+		{ID: uuid.New(), Relation: HouseholdRelationChild, RelationStatus: HouseholdRelationStatusUnconfirmed, GroupType: verified},
+		{ID: uuid.New(), Relation: HouseholdRelationSpouse, RelationStatus: HouseholdRelationStatusPending, GroupType: verified},
+		{ID: uuid.New(), Relation: HouseholdRelationSpouse, RelationStatus: HouseholdRelationStatusRejected, GroupType: verified},
+	}
+
+	groups := NewHouseholdGroups(&User{}, members, now)
+
+	if len(groups) != 1 || groups[0].MemberID == nil || *groups[0].MemberID != members[0].ID {
+		t.Fatalf("groups = %+v, want only the member with verified relation", groups)
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeBenefitsRead   APIKeyScope = "benefits:read"
+	APIKeyScopeBenefitsWrite  APIKeyScope = "benefits:write"
+	APIKeyScopeBuildingsRead  APIKeyScope = "buildings:read"
+	APIKeyScopeBuildingsWrite APIKeyScope = "buildings:write"
+)
+
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case APIKeyScopeBenefitsRead, APIKeyScopeBenefitsWrite, APIKeyScopeBuildingsRead, APIKeyScopeBuildingsWrite:
+		return true
+	}
+	return false
+}
+
+// APIKeyScopeList - кастомный тип для работы с JSON в БД
+type APIKeyScopeList []APIKeyScope
+
+// Scan implements sql.Scanner interface
+func (l *APIKeyScopeList) Scan(value interface{}) error {
+	if value == nil {
+		*l = []APIKeyScope{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan APIKeyScopeList: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+// Value implements driver.Valuer interface
+func (l APIKeyScopeList) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]APIKeyScope{})
+	}
+	return json.Marshal(l)
+}
+
+func (l APIKeyScopeList) Has(scope APIKeyScope) bool {
+	for _, s := range l {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// PartnerAPIKey - ключ для доступа систем организации-партнера к API без участия пользователя.
+// Хранится только хеш ключа, сам ключ показывается один раз при выпуске
+type PartnerAPIKey struct {
+	ID             uuid.UUID       `db:"id"`
+	OrganizationID uuid.UUID       `db:"organization_id"`
+	Name           string          `db:"name"`
+	KeyPrefix      string          `db:"key_prefix"`
+	KeyHash        string          `db:"key_hash"`
+	Scopes         APIKeyScopeList `db:"scopes"`
+	CreatedBy      *uuid.UUID      `db:"created_by"`
+	ExpiresAt      *time.Time      `db:"expires_at"`
+	LastUsedAt     *time.Time      `db:"last_used_at"`
+	LastUsedIP     *string         `db:"last_used_ip"`
+	RevokedAt      *time.Time      `db:"revoked_at"`
+	CreatedAt      time.Time       `db:"created_at"`
+}
+
+// IsActive - ключ не отозван и не истек
+func (k *PartnerAPIKey) IsActive() bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || k.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "fmt"
+
+// NormalizePassport приводит серию и номер паспорта к виду 9800 123456.
+// Пробелы и дефисы во входной строке игнорируются
+func NormalizePassport(passport string) (string, bool) {
+	digits := make([]byte, 0, 10)
+	for i := 0; i < len(passport); i++ {
+		c := passport[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-':
+		default:
+			return "", false
+		}
+	}
+	if len(digits) != 10 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s %s", digits[0:4], digits[4:10]), true
+}
@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Семейное положение в анкете
+type FamilyStatus string
+
+const (
+	FamilyStatusSingle  FamilyStatus = "single"
+	FamilyStatusMarried FamilyStatus = "married"
+)
+
+// Статус гражданина в анкете. Каждый статус соответствует группе пользователя
+type QuestionnaireStatus string
+
+const (
+	QuestionnaireStatusPensioner QuestionnaireStatus = "pensioner"
+	QuestionnaireStatusDisabled  QuestionnaireStatus = "disabled"
+	QuestionnaireStatusStudent   QuestionnaireStatus = "student"
+	QuestionnaireStatusVeteran   QuestionnaireStatus = "veteran"
+)
+
+// Среднедушевой доход семьи относительно прожиточного минимума
+type IncomeBracket string
+
+const (
+	IncomeBracketBelowSubsistence       IncomeBracket = "below_subsistence"        // Ниже прожиточного минимума
+	IncomeBracketBelowDoubleSubsistence IncomeBracket = "below_double_subsistence" // До двух прожиточных минимумов
+	IncomeBracketAboveDoubleSubsistence IncomeBracket = "above_double_subsistence" // Больше двух прожиточных минимумов
+)
+
+// Идентификаторы вопросов анкеты, они же поля QuestionnaireAnswers
+const (
+	QuestionAge                = "age"
+	QuestionCity               = "city_id"
+	QuestionFamilyStatus       = "family_status"
+	QuestionChildrenCount      = "children_count"
+	QuestionStatuses           = "statuses"
+	QuestionDisabilityCategory = "disability_category"
+	QuestionIncomeBracket      = "income_bracket"
+)
+
+// Тип ответа на вопрос анкеты
+type QuestionType string
+
+const (
+	QuestionTypeNumber         QuestionType = "number"
+	QuestionTypeCity           QuestionType = "city" // Выбор из справочника городов
+	QuestionTypeSingleChoice   QuestionType = "single_choice"
+	QuestionTypeMultipleChoice QuestionType = "multiple_choice"
+)
+
+// Возраст, с которого анкета не спрашивает о семье и детях
+const questionnaireAdultAge = 18
+
+// QuestionnaireAnswers - ответы анкеты "На что я имею право?". nil - вопрос еще не задан,
+// пустой список statuses - пользователь ответил, что ни один статус не подходит
+type QuestionnaireAnswers struct {
+	Age                *int                  `json:"age,omitempty"`
+	CityID             *uuid.UUID            `json:"city_id,omitempty"`
+	FamilyStatus       *FamilyStatus         `json:"family_status,omitempty"`
+	ChildrenCount      *int                  `json:"children_count,omitempty"`
+	Statuses           []QuestionnaireStatus `json:"statuses"`
+	DisabilityCategory *DisabilityCategory   `json:"disability_category,omitempty"`
+	IncomeBracket      *IncomeBracket        `json:"income_bracket,omitempty"`
+}
+
+// QuestionOption - вариант ответа
+type QuestionOption struct {
+	Value string `json:"value"`
+	Title string `json:"title"`
+}
+
+// Question - вопрос анкеты. Варианты ответа зависят от предыдущих ответов
+type Question struct {
+	ID      string           `json:"id"`
+	Text    string           `json:"text"`
+	Type    QuestionType     `json:"type"`
+	Options []QuestionOption `json:"options,omitempty"`
+	Min     *int             `json:"min,omitempty"`
+	Max     *int             `json:"max,omitempty"`
+}
+
+// Validate проверяет значения ответов. Текст ошибки показывается пользователю
+func (a *QuestionnaireAnswers) Validate() error {
+	if a.Age != nil && (*a.Age < 0 || *a.Age > 120) {
+		return errors.New("age must be between 0 and 120")
+	}
+	if a.FamilyStatus != nil && *a.FamilyStatus != FamilyStatusSingle && *a.FamilyStatus != FamilyStatusMarried {
+		return fmt.Errorf("invalid family_status: %s", *a.FamilyStatus)
+	}
+	if a.ChildrenCount != nil && (*a.ChildrenCount < 0 || *a.ChildrenCount > 30) {
+		return errors.New("children_count must be between 0 and 30")
+	}
+	for _, status := range a.Statuses {
+		if !slices.ContainsFunc(statusOptions(nil), func(o QuestionOption) bool { return o.Value == string(status) }) {
+			return fmt.Errorf("invalid status: %s", status)
+		}
+	}
+	if a.DisabilityCategory != nil && (!a.DisabilityCategory.IsValid() || *a.DisabilityCategory == DisabilityCategoryNone) {
+		return fmt.Errorf("invalid disability_category: %s", *a.DisabilityCategory)
+	}
+	if a.IncomeBracket != nil {
+		switch *a.IncomeBracket {
+		case IncomeBracketBelowSubsistence, IncomeBracketBelowDoubleSubsistence, IncomeBracketAboveDoubleSubsistence:
+		default:
+			return fmt.Errorf("invalid income_bracket: %s", *a.IncomeBracket)
+		}
+	}
+	return nil
+}
+
+// NextQuestion возвращает первый еще не заданный вопрос или nil, если анкета заполнена.
+// Вопросы о семье пропускаются для несовершеннолетних, категория инвалидности спрашивается только у инвалидов
+func (a *QuestionnaireAnswers) NextQuestion() *Question {
+	if a.Age == nil {
+		minAge, maxAge := 0, 120
+		return &Question{ID: QuestionAge, Text: "Сколько вам лет?", Type: QuestionTypeNumber, Min: &minAge, Max: &maxAge}
+	}
+	if a.CityID == nil {
+		return &Question{ID: QuestionCity, Text: "В каком городе вы живете?", Type: QuestionTypeCity}
+	}
+	if a.isAdult() && a.FamilyStatus == nil {
+		return &Question{ID: QuestionFamilyStatus, Text: "Вы состоите в браке?", Type: QuestionTypeSingleChoice, Options: []QuestionOption{
+			{Value: string(FamilyStatusMarried), Title: "Да"},
+			{Value: string(FamilyStatusSingle), Title: "Нет"},
+		}}
+	}
+	if a.isAdult() && a.ChildrenCount == nil {
+		minChildren, maxChildren := 0, 30
+		return &Question{ID: QuestionChildrenCount, Text: "Сколько у вас детей младше 18 лет?", Type: QuestionTypeNumber, Min: &minChildren, Max: &maxChildren}
+	}
+	if a.Statuses == nil {
+		return &Question{ID: QuestionStatuses, Text: "Что из этого про вас? Можно выбрать несколько вариантов или ни одного",
+			Type: QuestionTypeMultipleChoice, Options: statusOptions(a.Age)}
+	}
+	if slices.Contains(a.Statuses, QuestionnaireStatusDisabled) && a.DisabilityCategory == nil {
+		options := []QuestionOption{
+			{Value: string(DisabilityCategoryGroup1), Title: DisabilityCategoryGroup1.Title()},
+			{Value: string(DisabilityCategoryGroup2), Title: DisabilityCategoryGroup2.Title()},
+			{Value: string(DisabilityCategoryGroup3), Title: DisabilityCategoryGroup3.Title()},
+		}
+		if !a.isAdult() {
+			options = []QuestionOption{{Value: string(DisabilityCategoryChild), Title: DisabilityCategoryChild.Title()}}
+		}
+		return &Question{ID: QuestionDisabilityCategory, Text: "Какая у вас группа инвалидности?", Type: QuestionTypeSingleChoice, Options: options}
+	}
+	if a.IncomeBracket == nil {
+		return &Question{ID: QuestionIncomeBracket, Text: "Какой доход приходится на одного члена семьи в месяц?", Type: QuestionTypeSingleChoice,
+			Options: []QuestionOption{
+				{Value: string(IncomeBracketBelowSubsistence), Title: "Меньше прожиточного минимума"},
+				{Value: string(IncomeBracketBelowDoubleSubsistence), Title: "От одного до двух прожиточных минимумов"},
+				{Value: string(IncomeBracketAboveDoubleSubsistence), Title: "Больше двух прожиточных минимумов"},
+			}}
+	}
+	return nil
+}
+
+// GroupTypes - группы, под которые подходит гражданин по ответам анкеты
+func (a *QuestionnaireAnswers) GroupTypes() GroupTypeList {
+	var groups GroupTypeList
+
+	for _, status := range a.Statuses {
+		switch status {
+		case QuestionnaireStatusPensioner:
+			groups = append(groups, UserGroupPensioners)
+		case QuestionnaireStatusDisabled:
+			groups = append(groups, UserGroupDisabled)
+		case QuestionnaireStatusStudent:
+			groups = append(groups, UserGroupStudents)
+		case QuestionnaireStatusVeteran:
+			groups = append(groups, UserGroupVeterans)
+		}
+	}
+
+	if a.Age != nil && !a.isAdult() {
+		groups = append(groups, UserGroupChildren)
+	}
+	// Молодая семья - супруги не старше 35 лет
+	if a.Age != nil && *a.Age <= 35 && a.FamilyStatus != nil && *a.FamilyStatus == FamilyStatusMarried {
+		groups = append(groups, UserGroupYoungFamilies)
+	}
+	if a.ChildrenCount != nil && *a.ChildrenCount >= 3 {
+		groups = append(groups, UserGroupLargeFamilies)
+	}
+	if a.IncomeBracket != nil && *a.IncomeBracket == IncomeBracketBelowSubsistence {
+		groups = append(groups, UserGroupLowIncome)
+	}
+
+	return groups
+}
+
+func (a *QuestionnaireAnswers) isAdult() bool {
+	return a.Age != nil && *a.Age >= questionnaireAdultAge
+}
+
+// statusOptions - статусы, которые имеет смысл предлагать в указанном возрасте. Без возраста возвращаются все статусы
+func statusOptions(age *int) []QuestionOption {
+	var options []QuestionOption
+	if age == nil || *age >= 45 {
+		options = append(options, QuestionOption{Value: string(QuestionnaireStatusPensioner), Title: "Получаю пенсию"})
+	}
+	options = append(options, QuestionOption{Value: string(QuestionnaireStatusDisabled), Title: "Есть инвалидность"})
+	if age == nil || *age >= 14 && *age <= 35 {
+		options = append(options, QuestionOption{Value: string(QuestionnaireStatusStudent), Title: "Учусь очно"})
+	}
+	if age == nil || *age >= questionnaireAdultAge {
+		options = append(options, QuestionOption{Value: string(QuestionnaireStatusVeteran), Title: "Ветеран боевых действий или труда"})
+	}
+	return options
+}
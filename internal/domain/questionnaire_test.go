@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestQuestionnaireAnswersNextQuestion(t *testing.T) {
+	cityID := uuid.New()
+	intPtr := func(v int) *int { return &v }
+	married := FamilyStatusMarried
+	group2 := DisabilityCategoryGroup2
+	income := IncomeBracketBelowSubsistence
+
+	tests := []struct {
+		name        string
+		answers     QuestionnaireAnswers
+		wantID      string
+		wantOptions []string
+	}{
+		{name: "empty", wantID: QuestionAge},
+		{name: "city after age", answers: QuestionnaireAnswers{Age: intPtr(30)}, wantID: QuestionCity},
+		{
+			name:    "adult is asked about family",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID},
+			wantID:  QuestionFamilyStatus,
+		},
+		{
+			name:    "adult is asked about children",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID, FamilyStatus: &married},
+			wantID:  QuestionChildrenCount,
+		},
+		{
+			name:        "minor skips family questions",
+			answers:     QuestionnaireAnswers{Age: intPtr(16), CityID: &cityID},
+			wantID:      QuestionStatuses,
+			wantOptions: []string{"disabled", "student"},
+		},
+		{
+			name:        "statuses depend on age",
+			answers:     QuestionnaireAnswers{Age: intPtr(60), CityID: &cityID, FamilyStatus: &married, ChildrenCount: intPtr(0)},
+			wantID:      QuestionStatuses,
+			wantOptions: []string{"pensioner", "disabled", "veteran"},
+		},
+		{
+			name: "no status is an answer",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID, FamilyStatus: &married, ChildrenCount: intPtr(1),
+				Statuses: []QuestionnaireStatus{}},
+			wantID: QuestionIncomeBracket,
+		},
+		{
+			name: "adult with disability",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID, FamilyStatus: &married, ChildrenCount: intPtr(1),
+				Statuses: []QuestionnaireStatus{QuestionnaireStatusDisabled}},
+			wantID:      QuestionDisabilityCategory,
+			wantOptions: []string{"group_1", "group_2", "group_3"},
+		},
+		{
+			name:        "minor with disability",
+			answers:     QuestionnaireAnswers{Age: intPtr(10), CityID: &cityID, Statuses: []QuestionnaireStatus{QuestionnaireStatusDisabled}},
+			wantID:      QuestionDisabilityCategory,
+			wantOptions: []string{string(DisabilityCategoryChild)},
+		},
+		{
+			name: "disability category answered",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID, FamilyStatus: &married, ChildrenCount: intPtr(1),
+				Statuses: []QuestionnaireStatus{QuestionnaireStatusDisabled}, DisabilityCategory: &group2},
+			wantID: QuestionIncomeBracket,
+		},
+		{
+			name: "completed",
+			answers: QuestionnaireAnswers{Age: intPtr(30), CityID: &cityID, FamilyStatus: &married, ChildrenCount: intPtr(1),
+				Statuses: []QuestionnaireStatus{QuestionnaireStatusStudent}, IncomeBracket: &income},
+		},
+		{
+			name:    "minor completed",
+			answers: QuestionnaireAnswers{Age: intPtr(10), CityID: &cityID, Statuses: []QuestionnaireStatus{}, IncomeBracket: &income},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := tt.answers.NextQuestion()
+
+			if tt.wantID == "" {
+				if question != nil {
+					t.Fatalf("NextQuestion() = %s, want nil", question.ID)
+				}
+				return
+			}
+			if question == nil {
+				t.Fatalf("NextQuestion() = nil, want %s", tt.wantID)
+			}
+			if question.ID != tt.wantID {
+				t.Fatalf("NextQuestion() = %s, want %s", question.ID, tt.wantID)
+			}
+			if tt.wantOptions == nil {
+				return
+			}
+			var options []string
+			for _, option := range question.Options {
+				options = append(options, option.Value)
+			}
+			if !slices.Equal(options, tt.wantOptions) {
+				t.Errorf("options = %v, want %v", options, tt.wantOptions)
+			}
+		})
+	}
+}
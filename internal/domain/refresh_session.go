@@ -9,11 +9,36 @@ import (
 type RefreshSession struct {
 	ID           uuid.UUID  `json:"id" db:"id"`
 	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	FamilyID     uuid.UUID  `json:"family_id" db:"family_id"` // общий для всех токенов, полученных ротацией из одного входа
 	RefreshToken uuid.UUID  `json:"refresh_token" db:"refresh_token"`
 	UserAgent    string     `json:"user_agent" db:"user_agent"`
 	IP           string     `json:"ip" db:"ip"`
 	ExpiresIn    time.Time  `json:"expires_in" db:"expires_in"`
+	UsedAt       *time.Time `json:"used_at" db:"used_at"` // заполняется, когда токен обменян на новый
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at" db:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at" db:"deleted_at"`
 }
+
+func (s *RefreshSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresIn)
+}
+
+func (s *RefreshSession) IsUsed() bool {
+	return s.UsedAt != nil
+}
+
+func (s *RefreshSession) IsDeleted() bool {
+	return s.DeletedAt != nil
+}
+
+// UserSession - вход пользователя с конкретного устройства. Объединяет цепочку refresh токенов,
+// полученных ротацией, и описывается её последним активным токеном
+type UserSession struct {
+	ID         uuid.UUID `json:"id" db:"family_id"`
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	IP         string    `json:"ip" db:"ip"`
+	SignedInAt time.Time `json:"signed_in_at" db:"signed_in_at"`
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at" db:"expires_at"`
+}
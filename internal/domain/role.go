@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	// RoleCitizen есть у каждого пользователя и не хранится в БД
+	RoleCitizen             Role = "citizen"
+	RoleContentEditor       Role = "content_editor"
+	RoleOrganizationManager Role = "organization_manager"
+	RoleAdministrator       Role = "administrator"
+)
+
+type Permission string
+
+const (
+	PermissionBenefitsManage      Permission = "benefits:manage"
+	PermissionOrganizationsCreate Permission = "organizations:create"
+	PermissionOrganizationsManage Permission = "organizations:manage"
+	PermissionStatsRead           Permission = "stats:read"
+	PermissionRolesManage         Permission = "roles:manage"
+	PermissionStaffManage         Permission = "staff:manage"
+	PermissionUsersManage         Permission = "users:manage"
+	PermissionAPIKeysManage       Permission = "api_keys:manage"
+	// PermissionVerificationsModerate - проверка документов, которыми пользователи подтверждают группы
+	PermissionVerificationsModerate Permission = "verifications:moderate"
+	// PermissionBenefitsPublish - публикация льгот после проверки и расписание публикации
+	PermissionBenefitsPublish Permission = "benefits:publish"
+)
+
+// rolePermissions - права ролей. Права organization_manager действуют только в пределах его организации
+var rolePermissions = map[Role][]Permission{
+	RoleCitizen: {},
+	RoleContentEditor: {
+		PermissionBenefitsManage,
+		PermissionBenefitsPublish,
+		PermissionOrganizationsCreate,
+		PermissionOrganizationsManage,
+		PermissionStatsRead,
+		PermissionVerificationsModerate,
+	},
+	RoleOrganizationManager: {
+		PermissionBenefitsManage,
+		PermissionOrganizationsManage,
+		PermissionAPIKeysManage,
+	},
+	RoleAdministrator: {
+		PermissionBenefitsManage,
+		PermissionBenefitsPublish,
+		PermissionOrganizationsCreate,
+		PermissionOrganizationsManage,
+		PermissionStatsRead,
+		PermissionRolesManage,
+		PermissionStaffManage,
+		PermissionUsersManage,
+		PermissionAPIKeysManage,
+		PermissionVerificationsModerate,
+	},
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// IsOrganizationScoped - роль выдается на конкретную организацию
+func (r Role) IsOrganizationScoped() bool {
+	return r == RoleOrganizationManager
+}
+
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type UserRole struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Role           Role       `json:"role" db:"role"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" db:"organization_id"`
+	GrantedBy      *uuid.UUID `json:"granted_by,omitempty" db:"granted_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
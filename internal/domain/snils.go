@@ -0,0 +1,24 @@
+package domain
+
+import "fmt"
+
+// NormalizeSNILS приводит СНИЛС к виду 123-456-789 00, в котором его отдает ЕСИА.
+// Пробелы, дефисы и другие разделители во входной строке игнорируются
+func NormalizeSNILS(snils string) (string, bool) {
+	digits := make([]byte, 0, 11)
+	for i := 0; i < len(snils); i++ {
+		c := snils[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-':
+		default:
+			return "", false
+		}
+	}
+	if len(digits) != 11 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s-%s-%s %s", digits[0:3], digits[3:6], digits[6:9], digits[9:11]), true
+}
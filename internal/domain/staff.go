@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StaffCredential - учетные данные сотрудника для входа по логину и паролю
+type StaffCredential struct {
+	UserID         uuid.UUID      `db:"user_id"`
+	Login          string         `db:"login"`
+	PasswordHash   string         `db:"password_hash"`
+	TOTPSecret     sql.NullString `db:"totp_secret"`
+	TOTPEnabled    bool           `db:"totp_enabled"`
+	TOTPLastStep   int64          `db:"totp_last_step"`
+	FailedAttempts int            `db:"failed_attempts"`
+	LockedUntil    *time.Time     `db:"locked_until"`
+	LastLoginAt    *time.Time     `db:"last_login_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func (c *StaffCredential) IsLocked() bool {
+	return c.LockedUntil != nil && c.LockedUntil.After(time.Now())
+}
+
+// StaffRecoveryCode - одноразовый код восстановления на случай потери устройства с TOTP.
+// Хранится только хеш кода
+type StaffRecoveryCode struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	CodeHash  string     `db:"code_hash"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
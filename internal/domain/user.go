@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,39 @@ const (
 
 type GroupTypeList []GroupType
 
+func (t GroupType) IsValid() bool {
+	switch t {
+	case UserGroupPensioners, UserGroupDisabled, UserGroupYoungFamilies, UserGroupLowIncome,
+		UserGroupStudents, UserGroupLargeFamilies, UserGroupChildren, UserGroupVeterans:
+		return true
+	}
+	return false
+}
+
+// Title - название группы для писем и справок
+func (t GroupType) Title() string {
+	switch t {
+	case UserGroupPensioners:
+		return "Пенсионеры"
+	case UserGroupDisabled:
+		return "Инвалиды"
+	case UserGroupYoungFamilies:
+		return "Молодые семьи"
+	case UserGroupLowIncome:
+		return "Малоимущие"
+	case UserGroupStudents:
+		return "Студенты"
+	case UserGroupLargeFamilies:
+		return "Многодетные семьи"
+	case UserGroupChildren:
+		return "Дети"
+	case UserGroupVeterans:
+		return "Ветераны"
+	default:
+		return string(t)
+	}
+}
+
 // Статус подтверждения группы
 type VerificationStatus string
 
@@ -33,6 +67,7 @@ const (
 	VerificationStatusVerified VerificationStatus = "verified" // Подтверждена
 	VerificationStatusRejected VerificationStatus = "rejected" // Отклонена
 	VerificationStatusExpired  VerificationStatus = "expired"  // Истекла
+	VerificationStatusFailed   VerificationStatus = "failed"   // Не удалось проверить
 )
 
 // Группа пользователя со статусом подтверждения
@@ -46,6 +81,25 @@ type UserGroup struct {
 	ErrorMessage string             `json:"error_message,omitempty"` // Сообщение об ошибке
 }
 
+// documentExternalIDPrefix - ExternalID группы, подтвержденной модератором по документу
+const documentExternalIDPrefix = "document:"
+
+// DocumentExternalID - ExternalID группы, подтвержденной документом documentID
+func DocumentExternalID(documentID uuid.UUID) string {
+	return documentExternalIDPrefix + documentID.String()
+}
+
+// VerifiedByDocument - группа подтверждена модератором по документу, а не реестром по СНИЛС
+func (g UserGroup) VerifiedByDocument() bool {
+	return strings.HasPrefix(g.ExternalID, documentExternalIDPrefix)
+}
+
+// IsVerified - группа подтверждена и срок подтверждения не истек. Истекшие группы переводятся
+// в expired периодической задачей, до ее запуска срок проверяется здесь
+func (g UserGroup) IsVerified(now time.Time) bool {
+	return g.Status == VerificationStatusVerified && (g.ExpiresAt == nil || now.Before(*g.ExpiresAt))
+}
+
 // Список групп со статусами
 type UserGroupList []UserGroup
 
@@ -89,11 +143,36 @@ type User struct {
 	CityID      *uuid.UUID     `db:"city_id" json:"city_id"`
 	GroupType   UserGroupList  `db:"group_type" json:"group_type"`
 
+	// Данные ЕСИА, обновляются при каждом входе
+	BirthDate       *time.Time     `db:"birth_date" json:"birth_date,omitempty"`
+	Gender          sql.NullString `db:"gender" json:"gender"`
+	INN             sql.NullString `db:"inn" json:"inn"`
+	Trusted         bool           `db:"trusted" json:"trusted"`
+	Citizenship     sql.NullString `db:"citizenship" json:"citizenship"`
+	ProfileSyncedAt *time.Time     `db:"profile_synced_at" json:"profile_synced_at,omitempty"`
+
+	// Когда пользователь сам сменил email или телефон с подтверждением кодом.
+	// Подтвержденные пользователем контакты больше не перезаписываются данными ЕСИА
+	EmailConfirmedAt *time.Time `db:"email_confirmed_at" json:"email_confirmed_at,omitempty"`
+	PhoneConfirmedAt *time.Time `db:"phone_confirmed_at" json:"phone_confirmed_at,omitempty"`
+
+	// Данные для проверки права на льготы, заполняет пользователь. Пустое значение - данные не указаны
+	MonthlyIncome      *int64              `db:"monthly_income" json:"monthly_income,omitempty"` // Среднедушевой доход семьи в месяц, руб.
+	ChildrenCount      *int                `db:"children_count" json:"children_count,omitempty"`
+	DisabilityCategory *DisabilityCategory `db:"disability_category" json:"disability_category,omitempty"`
+
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
 	RegisteredAt *time.Time `db:"registered_at" json:"registered_at,omitempty"`
-	Documents    []UserDocument
+	// DeactivatedAt - когда администратор заблокировал пользователя
+	DeactivatedAt *time.Time `db:"deactivated_at" json:"deactivated_at,omitempty"`
+	Documents     []UserDocument
+}
+
+// IsActive - пользователь не удален и не заблокирован и может входить в систему
+func (u *User) IsActive() bool {
+	return u.DeletedAt == nil && u.DeactivatedAt == nil
 }
 
 type UserDocumentType string
@@ -104,13 +183,26 @@ const (
 	UserDocumentTypeRegistration UserDocumentType = "registration"
 )
 
+func (t UserDocumentType) IsValid() bool {
+	switch t {
+	case UserDocumentTypePassport, UserDocumentTypeSNILS, UserDocumentTypeRegistration:
+		return true
+	default:
+		return false
+	}
+}
+
 type UserDocument struct {
 	ID             uuid.UUID        `db:"id" json:"id"`
 	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
 	DocumentType   UserDocumentType `db:"document_type" json:"document_type"`
 	DocumentNumber string           `db:"document_number" json:"document_number"`
-	CreatedAt      time.Time        `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time        `db:"updated_at" json:"updated_at"`
-	DeletedAt      *time.Time       `db:"deleted_at" json:"deleted_at,omitempty"`
+	// IssuedAt, IssuedBy - дата выдачи и кем выдан, ExpiresAt - срок действия, если он есть у документа
+	IssuedAt  *time.Time     `db:"issued_at" json:"issued_at,omitempty"`
+	IssuedBy  sql.NullString `db:"issued_by" json:"issued_by"`
+	ExpiresAt *time.Time     `db:"expires_at" json:"expires_at,omitempty"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time     `db:"deleted_at" json:"deleted_at,omitempty"`
 }
 
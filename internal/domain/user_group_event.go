@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Источник изменения статуса группы
+type UserGroupEventSource string
+
+const (
+	UserGroupEventSourceUser        UserGroupEventSource = "user"         // Пользователь выбрал группу или загрузил документ
+	UserGroupEventSourceChecker     UserGroupEventSource = "checker"      // Сервис проверки групп по СНИЛС
+	UserGroupEventSourceModerator   UserGroupEventSource = "moderator"    // Решение модератора по документу
+	UserGroupEventSourceExpiry      UserGroupEventSource = "expiry"       // Истек срок подтверждения
+	UserGroupEventSourceProfileSync UserGroupEventSource = "profile_sync" // Сменился СНИЛС в ЕСИА
+)
+
+// UserGroupUpdate - кто и на основании чего меняет группы пользователя
+type UserGroupUpdate struct {
+	Source  UserGroupEventSource
+	ActorID *uuid.UUID
+	// ExternalRequestID - ID запроса к сервису проверки групп
+	ExternalRequestID string
+	// Payload - ответ сервиса проверки или данные решения, сохраняется в событии как есть
+	Payload json.RawMessage
+}
+
+// UserGroupEvent - переход статуса группы пользователя. Пустой FromStatus - группа добавлена в профиль,
+// пустой ToStatus - убрана из профиля. Для групп члена семьи заполнен HouseholdMemberID
+type UserGroupEvent struct {
+	ID                uuid.UUID            `db:"id" json:"id"`
+	UserID            uuid.UUID            `db:"user_id" json:"user_id"`
+	HouseholdMemberID *uuid.UUID           `db:"household_member_id" json:"household_member_id,omitempty"`
+	GroupType         GroupType            `db:"group_type" json:"group_type"`
+	FromStatus        *VerificationStatus  `db:"from_status" json:"from_status"`
+	ToStatus          *VerificationStatus  `db:"to_status" json:"to_status"`
+	Source            UserGroupEventSource `db:"source" json:"source"`
+	ActorID           *uuid.UUID           `db:"actor_id" json:"actor_id,omitempty"`
+	ExternalRequestID sql.NullString       `db:"external_request_id" json:"external_request_id"`
+	Message           sql.NullString       `db:"message" json:"message"`
+	Payload           sql.NullString       `db:"payload" json:"payload"`
+	CreatedAt         time.Time            `db:"created_at" json:"created_at"`
+}
+
+// UserGroupEvents сравнивает группы до и после изменения и возвращает события для групп, у которых сменился
+// статус, а также для продленных подтверждений (новый срок без смены статуса). ID событий не заполняются
+func UserGroupEvents(userID uuid.UUID, before, after UserGroupList, update UserGroupUpdate) []UserGroupEvent {
+	var events []UserGroupEvent
+
+	newEvent := func(groupType GroupType, from, to *UserGroup) UserGroupEvent {
+		event := UserGroupEvent{
+			UserID:            userID,
+			GroupType:         groupType,
+			Source:            update.Source,
+			ActorID:           update.ActorID,
+			ExternalRequestID: sql.NullString{String: update.ExternalRequestID, Valid: update.ExternalRequestID != ""},
+			Payload:           sql.NullString{String: string(update.Payload), Valid: len(update.Payload) > 0},
+		}
+		if from != nil {
+			event.FromStatus = &from.Status
+		}
+		if to != nil {
+			event.ToStatus = &to.Status
+			event.Message = sql.NullString{String: to.ErrorMessage, Valid: to.ErrorMessage != ""}
+		}
+		return event
+	}
+
+	for i := range after {
+		group := &after[i]
+		previous := before.find(group.Type)
+		if previous != nil && previous.Status == group.Status && equalTime(previous.ExpiresAt, group.ExpiresAt) {
+			continue
+		}
+		events = append(events, newEvent(group.Type, previous, group))
+	}
+
+	for i := range before {
+		if after.find(before[i].Type) == nil {
+			events = append(events, newEvent(before[i].Type, &before[i], nil))
+		}
+	}
+
+	return events
+}
+
+func (g UserGroupList) find(groupType GroupType) *UserGroup {
+	for i := range g {
+		if g[i].Type == groupType {
+			return &g[i]
+		}
+	}
+	return nil
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUserGroupEvents(t *testing.T) {
+	userID := uuid.New()
+	moderatorID := uuid.New()
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	extendedAt := expiresAt.AddDate(1, 0, 0)
+	status := func(s VerificationStatus) *VerificationStatus { return &s }
+
+	type wantEvent struct {
+		group   GroupType
+		from    *VerificationStatus
+		to      *VerificationStatus
+		message string
+	}
+
+	tests := []struct {
+		name   string
+		before UserGroupList
+		after  UserGroupList
+		want   []wantEvent
+	}{
+		{
+			name:  "group added",
+			after: UserGroupList{{Type: UserGroupStudents, Status: VerificationStatusPending}},
+			want:  []wantEvent{{group: UserGroupStudents, to: status(VerificationStatusPending)}},
+		},
+		{
+			name:   "group removed",
+			before: UserGroupList{{Type: UserGroupStudents, Status: VerificationStatusVerified}},
+			want:   []wantEvent{{group: UserGroupStudents, from: status(VerificationStatusVerified)}},
+		},
+		{
+			name:   "status changed with reason",
+			before: UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusPending}},
+			after:  UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusRejected, ErrorMessage: "не найдено в реестре"}},
+			want: []wantEvent{{
+				group: UserGroupPensioners, from: status(VerificationStatusPending), to: status(VerificationStatusRejected),
+				message: "не найдено в реестре",
+			}},
+		},
+		{
+			name:   "confirmation extended",
+			before: UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusVerified, ExpiresAt: &expiresAt}},
+			after:  UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusVerified, ExpiresAt: &extendedAt}},
+			want: []wantEvent{{
+				group: UserGroupPensioners, from: status(VerificationStatusVerified), to: status(VerificationStatusVerified),
+			}},
+		},
+		{
+			name:   "unchanged",
+			before: UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusVerified, ExpiresAt: &expiresAt}},
+			after: UserGroupList{{Type: UserGroupPensioners, Status: VerificationStatusVerified,
+				ExpiresAt: func() *time.Time { v := expiresAt.In(time.FixedZone("UTC+9", 9*3600)); return &v }()}},
+		},
+		{
+			name: "added, changed and removed at once",
+			before: UserGroupList{
+				{Type: UserGroupPensioners, Status: VerificationStatusVerified, ExpiresAt: &expiresAt},
+				{Type: UserGroupDisabled, Status: VerificationStatusPending},
+				{Type: UserGroupVeterans, Status: VerificationStatusRejected},
+			},
+			after: UserGroupList{
+				{Type: UserGroupPensioners, Status: VerificationStatusVerified, ExpiresAt: &expiresAt},
+				{Type: UserGroupDisabled, Status: VerificationStatusVerified, ExpiresAt: &expiresAt},
+				{Type: UserGroupStudents, Status: VerificationStatusPending},
+			},
+			want: []wantEvent{
+				{group: UserGroupDisabled, from: status(VerificationStatusPending), to: status(VerificationStatusVerified)},
+				{group: UserGroupStudents, to: status(VerificationStatusPending)},
+				{group: UserGroupVeterans, from: status(VerificationStatusRejected)},
+			},
+		},
+	}
+
+	equalStatus := func(a, b *VerificationStatus) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return *a == *b
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := UserGroupUpdate{
+				Source:            UserGroupEventSourceModerator,
+				ActorID:           &moderatorID,
+				ExternalRequestID: "request-1",
+				Payload:           json.RawMessage(`{"document_id":"1"}`),
+			}
+
+			events := UserGroupEvents(userID, tt.before, tt.after, update)
+
+			if len(events) != len(tt.want) {
+				t.Fatalf("events = %+v, want %d events", events, len(tt.want))
+			}
+			for i, want := range tt.want {
+				event := events[i]
+				if event.GroupType != want.group || !equalStatus(event.FromStatus, want.from) || !equalStatus(event.ToStatus, want.to) {
+					t.Errorf("event %d = %s %v -> %v, want %s %v -> %v", i,
+						event.GroupType, event.FromStatus, event.ToStatus, want.group, want.from, want.to)
+				}
+				if event.Message.String != want.message || event.Message.Valid != (want.message != "") {
+					t.Errorf("event %d message = %+v, want %q", i, event.Message, want.message)
+				}
+				if event.UserID != userID || event.Source != update.Source || event.ActorID != update.ActorID ||
+					event.ExternalRequestID.String != update.ExternalRequestID || event.Payload.String != string(update.Payload) {
+					t.Errorf("event %d does not carry the update: %+v", i, event)
+				}
+			}
+		})
+	}
+}
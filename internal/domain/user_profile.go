@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Поля профиля, которые приходят из ЕСИА
+const (
+	ProfileFieldFirstName   = "first_name"
+	ProfileFieldLastName    = "last_name"
+	ProfileFieldMiddleName  = "middle_name"
+	ProfileFieldSNILS       = "snils"
+	ProfileFieldEmail       = "email"
+	ProfileFieldPhoneNumber = "phone_number"
+	ProfileFieldBirthDate   = "birth_date"
+	ProfileFieldGender      = "gender"
+	ProfileFieldINN         = "inn"
+	ProfileFieldTrusted     = "trusted"
+	ProfileFieldCitizenship = "citizenship"
+)
+
+const birthDateLayout = "2006-01-02"
+
+// UserProfileChange - изменение поля профиля при синхронизации с ЕСИА или смене контактов пользователем
+type UserProfileChange struct {
+	ID        uuid.UUID      `db:"id" json:"id"`
+	UserID    uuid.UUID      `db:"user_id" json:"user_id"`
+	Field     string         `db:"field" json:"field"`
+	OldValue  sql.NullString `db:"old_value" json:"old_value"`
+	NewValue  sql.NullString `db:"new_value" json:"new_value"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+}
+
+// ApplyProfile переносит в пользователя данные профиля ЕСИА и возвращает список изменившихся полей.
+// Пустые значения не стирают сохраненные: ЕСИА не отдает поля, на которые нет scope.
+// Email и телефон, которые пользователь сменил сам, остаются без изменений
+func (u *User) ApplyProfile(profile *User) []UserProfileChange {
+	var changes []UserProfileChange
+
+	applyString := func(field string, current *sql.NullString, incoming sql.NullString) {
+		if !incoming.Valid || incoming.String == "" || (current.Valid && current.String == incoming.String) {
+			return
+		}
+		changes = append(changes, UserProfileChange{
+			UserID:   u.ID,
+			Field:    field,
+			OldValue: *current,
+			NewValue: incoming,
+		})
+		*current = incoming
+	}
+
+	applyString(ProfileFieldFirstName, &u.FirstName, profile.FirstName)
+	applyString(ProfileFieldLastName, &u.LastName, profile.LastName)
+	applyString(ProfileFieldMiddleName, &u.MiddleName, profile.MiddleName)
+	applyString(ProfileFieldSNILS, &u.SNILS, profile.SNILS)
+	if u.EmailConfirmedAt == nil {
+		applyString(ProfileFieldEmail, &u.Email, profile.Email)
+	}
+	if u.PhoneConfirmedAt == nil {
+		applyString(ProfileFieldPhoneNumber, &u.PhoneNumber, profile.PhoneNumber)
+	}
+	applyString(ProfileFieldGender, &u.Gender, profile.Gender)
+	applyString(ProfileFieldINN, &u.INN, profile.INN)
+	applyString(ProfileFieldCitizenship, &u.Citizenship, profile.Citizenship)
+
+	if profile.BirthDate != nil {
+		birthDate := formatBirthDate(profile.BirthDate)
+		if current := formatBirthDate(u.BirthDate); current.String != birthDate.String {
+			changes = append(changes, UserProfileChange{
+				UserID:   u.ID,
+				Field:    ProfileFieldBirthDate,
+				OldValue: current,
+				NewValue: birthDate,
+			})
+			u.BirthDate = profile.BirthDate
+		}
+	}
+
+	if u.Trusted != profile.Trusted {
+		changes = append(changes, UserProfileChange{
+			UserID:   u.ID,
+			Field:    ProfileFieldTrusted,
+			OldValue: sql.NullString{String: strconv.FormatBool(u.Trusted), Valid: true},
+			NewValue: sql.NullString{String: strconv.FormatBool(profile.Trusted), Valid: true},
+		})
+		u.Trusted = profile.Trusted
+	}
+
+	return changes
+}
+
+func formatBirthDate(date *time.Time) sql.NullString {
+	if date == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: date.Format(birthDateLayout), Valid: true}
+}
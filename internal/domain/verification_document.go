@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Статус проверки документа модератором
+type VerificationDocumentStatus string
+
+const (
+	VerificationDocumentStatusPending  VerificationDocumentStatus = "pending"
+	VerificationDocumentStatusApproved VerificationDocumentStatus = "approved"
+	VerificationDocumentStatusRejected VerificationDocumentStatus = "rejected"
+)
+
+func (s VerificationDocumentStatus) IsValid() bool {
+	switch s {
+	case VerificationDocumentStatusPending, VerificationDocumentStatusApproved, VerificationDocumentStatusRejected:
+		return true
+	}
+	return false
+}
+
+// VerificationDocument - скан документа, которым пользователь подтверждает группу,
+// если внешняя проверка по СНИЛС ее не нашла, или родство с членом семьи. У документа о родстве
+// заполнен HouseholdMemberID и пуст GroupType
+type VerificationDocument struct {
+	ID                uuid.UUID                  `db:"id" json:"id"`
+	UserID            uuid.UUID                  `db:"user_id" json:"user_id"`
+	HouseholdMemberID *uuid.UUID                 `db:"household_member_id" json:"household_member_id,omitempty"`
+	GroupType         GroupType                  `db:"group_type" json:"group_type,omitempty"`
+	StorageKey        string                     `db:"storage_key" json:"-"`
+	FileName          string                     `db:"file_name" json:"file_name"`
+	ContentType       string                     `db:"content_type" json:"content_type"`
+	Size              int64                      `db:"size" json:"size"`
+	Status            VerificationDocumentStatus `db:"status" json:"status"`
+	RejectReason      *string                    `db:"reject_reason" json:"reject_reason,omitempty"`
+	ReviewedBy        *uuid.UUID                 `db:"reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time                 `db:"reviewed_at" json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time                  `db:"created_at" json:"created_at"`
+
+	// Member - член семьи для сверки с документом о родстве, заполняется при чтении
+	Member *VerificationDocumentMember `db:"-" json:"member,omitempty"`
+}
+
+// VerificationDocumentMember - данные члена семьи, которые модератор сверяет с документом о родстве
+type VerificationDocumentMember struct {
+	Relation HouseholdRelation `json:"relation"`
+	Name     string            `json:"name"`
+	SNILS    string            `json:"snils"`
+	// SNILSCollisions - сколько других аккаунтов добавили члена семьи с тем же СНИЛС
+	SNILSCollisions int `json:"snils_collisions"`
+}
@@ -15,8 +15,9 @@ import (
 )
 
 type Client struct {
-	config     config.ESIAConfig
-	httpClient *http.Client
+	config          config.ESIAConfig
+	httpClient      *http.Client
+	idTokenVerifier *IDTokenVerifier
 }
 
 func NewClient(cfg config.ESIAConfig) *Client {
@@ -28,14 +29,32 @@ func NewClient(cfg config.ESIAConfig) *Client {
 	}
 }
 
-// GetAuthorizationURL возвращает URL для перенаправления пользователя на ESIA для авторизации
-func (c *Client) GetAuthorizationURL(state string) string {
+// SetIDTokenVerifier устанавливает верификатор подписи id_token
+func (c *Client) SetIDTokenVerifier(verifier *IDTokenVerifier) {
+	c.idTokenVerifier = verifier
+}
+
+// VerifyIDToken проверяет id_token, полученный при обмене code. Без настроенного верификатора вход запрещен
+func (c *Client) VerifyIDToken(idToken string, nonce string) (*IDTokenClaims, error) {
+	if c.idTokenVerifier == nil {
+		return nil, ErrIDTokenKeysMissing
+	}
+
+	return c.idTokenVerifier.Verify(idToken, nonce)
+}
+
+// GetAuthorizationURL возвращает URL для перенаправления пользователя на ESIA для авторизации.
+// codeChallenge - PKCE challenge (S256), nonce попадет в id_token и проверяется при обмене code
+func (c *Client) GetAuthorizationURL(state string, codeChallenge string, nonce string) string {
 	params := url.Values{}
 	params.Add("client_id", c.config.ClientID)
 	params.Add("redirect_uri", c.config.RedirectURI)
 	params.Add("response_type", "code")
 	params.Add("state", state)
 	params.Add("scope", c.config.Scope)
+	params.Add("code_challenge", codeChallenge)
+	params.Add("code_challenge_method", CodeChallengeMethodS256)
+	params.Add("nonce", nonce)
 
 	return fmt.Sprintf("%s/aas/oauth2/ac?%s", c.config.BaseURL, params.Encode())
 }
@@ -48,13 +67,15 @@ type TokenResponse struct {
 	TokenType    string `json:"token_type"`
 }
 
-// ExchangeCodeForToken обменивает authorization code на access token
-func (c *Client) ExchangeCodeForToken(code string) (*TokenResponse, error) {
+// ExchangeCodeForToken обменивает authorization code на access token. codeVerifier - PKCE verifier,
+// challenge от которого был передан в GetAuthorizationURL
+func (c *Client) ExchangeCodeForToken(code string, codeVerifier string) (*TokenResponse, error) {
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("code", code)
 	data.Set("client_id", c.config.ClientID)
 	data.Set("redirect_uri", c.config.RedirectURI)
+	data.Set("code_verifier", codeVerifier)
 
 	req, err := http.NewRequest("POST", c.config.BaseURL+"/aas/oauth2/te", bytes.NewBufferString(data.Encode()))
 	if err != nil {
@@ -0,0 +1,371 @@
+package esia
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	jwksCacheTTL        = time.Hour
+	jwksMinRefreshDelay = time.Minute
+)
+
+var (
+	ErrIDTokenInvalid     = errors.New("id_token is invalid")
+	ErrIDTokenKeyMissing  = errors.New("id_token signing key not found")
+	ErrIDTokenKeysMissing = errors.New("esia signing keys are not configured: set ESIA_JWKS_URL or ESIA_CERTS_PATH")
+)
+
+// IDTokenClaims - claims id_token, которые используются при авторизации
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce string `json:"nonce"`
+}
+
+// IDTokenVerifier проверяет подпись и claims id_token, выданного ESIA.
+// Ключи берутся из JWKS по URL или из локального PEM файла с сертификатами/публичными ключами
+type IDTokenVerifier struct {
+	issuer   string
+	audience string
+	keys     keySource
+	// skipSignature - подпись и claims кроме nonce и sub не проверяются (ESIA_INSECURE_SKIP_ID_TOKEN_VERIFY, только ENV=local)
+	skipSignature bool
+}
+
+// NewIDTokenVerifier создает верификатор по конфигу. Если источник ключей не настроен, возвращает ErrIDTokenKeysMissing,
+// кроме явного отключения проверки через InsecureSkipVerify
+func NewIDTokenVerifier(cfg config.ESIAConfig) (*IDTokenVerifier, error) {
+	var (
+		keys keySource
+		err  error
+	)
+
+	switch {
+	case cfg.JWKSURL != "":
+		keys = newJWKSKeySource(cfg.JWKSURL)
+	case cfg.CertsPath != "":
+		keys, err = newPEMKeySource(cfg.CertsPath)
+		if err != nil {
+			return nil, err
+		}
+	case cfg.InsecureSkipVerify:
+		return &IDTokenVerifier{skipSignature: true}, nil
+	default:
+		return nil, ErrIDTokenKeysMissing
+	}
+
+	issuer := cfg.Issuer
+	if issuer == "" {
+		issuer = cfg.BaseURL
+	}
+
+	return &IDTokenVerifier{
+		issuer:   issuer,
+		audience: cfg.ClientID,
+		keys:     keys,
+	}, nil
+}
+
+// Verify проверяет подпись, iss, aud, exp и nonce id_token
+func (v *IDTokenVerifier) Verify(idToken string, nonce string) (*IDTokenClaims, error) {
+	if idToken == "" {
+		return nil, fmt.Errorf("%w: id_token is empty", ErrIDTokenInvalid)
+	}
+
+	if v.skipSignature {
+		return parseIDTokenUnverified(idToken, nonce)
+	}
+
+	var claims IDTokenClaims
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if _, err := parser.ParseWithClaims(idToken, &claims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIDTokenInvalid, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is empty", ErrIDTokenInvalid)
+	}
+
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	return &claims, nil
+}
+
+// parseIDTokenUnverified разбирает id_token без проверки подписи и сверяет nonce.
+// Используется только при явно отключенной проверке на локальном окружении
+func parseIDTokenUnverified(idToken string, nonce string) (*IDTokenClaims, error) {
+	var claims IDTokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(idToken, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIDTokenInvalid, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is empty", ErrIDTokenInvalid)
+	}
+
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	return &claims, nil
+}
+
+func (v *IDTokenVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	keys, err := v.keys.Keys(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 1 {
+		return keys[0], nil
+	}
+
+	return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+// keySource возвращает ключи для проверки подписи. Если kid не указан или не известен источнику,
+// возвращаются все ключи
+type keySource interface {
+	Keys(kid string) ([]jwt.VerificationKey, error)
+}
+
+type pemKeySource struct {
+	keys []jwt.VerificationKey
+}
+
+// newPEMKeySource читает из файла все сертификаты и публичные ключи в формате PEM
+func newPEMKeySource(path string) (*pemKeySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read esia certs: %w", err)
+	}
+
+	var keys []jwt.VerificationKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var key crypto.PublicKey
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse esia certificate: %w", err)
+			}
+			key = cert.PublicKey
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse esia public key: %w", err)
+			}
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse esia rsa public key: %w", err)
+			}
+		default:
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no public keys found in %s", path)
+	}
+
+	return &pemKeySource{keys: keys}, nil
+}
+
+func (s *pemKeySource) Keys(_ string) ([]jwt.VerificationKey, error) {
+	return s.keys, nil
+}
+
+type jwksKeySource struct {
+	sync.Mutex
+
+	url        string
+	httpClient *http.Client
+	keys       map[string]jwt.VerificationKey
+	fetchedAt  time.Time
+}
+
+func newJWKSKeySource(url string) *jwksKeySource {
+	return &jwksKeySource{
+		url: url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Keys возвращает ключ по kid. JWKS кешируется на час; при неизвестном kid перечитывается,
+// но не чаще раза в минуту, чтобы токены с произвольным kid не нагружали ESIA
+func (s *jwksKeySource) Keys(kid string) ([]jwt.VerificationKey, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	_, known := s.keys[kid]
+	stale := time.Since(s.fetchedAt) > jwksCacheTTL
+	if stale || (kid != "" && !known && time.Since(s.fetchedAt) > jwksMinRefreshDelay) {
+		if err := s.refresh(); err != nil && len(s.keys) == 0 {
+			return nil, err
+		}
+	}
+
+	if kid != "" {
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: kid %s", ErrIDTokenKeyMissing, kid)
+		}
+		return []jwt.VerificationKey{key}, nil
+	}
+
+	keys := make([]jwt.VerificationKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, ErrIDTokenKeyMissing
+	}
+
+	return keys, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (s *jwksKeySource) refresh() error {
+	s.fetchedAt = time.Now()
+
+	resp, err := s.httpClient.Get(s.url)
+	if err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]jwt.VerificationKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		// Ключ неподдерживаемого типа (например, ГОСТ) не должен ломать проверку остальными ключами
+		key, err := k.publicKey()
+		if err != nil {
+			logger.Warn("skip esia jwk", zap.String("kid", k.Kid), zap.String("kty", k.Kty), zap.Error(err))
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: no usable signing keys in jwks", ErrIDTokenKeyMissing)
+	}
+
+	s.keys = keys
+
+	return nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() == 0 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa modulus or exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if x.Sign() == 0 || y.Sign() == 0 {
+			return nil, errors.New("invalid ec point")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode jwk value: %w", err)
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,351 @@
+package esia
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/pkg/logger"
+)
+
+const (
+	testIssuer   = "https://esia.test"
+	testAudience = "test_client"
+	testNonce    = "nonce-1"
+	testKID      = "key-1"
+)
+
+func testClaims() IDTokenClaims {
+	now := time.Now()
+	return IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Subject:   "1000000001",
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Nonce: testNonce,
+	}
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims IDTokenClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	return key
+}
+
+// writePEMCerts записывает публичные ключи во временный PEM файл
+func writePEMCerts(t *testing.T, keys ...*rsa.PrivateKey) string {
+	t.Helper()
+
+	var data []byte
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatalf("marshal public key: %v", err)
+		}
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+	}
+
+	path := filepath.Join(t.TempDir(), "esia.pem")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write pem: %v", err)
+	}
+	return path
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+	}
+}
+
+// newJWKSServer отдает публичный ключ в формате JWKS
+func newJWKSServer(t *testing.T, kid string, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	return newJWKSServerWithKeys(t, rsaJWK(kid, key))
+}
+
+func newJWKSServerWithKeys(t *testing.T, keys ...map[string]string) *httptest.Server {
+	t.Helper()
+
+	set := map[string]any{"keys": keys}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestVerifier(t *testing.T, cfg config.ESIAConfig) *IDTokenVerifier {
+	t.Helper()
+
+	cfg.Issuer = testIssuer
+	cfg.ClientID = testAudience
+	verifier, err := NewIDTokenVerifier(cfg)
+	if err != nil {
+		t.Fatalf("new verifier: %v", err)
+	}
+	return verifier
+}
+
+func TestIDTokenVerifierVerify(t *testing.T) {
+	key := generateRSAKey(t)
+	otherKey := generateRSAKey(t)
+
+	sources := map[string]config.ESIAConfig{
+		"pem":  {CertsPath: writePEMCerts(t, key)},
+		"jwks": {JWKSURL: newJWKSServer(t, testKID, key).URL},
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		nonce   string
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			token: func() string { return signTestToken(t, key, testKID, testClaims()) },
+			nonce: testNonce,
+		},
+		{
+			name:    "invalid signature",
+			token:   func() string { return signTestToken(t, otherKey, testKID, testClaims()) },
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := testClaims()
+				claims.Issuer = "https://attacker.test"
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := testClaims()
+				claims.Audience = jwt.ClaimStrings{"other_client"}
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := testClaims()
+				claims.IssuedAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Hour))
+				claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name: "without expiration",
+			token: func() string {
+				claims := testClaims()
+				claims.ExpiresAt = nil
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name:    "nonce mismatch",
+			token:   func() string { return signTestToken(t, key, testKID, testClaims()) },
+			nonce:   "other-nonce",
+			wantErr: ErrNonceMismatch,
+		},
+		{
+			name: "empty nonce",
+			token: func() string {
+				claims := testClaims()
+				claims.Nonce = ""
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   "",
+			wantErr: ErrNonceMismatch,
+		},
+		{
+			name: "empty subject",
+			token: func() string {
+				claims := testClaims()
+				claims.Subject = ""
+				return signTestToken(t, key, testKID, claims)
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name:    "empty token",
+			token:   func() string { return "" },
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+		{
+			name: "unsigned",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims())
+				signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+				if err != nil {
+					t.Fatalf("sign token: %v", err)
+				}
+				return signed
+			},
+			nonce:   testNonce,
+			wantErr: ErrIDTokenInvalid,
+		},
+	}
+
+	for sourceName, cfg := range sources {
+		verifier := newTestVerifier(t, cfg)
+		for _, tt := range tests {
+			t.Run(sourceName+"/"+tt.name, func(t *testing.T) {
+				claims, err := verifier.Verify(tt.token(), tt.nonce)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				if claims.Subject != "1000000001" {
+					t.Errorf("Verify() subject = %q", claims.Subject)
+				}
+			})
+		}
+	}
+}
+
+func TestIDTokenVerifierJWKSUnknownKID(t *testing.T) {
+	key := generateRSAKey(t)
+	verifier := newTestVerifier(t, config.ESIAConfig{JWKSURL: newJWKSServer(t, testKID, key).URL})
+
+	_, err := verifier.Verify(signTestToken(t, key, "unknown", testClaims()), testNonce)
+	if !errors.Is(err, ErrIDTokenInvalid) || !errors.Is(err, ErrIDTokenKeyMissing) {
+		t.Fatalf("Verify() error = %v, want %v", err, ErrIDTokenKeyMissing)
+	}
+}
+
+func TestIDTokenVerifierJWKSSkipsUnusableKeys(t *testing.T) {
+	logger.Init("error")
+
+	key := generateRSAKey(t)
+	gost := map[string]string{"kty": "EC", "kid": "gost", "use": "sig", "crv": "GOST3410-2012-256", "x": "AQ", "y": "AQ"}
+	malformed := map[string]string{"kty": "RSA", "kid": "malformed", "use": "sig", "n": "not base64!", "e": "AQAB"}
+	empty := map[string]string{"kty": "RSA", "kid": "empty", "use": "sig"}
+	unknown := map[string]string{"kty": "OKP", "kid": "okp", "use": "sig", "crv": "Ed25519", "x": "AQ"}
+
+	t.Run("good key next to bad ones", func(t *testing.T) {
+		verifier := newTestVerifier(t, config.ESIAConfig{
+			JWKSURL: newJWKSServerWithKeys(t, gost, malformed, rsaJWK(testKID, key), empty, unknown).URL,
+		})
+
+		if _, err := verifier.Verify(signTestToken(t, key, testKID, testClaims()), testNonce); err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if _, err := verifier.Verify(signTestToken(t, key, "gost", testClaims()), testNonce); !errors.Is(err, ErrIDTokenKeyMissing) {
+			t.Fatalf("Verify() with skipped kid error = %v, want %v", err, ErrIDTokenKeyMissing)
+		}
+	})
+
+	t.Run("no usable keys", func(t *testing.T) {
+		verifier := newTestVerifier(t, config.ESIAConfig{
+			JWKSURL: newJWKSServerWithKeys(t, gost, malformed, empty, unknown).URL,
+		})
+
+		_, err := verifier.Verify(signTestToken(t, key, testKID, testClaims()), testNonce)
+		if !errors.Is(err, ErrIDTokenInvalid) || !errors.Is(err, ErrIDTokenKeyMissing) {
+			t.Fatalf("Verify() error = %v, want %v", err, ErrIDTokenKeyMissing)
+		}
+	})
+}
+
+func TestIDTokenVerifierPEMCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "esia.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write pem: %v", err)
+	}
+
+	verifier := newTestVerifier(t, config.ESIAConfig{CertsPath: path})
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodES256, testClaims()).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	if _, err := verifier.Verify(signed, testNonce); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+}
+
+func TestNewIDTokenVerifierWithoutKeys(t *testing.T) {
+	if _, err := NewIDTokenVerifier(config.ESIAConfig{}); !errors.Is(err, ErrIDTokenKeysMissing) {
+		t.Fatalf("NewIDTokenVerifier() error = %v, want %v", err, ErrIDTokenKeysMissing)
+	}
+
+	client := NewClient(config.ESIAConfig{})
+	if _, err := client.VerifyIDToken("token", testNonce); !errors.Is(err, ErrIDTokenKeysMissing) {
+		t.Fatalf("VerifyIDToken() error = %v, want %v", err, ErrIDTokenKeysMissing)
+	}
+}
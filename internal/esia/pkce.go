@@ -0,0 +1,36 @@
+package esia
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const CodeChallengeMethodS256 = "S256"
+
+var ErrNonceMismatch = errors.New("id_token nonce mismatch")
+
+// PKCE - пара code_verifier/code_challenge (RFC 7636). Verifier остается на сервере,
+// в ESIA при редиректе уходит только challenge
+type PKCE struct {
+	CodeVerifier  string
+	CodeChallenge string
+}
+
+// NewPKCE генерирует code_verifier из 32 случайных байт и code_challenge = BASE64URL(SHA256(verifier))
+func NewPKCE() (*PKCE, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generate code verifier: %w", err)
+	}
+
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+	sum := sha256.Sum256([]byte(verifier))
+
+	return &PKCE{
+		CodeVerifier:  verifier,
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}, nil
+}
@@ -0,0 +1,149 @@
+package esia_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vibe-gaming/backend/internal/authstate"
+	"github.com/vibe-gaming/backend/internal/esia"
+	"github.com/vibe-gaming/backend/internal/standin"
+	"github.com/vibe-gaming/backend/internal/standin/standintest"
+	"github.com/vibe-gaming/backend/pkg/logger"
+)
+
+const (
+	testClientID    = "test_client"
+	testRedirectURI = "http://localhost/api/v1/users/auth/callback"
+	testCitizenOID  = "1000000001"
+)
+
+// login повторяет шаги входа: login сохраняет state с PKCE и nonce, callback сохраняет code,
+// token потребляет code и state. Возвращает code и данные state для обмена на токены
+func login(t *testing.T, srv *standintest.Server, client *esia.Client, store authstate.Store, loginHint string) (string, *authstate.StateData) {
+	t.Helper()
+	ctx := context.Background()
+
+	pkce, err := esia.NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE() error = %v", err)
+	}
+	state, nonce := "state-"+loginHint, "nonce-"+loginHint
+	if err := store.SaveState(ctx, state, &authstate.StateData{CodeVerifier: pkce.CodeVerifier, Nonce: nonce, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	location, err := srv.Authorize(client.GetAuthorizationURL(state, pkce.CodeChallenge, nonce), loginHint)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if got := location.Query().Get("state"); got != state {
+		t.Fatalf("redirect state = %q, want %q", got, state)
+	}
+	code := location.Query().Get("code")
+	if err := store.SaveCode(ctx, code, &authstate.CodeData{State: state, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveCode() error = %v", err)
+	}
+
+	codeData, err := store.ConsumeCode(ctx, code)
+	if err != nil {
+		t.Fatalf("ConsumeCode() error = %v", err)
+	}
+	stateData, err := store.ConsumeState(ctx, codeData.State)
+	if err != nil {
+		t.Fatalf("ConsumeState() error = %v", err)
+	}
+
+	return code, stateData
+}
+
+func newStandInClient(t *testing.T, srv *standintest.Server) *esia.Client {
+	t.Helper()
+
+	cfg := srv.ESIAConfig(testClientID, testRedirectURI)
+	verifier, err := esia.NewIDTokenVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewIDTokenVerifier() error = %v", err)
+	}
+	client := esia.NewClient(cfg)
+	client.SetIDTokenVerifier(verifier)
+	return client
+}
+
+func TestAuthFlowWithStandIn(t *testing.T) {
+	logger.Init("error")
+
+	srv := standintest.NewServer(t, nil)
+	client := newStandInClient(t, srv)
+	store := authstate.NewMemoryStore(authstate.DefaultStateTTL, authstate.DefaultCodeTTL)
+
+	t.Run("login", func(t *testing.T) {
+		code, stateData := login(t, srv, client, store, testCitizenOID)
+
+		tokens, err := client.ExchangeCodeForToken(code, stateData.CodeVerifier)
+		if err != nil {
+			t.Fatalf("ExchangeCodeForToken() error = %v", err)
+		}
+		claims, err := client.VerifyIDToken(tokens.IDToken, stateData.Nonce)
+		if err != nil {
+			t.Fatalf("VerifyIDToken() error = %v", err)
+		}
+		userInfo, err := client.GetUserInfo(tokens.AccessToken)
+		if err != nil {
+			t.Fatalf("GetUserInfo() error = %v", err)
+		}
+		if claims.Subject != testCitizenOID || userInfo.OID != testCitizenOID {
+			t.Fatalf("subject = %q, userinfo oid = %q, want %q", claims.Subject, userInfo.OID, testCitizenOID)
+		}
+		if userInfo.SNILS != "112-233-445 95" {
+			t.Errorf("userinfo snils = %q", userInfo.SNILS)
+		}
+
+		// code одноразовый
+		if _, err := client.ExchangeCodeForToken(code, stateData.CodeVerifier); err == nil {
+			t.Error("ExchangeCodeForToken() with used code succeeded")
+		}
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		code, _ := login(t, srv, client, store, "1000000002")
+
+		other, err := esia.NewPKCE()
+		if err != nil {
+			t.Fatalf("NewPKCE() error = %v", err)
+		}
+		if _, err := client.ExchangeCodeForToken(code, other.CodeVerifier); err == nil {
+			t.Fatal("ExchangeCodeForToken() with other code_verifier succeeded")
+		}
+	})
+
+	t.Run("nonce of other login", func(t *testing.T) {
+		code, stateData := login(t, srv, client, store, "1000000003")
+
+		tokens, err := client.ExchangeCodeForToken(code, stateData.CodeVerifier)
+		if err != nil {
+			t.Fatalf("ExchangeCodeForToken() error = %v", err)
+		}
+		if _, err := client.VerifyIDToken(tokens.IDToken, "nonce-other"); !errors.Is(err, esia.ErrNonceMismatch) {
+			t.Fatalf("VerifyIDToken() error = %v, want %v", err, esia.ErrNonceMismatch)
+		}
+	})
+
+	t.Run("id_token signed by unknown key", func(t *testing.T) {
+		if err := srv.InjectFailure(standin.Failure{Endpoint: standin.EndpointToken, Mode: standin.FailureModeInvalidIDToken, Times: 1}); err != nil {
+			t.Fatalf("InjectFailure() error = %v", err)
+		}
+		t.Cleanup(srv.ResetFailures)
+
+		code, stateData := login(t, srv, client, store, testCitizenOID)
+
+		tokens, err := client.ExchangeCodeForToken(code, stateData.CodeVerifier)
+		if err != nil {
+			t.Fatalf("ExchangeCodeForToken() error = %v", err)
+		}
+		if _, err := client.VerifyIDToken(tokens.IDToken, stateData.Nonce); !errors.Is(err, esia.ErrIDTokenInvalid) {
+			t.Fatalf("VerifyIDToken() error = %v, want %v", err, esia.ErrIDTokenInvalid)
+		}
+	})
+}
@@ -1,12 +1,18 @@
 package asynqserver
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/hibiken/asynq"
 	"github.com/vibe-gaming/backend/internal/cache"
 	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/queue/processor"
 	"github.com/vibe-gaming/backend/internal/queue/task"
 	"github.com/vibe-gaming/backend/internal/worker"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
 )
 
 func New(cfg config.Cache, workers *worker.Workers) (*asynq.Server, *asynq.ServeMux) {
@@ -17,6 +23,12 @@ func New(cfg config.Cache, workers *worker.Workers) (*asynq.Server, *asynq.Serve
 			Concurrency: 10,
 			LogLevel:    asynq.ErrorLevel,
 			Queues:      queues,
+			RetryDelayFunc: func(n int, err error, t *asynq.Task) time.Duration {
+				if t.Type() == task.CheckSocialGroupTaskName {
+					return task.CheckSocialGroupRetryDelay(n)
+				}
+				return asynq.DefaultRetryDelayFunc(n, err, t)
+			},
 		},
 	)
 
@@ -44,9 +56,44 @@ func getQueues(workers *worker.Workers) (*asynq.ServeMux, map[string]int) {
 	mux := asynq.NewServeMux()
 	mux.Handle(task.SendEmailTaskName, processor.NewSendEmailProcessor(workers))
 	mux.Handle(task.CheckSocialGroupTaskName, processor.NewCheckSocialGroupProcessor(workers))
+	mux.Handle(task.ExpireSocialGroupsTaskName, processor.NewExpireSocialGroupsProcessor(workers))
+	mux.Handle(task.SendGroupExpiredEmailTaskName, processor.NewSendGroupExpiredEmailProcessor(workers))
+	mux.Handle(task.SendBenefitEndingEmailTaskName, processor.NewSendBenefitEndingEmailProcessor(workers))
+	mux.Handle(task.SendSMSTaskName, processor.NewSendSMSProcessor(workers))
+	mux.Handle(task.PublishScheduledBenefitsTaskName, processor.NewPublishScheduledBenefitsProcessor(workers))
+	mux.Handle(task.ProcessBenefitExpiryTaskName, processor.NewProcessBenefitExpiryProcessor(workers))
 	queues := map[string]int{
 		task.SendEmailQueueName:        1,
+		task.SendSMSQueueName:          1,
 		task.CheckSocialGroupQueueName: 1,
+		task.BenefitQueueName:          1,
 	}
 	return mux, queues
 }
+
+// NewScheduler регистрирует периодические задачи. Планировщик запускается на каждой реплике,
+// повторная постановка задачи, которая еще не выполнена, отсекается asynq.Unique
+func NewScheduler(cfg config.Cache, checkerCfg config.SocialGroupCheckerConfig, benefitsCfg config.BenefitsConfig) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(RedisOptions(cfg), &asynq.SchedulerOpts{
+		LogLevel: asynq.ErrorLevel,
+		PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+			if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+				logger.Error("asynq scheduler enqueue failed", zap.Error(err))
+			}
+		},
+	})
+
+	if _, err := scheduler.Register(checkerCfg.ExpirySchedule, task.NewExpireSocialGroupsTask()); err != nil {
+		return nil, fmt.Errorf("register expire social groups task failed: %w", err)
+	}
+
+	if _, err := scheduler.Register(benefitsCfg.PublicationSchedule, task.NewPublishScheduledBenefitsTask()); err != nil {
+		return nil, fmt.Errorf("register publish scheduled benefits task failed: %w", err)
+	}
+
+	if _, err := scheduler.Register(benefitsCfg.LifecycleSchedule, task.NewProcessBenefitExpiryTask()); err != nil {
+		return nil, fmt.Errorf("register process benefit expiry task failed: %w", err)
+	}
+
+	return scheduler, nil
+}
@@ -28,6 +28,13 @@ func (p *checkSocialGroupProcessor) ProcessTask(ctx context.Context, t *asynq.Ta
 		return fmt.Errorf("process check social group task json unmarshal failed: %w", err)
 	}
 
+	if data.MemberID != nil {
+		if err = p.workers.SocialGroupChecker.CheckAndUpdateHouseholdMemberGroups(ctx, *data.MemberID, data.SNILS, data.Groups); err != nil {
+			return fmt.Errorf("check and update household member groups failed: %w", err)
+		}
+		return nil
+	}
+
 	if err = p.workers.SocialGroupChecker.CheckAndUpdateUserGroups(ctx, data.UserID, data.SNILS, data.Groups); err != nil {
 		return fmt.Errorf("check and update user groups failed: %w", err)
 	}
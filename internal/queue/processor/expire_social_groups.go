@@ -0,0 +1,28 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vibe-gaming/backend/internal/worker"
+
+	"github.com/hibiken/asynq"
+)
+
+type expireSocialGroupsProcessor struct {
+	workers *worker.Workers
+}
+
+func NewExpireSocialGroupsProcessor(workers *worker.Workers) *expireSocialGroupsProcessor {
+	return &expireSocialGroupsProcessor{
+		workers: workers,
+	}
+}
+
+func (p *expireSocialGroupsProcessor) ProcessTask(ctx context.Context, _ *asynq.Task) error {
+	if err := p.workers.SocialGroupChecker.ExpireUserGroups(ctx); err != nil {
+		return fmt.Errorf("expire user groups failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vibe-gaming/backend/internal/worker"
+
+	"github.com/hibiken/asynq"
+)
+
+type processBenefitExpiryProcessor struct {
+	workers *worker.Workers
+}
+
+func NewProcessBenefitExpiryProcessor(workers *worker.Workers) *processBenefitExpiryProcessor {
+	return &processBenefitExpiryProcessor{
+		workers: workers,
+	}
+}
+
+func (p *processBenefitExpiryProcessor) ProcessTask(ctx context.Context, _ *asynq.Task) error {
+	if err := p.workers.BenefitLifecycle.ProcessExpiry(ctx); err != nil {
+		return fmt.Errorf("process benefit expiry failed: %w", err)
+	}
+
+	return nil
+}
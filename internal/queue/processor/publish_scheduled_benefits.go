@@ -0,0 +1,28 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vibe-gaming/backend/internal/worker"
+
+	"github.com/hibiken/asynq"
+)
+
+type publishScheduledBenefitsProcessor struct {
+	workers *worker.Workers
+}
+
+func NewPublishScheduledBenefitsProcessor(workers *worker.Workers) *publishScheduledBenefitsProcessor {
+	return &publishScheduledBenefitsProcessor{
+		workers: workers,
+	}
+}
+
+func (p *publishScheduledBenefitsProcessor) ProcessTask(ctx context.Context, _ *asynq.Task) error {
+	if err := p.workers.BenefitPublisher.PublishScheduled(ctx); err != nil {
+		return fmt.Errorf("publish scheduled benefits failed: %w", err)
+	}
+
+	return nil
+}
@@ -34,3 +34,51 @@ func (p *sendEmailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 
 	return nil
 }
+
+type sendGroupExpiredEmailProcessor struct {
+	workers *worker.Workers
+}
+
+func NewSendGroupExpiredEmailProcessor(workers *worker.Workers) *sendGroupExpiredEmailProcessor {
+	return &sendGroupExpiredEmailProcessor{
+		workers: workers,
+	}
+}
+
+func (p *sendGroupExpiredEmailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var data task.SendGroupExpiredEmail
+	err := json.Unmarshal(t.Payload(), &data)
+	if err != nil {
+		return fmt.Errorf("process send group expired email task json unmarshal failed: %w", err)
+	}
+
+	if err = p.workers.EmailSender.SendGroupExpiredEmail(ctx, data.Email, data.Groups); err != nil {
+		return fmt.Errorf("send group expired email failed: %w", err)
+	}
+
+	return nil
+}
+
+type sendBenefitEndingEmailProcessor struct {
+	workers *worker.Workers
+}
+
+func NewSendBenefitEndingEmailProcessor(workers *worker.Workers) *sendBenefitEndingEmailProcessor {
+	return &sendBenefitEndingEmailProcessor{
+		workers: workers,
+	}
+}
+
+func (p *sendBenefitEndingEmailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var data task.SendBenefitEndingEmail
+	err := json.Unmarshal(t.Payload(), &data)
+	if err != nil {
+		return fmt.Errorf("process send benefit ending email task json unmarshal failed: %w", err)
+	}
+
+	if err = p.workers.EmailSender.SendBenefitEndingEmail(ctx, data.Email, data.Title, data.ValidTo); err != nil {
+		return fmt.Errorf("send benefit ending email failed: %w", err)
+	}
+
+	return nil
+}
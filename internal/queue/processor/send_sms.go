@@ -0,0 +1,36 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vibe-gaming/backend/internal/queue/task"
+	"github.com/vibe-gaming/backend/internal/worker"
+
+	"github.com/hibiken/asynq"
+)
+
+type sendSMSProcessor struct {
+	workers *worker.Workers
+}
+
+func NewSendSMSProcessor(workers *worker.Workers) *sendSMSProcessor {
+	return &sendSMSProcessor{
+		workers: workers,
+	}
+}
+
+func (p *sendSMSProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var data task.SendSMS
+	err := json.Unmarshal(t.Payload(), &data)
+	if err != nil {
+		return fmt.Errorf("process send sms task json unmarshal failed: %w", err)
+	}
+
+	if err = p.workers.SMSSender.SendVerificationSMS(ctx, data.Phone, data.VerificationCode); err != nil {
+		return fmt.Errorf("send verification sms failed: %w", err)
+	}
+
+	return nil
+}
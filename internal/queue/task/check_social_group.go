@@ -3,6 +3,8 @@ package task
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
@@ -11,22 +13,55 @@ import (
 const (
 	CheckSocialGroupTaskName  = "checkSocialGroupTask"
 	CheckSocialGroupQueueName = "checkSocialGroupQueue"
+
+	// CheckSocialGroupMaxRetry - сколько раз повторяется проверка при временной недоступности сервиса.
+	// С экспоненциальной задержкой последняя попытка выполняется примерно через 2 часа после первой
+	CheckSocialGroupMaxRetry = 8
+
+	checkSocialGroupRetryBaseDelay = 30 * time.Second
+	checkSocialGroupRetryMaxDelay  = time.Hour
 )
 
+// CheckSocialGroupRetryDelay - задержка перед повтором проверки после n неудачных попыток:
+// 30s, 1m, 2m, 4m... не больше часа, со случайным разбросом до 20%, чтобы повторы не приходили разом
+func CheckSocialGroupRetryDelay(n int) time.Duration {
+	delay := checkSocialGroupRetryMaxDelay
+	if n < 16 {
+		delay = min(checkSocialGroupRetryBaseDelay<<n, checkSocialGroupRetryMaxDelay)
+	}
+
+	return delay + time.Duration(rand.Int64N(int64(delay/5)+1))
+}
+
 type CheckSocialGroup struct {
 	UserID uuid.UUID `json:"user_id"`
-	SNILS  string    `json:"snils"`
-	Groups []string  `json:"groups"` // Список типов групп для проверки
+	// MemberID - член семьи пользователя, группы которого проверяются. Пусто - проверяются группы самого пользователя
+	MemberID *uuid.UUID `json:"member_id,omitempty"`
+	SNILS    string     `json:"snils"`
+	Groups   []string   `json:"groups"` // Список типов групп для проверки
 }
 
-// NewCheckSocialGroupTask создает новую задачу для проверки социальной группы
-func NewCheckSocialGroupTask(userID uuid.UUID, snils string, groups []string) (*asynq.Task, error) {
-	data := CheckSocialGroup{
+// NewCheckSocialGroupTask создает новую задачу для проверки социальной группы.
+// opts дополняют и переопределяют настройки по умолчанию, например asynq.TaskID для защиты от дублей
+func NewCheckSocialGroupTask(userID uuid.UUID, snils string, groups []string, opts ...asynq.Option) (*asynq.Task, error) {
+	return newCheckSocialGroupTask(CheckSocialGroup{
 		UserID: userID,
 		SNILS:  snils,
 		Groups: groups,
-	}
+	}, opts...)
+}
+
+// NewCheckHouseholdMemberGroupTask создает задачу для проверки социальных групп члена семьи пользователя
+func NewCheckHouseholdMemberGroupTask(userID uuid.UUID, memberID uuid.UUID, snils string, groups []string, opts ...asynq.Option) (*asynq.Task, error) {
+	return newCheckSocialGroupTask(CheckSocialGroup{
+		UserID:   userID,
+		MemberID: &memberID,
+		SNILS:    snils,
+		Groups:   groups,
+	}, opts...)
+}
 
+func newCheckSocialGroupTask(data CheckSocialGroup, opts ...asynq.Option) (*asynq.Task, error) {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("json data marshal failed: %w", err)
@@ -35,7 +70,9 @@ func NewCheckSocialGroupTask(userID uuid.UUID, snils string, groups []string) (*
 	return asynq.NewTask(
 		CheckSocialGroupTaskName,
 		payload,
-		asynq.MaxRetry(3),
-		asynq.Queue(CheckSocialGroupQueueName),
+		append([]asynq.Option{
+			asynq.MaxRetry(CheckSocialGroupMaxRetry),
+			asynq.Queue(CheckSocialGroupQueueName),
+		}, opts...)...,
 	), nil
 }
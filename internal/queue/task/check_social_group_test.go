@@ -0,0 +1,48 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckSocialGroupRetryDelay(t *testing.T) {
+	tests := []struct {
+		retried int
+		base    time.Duration
+	}{
+		{retried: 0, base: 30 * time.Second},
+		{retried: 1, base: time.Minute},
+		{retried: 2, base: 2 * time.Minute},
+		{retried: 3, base: 4 * time.Minute},
+		{retried: 6, base: 32 * time.Minute},
+		{retried: 7, base: time.Hour},
+		{retried: 15, base: time.Hour},
+		// Сдвиг на 64 и больше бит дал бы ноль, задержка не должна обнуляться
+		{retried: 64, base: time.Hour},
+		{retried: 1000, base: time.Hour},
+	}
+
+	for _, tt := range tests {
+		// Разброс случайный, поэтому каждое значение проверяется несколько раз
+		for range 100 {
+			delay := CheckSocialGroupRetryDelay(tt.retried)
+			if delay < tt.base || delay > tt.base+tt.base/5 {
+				t.Fatalf("CheckSocialGroupRetryDelay(%d) = %s, want between %s and %s",
+					tt.retried, delay, tt.base, tt.base+tt.base/5)
+			}
+		}
+	}
+}
+
+func TestCheckSocialGroupRetriesTakeAboutTwoHours(t *testing.T) {
+	var minTotal, maxTotal time.Duration
+	for n := range CheckSocialGroupMaxRetry {
+		base := min(checkSocialGroupRetryBaseDelay<<n, checkSocialGroupRetryMaxDelay)
+		minTotal += base
+		maxTotal += base + base/5
+	}
+
+	if minTotal < 90*time.Minute || maxTotal > 150*time.Minute {
+		t.Fatalf("retries take from %s to %s, want about 2 hours", minTotal, maxTotal)
+	}
+}
@@ -0,0 +1,24 @@
+package task
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	ExpireSocialGroupsTaskName = "expireSocialGroupsTask"
+)
+
+// NewExpireSocialGroupsTask создает задачу, которая переводит истекшие группы в expired
+// и заранее запускает повторную проверку групп, срок которых подходит к концу.
+// Пока задача в очереди или выполняется, такая же не ставится: планировщик запущен на каждой реплике
+func NewExpireSocialGroupsTask() *asynq.Task {
+	return asynq.NewTask(
+		ExpireSocialGroupsTaskName,
+		nil,
+		asynq.MaxRetry(3),
+		asynq.Queue(CheckSocialGroupQueueName),
+		asynq.Unique(30*time.Minute),
+	)
+}
@@ -0,0 +1,23 @@
+package task
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	ProcessBenefitExpiryTaskName = "processBenefitExpiryTask"
+)
+
+// NewProcessBenefitExpiryTask создает задачу, которая архивирует льготы с прошедшим valid_to
+// и предупреждает пользователей об окончании льгот из избранного. Пока задача в очереди или выполняется, такая же не ставится
+func NewProcessBenefitExpiryTask() *asynq.Task {
+	return asynq.NewTask(
+		ProcessBenefitExpiryTaskName,
+		nil,
+		asynq.MaxRetry(3),
+		asynq.Queue(BenefitQueueName),
+		asynq.Unique(30*time.Minute),
+	)
+}
@@ -0,0 +1,24 @@
+package task
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	PublishScheduledBenefitsTaskName = "publishScheduledBenefitsTask"
+	BenefitQueueName                 = "benefitQueue"
+)
+
+// NewPublishScheduledBenefitsTask создает задачу, которая публикует одобренные льготы по publish_at
+// и переводит в архив льготы по unpublish_at. Пока задача в очереди или выполняется, такая же не ставится
+func NewPublishScheduledBenefitsTask() *asynq.Task {
+	return asynq.NewTask(
+		PublishScheduledBenefitsTaskName,
+		nil,
+		asynq.MaxRetry(3),
+		asynq.Queue(BenefitQueueName),
+		asynq.Unique(5*time.Minute),
+	)
+}
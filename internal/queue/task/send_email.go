@@ -3,6 +3,7 @@ package task
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 )
@@ -34,3 +35,61 @@ func NewSendEmailTask(email string, verificationCode string) (*asynq.Task, error
 		asynq.Queue(SendEmailQueueName),
 	), nil
 }
+
+const (
+	SendGroupExpiredEmailTaskName = "sendGroupExpiredEmailTask"
+)
+
+type SendGroupExpiredEmail struct {
+	Email  string   `json:"email"`
+	Groups []string `json:"groups"` // Типы групп, подтверждение которых истекло
+}
+
+// NewSendGroupExpiredEmailTask создает задачу уведомления об истечении подтверждения групп
+func NewSendGroupExpiredEmailTask(email string, groups []string) (*asynq.Task, error) {
+	payload, err := json.Marshal(SendGroupExpiredEmail{
+		Email:  email,
+		Groups: groups,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json data marshal failed: %w", err)
+	}
+
+	return asynq.NewTask(
+		SendGroupExpiredEmailTaskName,
+		payload,
+		asynq.MaxRetry(5),
+		asynq.Queue(SendEmailQueueName),
+	), nil
+}
+
+const (
+	SendBenefitEndingEmailTaskName = "sendBenefitEndingEmailTask"
+)
+
+type SendBenefitEndingEmail struct {
+	Email   string    `json:"email"`
+	Title   string    `json:"title"`
+	ValidTo time.Time `json:"valid_to"`
+}
+
+// NewSendBenefitEndingEmailTask создает задачу уведомления о скором окончании льготы из избранного
+func NewSendBenefitEndingEmailTask(email string, title string, validTo time.Time, opts ...asynq.Option) (*asynq.Task, error) {
+	payload, err := json.Marshal(SendBenefitEndingEmail{
+		Email:   email,
+		Title:   title,
+		ValidTo: validTo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json data marshal failed: %w", err)
+	}
+
+	return asynq.NewTask(
+		SendBenefitEndingEmailTaskName,
+		payload,
+		append([]asynq.Option{
+			asynq.MaxRetry(5),
+			asynq.Queue(SendEmailQueueName),
+		}, opts...)...,
+	), nil
+}
@@ -0,0 +1,36 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	SendSMSTaskName  = "sendSMSTask"
+	SendSMSQueueName = "sendSMSQueue"
+)
+
+type SendSMS struct {
+	Phone            string `json:"phone"`
+	VerificationCode string `json:"verification_code"`
+}
+
+// NewSendSMSTask создает задачу отправки кода подтверждения по SMS
+func NewSendSMSTask(phone string, verificationCode string) (*asynq.Task, error) {
+	payload, err := json.Marshal(SendSMS{
+		Phone:            phone,
+		VerificationCode: verificationCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json data marshal failed: %w", err)
+	}
+
+	return asynq.NewTask(
+		SendSMSTaskName,
+		payload,
+		asynq.MaxRetry(5),
+		asynq.Queue(SendSMSQueueName),
+	), nil
+}
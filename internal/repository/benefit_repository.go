@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/vibe-gaming/backend/internal/domain"
 	"github.com/vibe-gaming/backend/pkg/logger"
@@ -14,6 +18,8 @@ import (
 type BenefitFilters struct {
 	RegionID            *int
 	CityID              *string
+	ResidenceCityID     *string  // Город проживания: льготы без привязки к городу и льготы этого города
+	OrganizationID      *string  // UUID организации, которой принадлежат льготы
 	Types               []string // Типы льгот для фильтрации (federal, regional, commercial) - OR логика
 	TargetGroups        []string
 	Tags                []string
@@ -28,21 +34,44 @@ type BenefitFilters struct {
 	FilterFavoritesOnly *bool    // Фильтровать только избранные (favorites=true)
 	FilterByUserGroups  *bool    // Фильтровать по группам пользователя
 	UserGroupTypes      []string // Подтвержденные группы пользователя для фильтрации
+	// Statuses - статусы публикации. Пусто - только опубликованные льготы, как в публичном каталоге
+	Statuses []domain.BenefitStatus
 }
 
 type UserBenefitsStats struct {
 	TotalBenefits  int64 `json:"total_benefits"`
 	TotalFavorites int64 `json:"total_favorites"`
+	// Members - кому из семьи принадлежат подтвержденные группы и сколько льгот они открывают
+	Members []UserBenefitsMemberStats `json:"members"`
+}
+
+// UserBenefitsMemberStats - льготы, доступные по подтвержденным группам одного члена семьи
+type UserBenefitsMemberStats struct {
+	MemberID      *uuid.UUID               `json:"member_id,omitempty"` // Пусто для самого пользователя
+	Relation      domain.HouseholdRelation `json:"relation"`
+	Name          string                   `json:"name"`
+	Groups        []string                 `json:"groups"`
+	TotalBenefits int64                    `json:"total_benefits"`
 }
 
 type BenefitRepository interface {
-	Create(ctx context.Context, benefit *domain.Benefit) error
+	Create(ctx context.Context, benefit *domain.Benefit, revision *domain.BenefitRevision) error
 	GetByID(ctx context.Context, id string, userID *string) (*domain.Benefit, error)
+	GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*domain.Benefit, error)
 	GetAll(ctx context.Context, limit, offset int, filters *BenefitFilters) ([]*domain.Benefit, error)
 	Count(ctx context.Context, filters *BenefitFilters) (int64, error)
 	CountAvailableForUser(ctx context.Context, targetGroups []string) (int64, error)
-	Update(ctx context.Context, benefit *domain.Benefit) error
-	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, benefit *domain.Benefit, revision *domain.BenefitRevision) error
+	Upsert(ctx context.Context, items []BenefitUpsert) error
+	GetByExternalIDs(ctx context.Context, externalIDs []string) ([]domain.Benefit, error)
+	IncrementViews(ctx context.Context, id uuid.UUID) error
+	UpdateStatus(ctx context.Context, benefit *domain.Benefit, fromStatus domain.BenefitStatus) error
+	PublishScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	ArchiveScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	ArchiveExpired(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	Delete(ctx context.Context, id string, revision *domain.BenefitRevision) error
+	GetRevisions(ctx context.Context, benefitID uuid.UUID) ([]domain.BenefitRevision, error)
+	GetRevision(ctx context.Context, benefitID uuid.UUID, version int) (*domain.BenefitRevision, error)
 	GetFilterStats(ctx context.Context, filters *BenefitFilters) (*FilterStats, error)
 }
 
@@ -61,17 +90,40 @@ func NewBenefitRepository(db *sqlx.DB) BenefitRepository {
 	}
 }
 
-func (r *benefitRepository) Create(ctx context.Context, benefit *domain.Benefit) error {
+// Create сохраняет льготу и ее первую версию одной транзакцией
+func (r *benefitRepository) Create(ctx context.Context, benefit *domain.Benefit, revision *domain.BenefitRevision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := insertBenefit(ctx, tx, benefit); err != nil {
+		return err
+	}
+
+	if err := insertBenefitRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+	return nil
+}
+
+func insertBenefit(ctx context.Context, tx *sqlx.Tx, benefit *domain.Benefit) error {
 	const query = `
-	INSERT INTO benefit (id, title, description, valid_from, valid_to, created_at, updated_at, deleted_at, type, target_group_ids, longitude, latitude, city_id, region, category, requirment, how_to_use, source_url, tags, views)
-	VALUES (uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?);
+	INSERT INTO benefit (id, title, description, valid_from, valid_to, created_at, updated_at, deleted_at, type, target_group_ids, longitude, latitude, city_id, region, category, requirment, how_to_use, source_url, tags, views, organization_id, external_id, eligibility_rules, status, publish_at, unpublish_at)
+	VALUES (uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, uuid_to_bin(?), ?, ?, ?, ?, ?);
 	`
-	_, err := r.db.ExecContext(ctx, query, benefit.ID, benefit.Title, benefit.Description, benefit.ValidFrom, benefit.ValidTo, benefit.CreatedAt, benefit.UpdatedAt, benefit.DeletedAt, benefit.Type, benefit.TargetGroupIDs, benefit.Longitude, benefit.Latitude, benefit.CityID, benefit.Region, benefit.Category, benefit.Requirement, benefit.HowToUse, benefit.SourceURL, benefit.Tags, benefit.Views)
+	_, err := tx.ExecContext(ctx, query, benefit.ID, benefit.Title, benefit.Description, benefit.ValidFrom, benefit.ValidTo, benefit.CreatedAt, benefit.UpdatedAt, benefit.DeletedAt, benefit.Type, benefit.TargetGroupIDs, benefit.Longitude, benefit.Latitude, benefit.CityID, benefit.Region, benefit.Category, benefit.Requirement, benefit.HowToUse, benefit.SourceURL, benefit.Tags, benefit.Views, benefit.OrganizationID, benefit.ExternalID, benefit.EligibilityRules, benefit.Status, benefit.PublishAt, benefit.UnpublishAt)
 	if err != nil {
 		return fmt.Errorf("db insert benefit: %w", err)
 	}
 	return nil
 }
+
 func (r *benefitRepository) GetByID(ctx context.Context, id string, userID *string) (*domain.Benefit, error) {
 	query := `
 		SELECT 
@@ -95,7 +147,12 @@ func (r *benefitRepository) GetByID(ctx context.Context, id string, userID *stri
 			b.source_url,
 			b.tags,
 			b.views,
-			bin_to_uuid(b.organization_id) as organization_id`
+			bin_to_uuid(b.organization_id) as organization_id,
+			b.external_id,
+			b.eligibility_rules,
+			b.status,
+			b.publish_at,
+			b.unpublish_at`
 
 	args := []interface{}{}
 
@@ -163,7 +220,12 @@ func (r *benefitRepository) GetAll(ctx context.Context, limit, offset int, filte
 			b.source_url,
 			b.tags,
 			b.views,
-			b.organization_id`
+			b.organization_id,
+			b.external_id,
+			b.eligibility_rules,
+			b.status,
+			b.publish_at,
+			b.unpublish_at`
 
 	// Добавляем поле is_favorite через LEFT JOIN с favorite
 	if filters != nil && filters.UserID != nil {
@@ -198,6 +260,8 @@ func (r *benefitRepository) GetAll(ctx context.Context, limit, offset int, filte
 	query += `
 		WHERE b.deleted_at IS NULL`
 
+	query, args = appendBenefitVisibilityFilter(query, args, filters)
+
 	// Если нужно фильтровать только избранные
 	if filters != nil && filters.FilterFavoritesOnly != nil && *filters.FilterFavoritesOnly && filters.UserID != nil {
 		query += ` AND f.id IS NOT NULL`
@@ -217,6 +281,18 @@ func (r *benefitRepository) GetAll(ctx context.Context, limit, offset int, filte
 			args = append(args, *filters.CityID)
 		}
 
+		// Фильтр по месту проживания: льготы без привязки к городу и льготы этого города
+		if filters.ResidenceCityID != nil {
+			query += ` AND (b.city_id IS NULL OR b.city_id = UUID_TO_BIN(?))`
+			args = append(args, *filters.ResidenceCityID)
+		}
+
+		// Фильтр по организации
+		if filters.OrganizationID != nil {
+			query += ` AND b.organization_id = UUID_TO_BIN(?)`
+			args = append(args, *filters.OrganizationID)
+		}
+
 		// Фильтр по типам (хотя бы один тип должен совпадать) - OR логика
 		if len(filters.Types) > 0 {
 			query += ` AND (`
@@ -414,6 +490,8 @@ func (r *benefitRepository) Count(ctx context.Context, filters *BenefitFilters)
 	query += `
 		WHERE b.deleted_at IS NULL`
 
+	query, args = appendBenefitVisibilityFilter(query, args, filters)
+
 	// Если нужно фильтровать только избранные
 	if filters != nil && filters.FilterFavoritesOnly != nil && *filters.FilterFavoritesOnly && filters.UserID != nil {
 		query += ` AND f.id IS NOT NULL`
@@ -433,6 +511,18 @@ func (r *benefitRepository) Count(ctx context.Context, filters *BenefitFilters)
 			args = append(args, *filters.CityID)
 		}
 
+		// Фильтр по месту проживания: льготы без привязки к городу и льготы этого города
+		if filters.ResidenceCityID != nil {
+			query += ` AND (b.city_id IS NULL OR b.city_id = UUID_TO_BIN(?))`
+			args = append(args, *filters.ResidenceCityID)
+		}
+
+		// Фильтр по организации
+		if filters.OrganizationID != nil {
+			query += ` AND b.organization_id = UUID_TO_BIN(?)`
+			args = append(args, *filters.OrganizationID)
+		}
+
 		// Фильтр по типам (хотя бы один тип должен совпадать) - OR логика
 		if len(filters.Types) > 0 {
 			query += ` AND (`
@@ -532,7 +622,29 @@ func (r *benefitRepository) Count(ctx context.Context, filters *BenefitFilters)
 	return count, nil
 }
 
-func (r *benefitRepository) Update(ctx context.Context, benefit *domain.Benefit) error {
+// Update сохраняет льготу и ее новую версию одной транзакцией. Статус публикации меняется только через UpdateStatus
+func (r *benefitRepository) Update(ctx context.Context, benefit *domain.Benefit, revision *domain.BenefitRevision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := updateBenefit(ctx, tx, benefit); err != nil {
+		return err
+	}
+
+	if err := insertBenefitRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+	return nil
+}
+
+func updateBenefit(ctx context.Context, tx *sqlx.Tx, benefit *domain.Benefit) error {
 	const query = `
 		UPDATE benefit
 		SET
@@ -553,23 +665,230 @@ func (r *benefitRepository) Update(ctx context.Context, benefit *domain.Benefit)
 			how_to_use = ?,
 			source_url = ?,
 			tags = ?,
-			views = ?
+			organization_id = uuid_to_bin(?),
+			eligibility_rules = ?,
+			status = ?,
+			publish_at = ?,
+			unpublish_at = ?
 		WHERE id = uuid_to_bin(?)
 	`
-	_, err := r.db.ExecContext(ctx, query, benefit.Title, benefit.Description, benefit.ValidFrom, benefit.ValidTo, benefit.UpdatedAt, benefit.DeletedAt, benefit.Type, benefit.TargetGroupIDs, benefit.Longitude, benefit.Latitude, benefit.CityID, benefit.Region, benefit.Category, benefit.Requirement, benefit.HowToUse, benefit.SourceURL, benefit.Tags, benefit.Views, benefit.ID)
+	_, err := tx.ExecContext(ctx, query, benefit.Title, benefit.Description, benefit.ValidFrom, benefit.ValidTo, benefit.UpdatedAt, benefit.DeletedAt, benefit.Type, benefit.TargetGroupIDs, benefit.Longitude, benefit.Latitude, benefit.CityID, benefit.Region, benefit.Category, benefit.Requirement, benefit.HowToUse, benefit.SourceURL, benefit.Tags, benefit.OrganizationID, benefit.EligibilityRules, benefit.Status, benefit.PublishAt, benefit.UnpublishAt, benefit.ID)
 	if err != nil {
 		return fmt.Errorf("db update benefit: %w", err)
 	}
 	return nil
 }
 
-func (r *benefitRepository) Delete(ctx context.Context, id string) error {
+// BenefitUpsert - льгота из импорта: новая (Create) или найденная по external_id, и ее версия
+type BenefitUpsert struct {
+	Benefit  *domain.Benefit
+	Revision *domain.BenefitRevision
+	Create   bool
+}
+
+// Upsert сохраняет льготы импорта и их версии одной транзакцией: при ошибке не сохраняется ни одна
+func (r *benefitRepository) Upsert(ctx context.Context, items []BenefitUpsert) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, item := range items {
+		if item.Create {
+			err = insertBenefit(ctx, tx, item.Benefit)
+		} else {
+			err = updateBenefit(ctx, tx, item.Benefit)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := insertBenefitRevision(ctx, tx, item.Revision); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+	return nil
+}
+
+// GetByExternalIDs возвращает льготы, в том числе удаленные, с указанными external_id
+func (r *benefitRepository) GetByExternalIDs(ctx context.Context, externalIDs []string) ([]domain.Benefit, error) {
+	benefits := []domain.Benefit{}
+	if len(externalIDs) == 0 {
+		return benefits, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT bin_to_uuid(id) as id, title, description, valid_from, valid_to, created_at, updated_at, deleted_at, type,
+			target_group_ids, longitude, latitude, bin_to_uuid(city_id) as city_id, region, category, requirment, how_to_use,
+			source_url, tags, views, bin_to_uuid(organization_id) as organization_id, external_id, eligibility_rules,
+			status, publish_at, unpublish_at
+		FROM benefit WHERE external_id IN (?)
+	`, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("build benefits by external ids query: %w", err)
+	}
+	if err := r.db.SelectContext(ctx, &benefits, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("db select benefits by external ids: %w", err)
+	}
+	return benefits, nil
+}
+
+// IncrementViews увеличивает счетчик просмотров, не трогая остальные поля и не создавая версию
+func (r *benefitRepository) IncrementViews(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		UPDATE benefit SET views = views + 1 WHERE id = uuid_to_bin(?)
+	`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("db increment benefit views: %w", err)
+	}
+	return nil
+}
+
+// UpdateStatus меняет статус публикации и расписание, если льгота все еще в статусе fromStatus.
+// Если статус успел смениться, например планировщиком, возвращает domain.ErrNotFound
+func (r *benefitRepository) UpdateStatus(ctx context.Context, benefit *domain.Benefit, fromStatus domain.BenefitStatus) error {
+	const query = `
+		UPDATE benefit SET status = ?, publish_at = ?, unpublish_at = ?, updated_at = ?
+		WHERE id = uuid_to_bin(?) AND status = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, benefit.Status, benefit.PublishAt, benefit.UnpublishAt, benefit.UpdatedAt, benefit.ID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("db update benefit status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db update benefit status: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// PublishScheduled публикует льготы на проверке, у которых наступило время publish_at
+func (r *benefitRepository) PublishScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return r.transitionScheduled(ctx, "publish_at", domain.BenefitStatusInReview, domain.BenefitStatusPublished, now)
+}
+
+// ArchiveScheduled переводит в архив опубликованные льготы, у которых наступило время unpublish_at
+func (r *benefitRepository) ArchiveScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return r.transitionScheduled(ctx, "unpublish_at", domain.BenefitStatusPublished, domain.BenefitStatusArchived, now)
+}
+
+// ArchiveExpired переводит в архив опубликованные льготы, срок действия которых закончился
+func (r *benefitRepository) ArchiveExpired(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return r.transitionBenefits(ctx, domain.BenefitStatusPublished, domain.BenefitStatusArchived,
+		"valid_to IS NOT NULL AND valid_to < ?", domain.BenefitExpiryBoundary(now), "", now)
+}
+
+// transitionScheduled переводит льготы из from в to по наступившему времени в колонке column и очищает его
+func (r *benefitRepository) transitionScheduled(ctx context.Context, column string, from, to domain.BenefitStatus, now time.Time) ([]uuid.UUID, error) {
+	return r.transitionBenefits(ctx, from, to, fmt.Sprintf("%[1]s IS NOT NULL AND %[1]s <= ?", column), now, column, now)
+}
+
+// transitionBenefits переводит льготы в статусе from, подходящие под условие condition с параметром conditionArg, в статус to
+// и очищает колонку clearColumn, если она задана. Строки блокируются, чтобы параллельный запуск на другой реплике не обработал их повторно
+func (r *benefitRepository) transitionBenefits(ctx context.Context, from, to domain.BenefitStatus, condition string, conditionArg interface{},
+	clearColumn string, now time.Time,
+) ([]uuid.UUID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	selectQuery := fmt.Sprintf(`
+		SELECT bin_to_uuid(id) FROM benefit
+		WHERE status = ? AND %s AND deleted_at IS NULL
+		FOR UPDATE
+	`, condition)
+	ids := []uuid.UUID{}
+	if err := tx.SelectContext(ctx, &ids, selectQuery, from, conditionArg); err != nil {
+		return nil, fmt.Errorf("db select benefits for transition: %w", err)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	set := `status = ?, updated_at = ?`
+	if clearColumn != "" {
+		set += fmt.Sprintf(`, %s = NULL`, clearColumn)
+	}
+	updateQuery, args, err := sqlx.In(fmt.Sprintf(`
+		UPDATE benefit SET %s
+		WHERE id IN (?)
+	`, set), to, now, benefitIDsToBin(ids))
+	if err != nil {
+		return nil, fmt.Errorf("build benefits transition update: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(updateQuery), args...); err != nil {
+		return nil, fmt.Errorf("db update benefits transition: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return ids, nil
+}
+
+func benefitIDsToBin(ids []uuid.UUID) [][]byte {
+	binIDs := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		binIDs = append(binIDs, id[:])
+	}
+	return binIDs
+}
+
+// appendBenefitVisibilityFilter ограничивает выборку статусами из фильтра. Публичная выборка (статусы не заданы)
+// содержит только опубликованные льготы, а если период date_from/date_to не задан - только действующие сейчас
+func appendBenefitVisibilityFilter(query string, args []interface{}, filters *BenefitFilters) (string, []interface{}) {
+	if filters != nil && len(filters.Statuses) > 0 {
+		query += ` AND b.status IN (?` + strings.Repeat(`, ?`, len(filters.Statuses)-1) + `)`
+		for _, status := range filters.Statuses {
+			args = append(args, status)
+		}
+		return query, args
+	}
+
+	query += ` AND b.status = ?`
+	args = append(args, domain.BenefitStatusPublished)
+
+	if filters == nil || (filters.DateFrom == nil && filters.DateTo == nil) {
+		query, args = appendBenefitValidityFilter(query, args, time.Now())
+	}
+
+	return query, args
+}
+
+// appendBenefitValidityFilter оставляет льготы, срок действия которых включает момент now (см. domain.Benefit.IsActiveAt)
+func appendBenefitValidityFilter(query string, args []interface{}, now time.Time) (string, []interface{}) {
+	query += ` AND (b.valid_from IS NULL OR b.valid_from <= ?) AND (b.valid_to IS NULL OR b.valid_to >= ?)`
+	args = append(args, now, domain.BenefitExpiryBoundary(now))
+	return query, args
+}
+
+// Delete помечает льготу удаленной и сохраняет версию с последним состоянием льготы одной транзакцией
+func (r *benefitRepository) Delete(ctx context.Context, id string, revision *domain.BenefitRevision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
 	const query = `
 		UPDATE benefit
 		SET deleted_at = NOW()
 		WHERE id = uuid_to_bin(?) AND deleted_at IS NULL
 	`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("db delete benefit: %w", err)
 	}
@@ -583,6 +902,86 @@ func (r *benefitRepository) Delete(ctx context.Context, id string) error {
 		return domain.ErrNotFound
 	}
 
+	if err := insertBenefitRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetByIDWithDeleted возвращает льготу, в том числе удаленную. Используется при восстановлении версий
+func (r *benefitRepository) GetByIDWithDeleted(ctx context.Context, id uuid.UUID) (*domain.Benefit, error) {
+	const query = `
+		SELECT bin_to_uuid(id) as id, title, description, valid_from, valid_to, created_at, updated_at, deleted_at, type,
+			target_group_ids, longitude, latitude, bin_to_uuid(city_id) as city_id, region, category, requirment, how_to_use,
+			source_url, tags, views, bin_to_uuid(organization_id) as organization_id, external_id, eligibility_rules,
+			status, publish_at, unpublish_at
+		FROM benefit WHERE id = uuid_to_bin(?)
+	`
+	var benefit domain.Benefit
+	if err := r.db.GetContext(ctx, &benefit, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("db get benefit with deleted: %w", err)
+	}
+	return &benefit, nil
+}
+
+// GetRevisions возвращает версии льготы, новые первыми
+func (r *benefitRepository) GetRevisions(ctx context.Context, benefitID uuid.UUID) ([]domain.BenefitRevision, error) {
+	const query = `
+		SELECT bin_to_uuid(id) AS id, bin_to_uuid(benefit_id) AS benefit_id, version, action, restored_from, snapshot,
+			bin_to_uuid(author_user_id) AS author_user_id, bin_to_uuid(author_api_key_id) AS author_api_key_id, created_at
+		FROM benefit_revision WHERE benefit_id = uuid_to_bin(?) ORDER BY version DESC
+	`
+	revisions := []domain.BenefitRevision{}
+	if err := r.db.SelectContext(ctx, &revisions, query, benefitID); err != nil {
+		return nil, fmt.Errorf("db select benefit revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *benefitRepository) GetRevision(ctx context.Context, benefitID uuid.UUID, version int) (*domain.BenefitRevision, error) {
+	const query = `
+		SELECT bin_to_uuid(id) AS id, bin_to_uuid(benefit_id) AS benefit_id, version, action, restored_from, snapshot,
+			bin_to_uuid(author_user_id) AS author_user_id, bin_to_uuid(author_api_key_id) AS author_api_key_id, created_at
+		FROM benefit_revision WHERE benefit_id = uuid_to_bin(?) AND version = ?
+	`
+	var revision domain.BenefitRevision
+	if err := r.db.GetContext(ctx, &revision, query, benefitID, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("db get benefit revision: %w", err)
+	}
+	return &revision, nil
+}
+
+// insertBenefitRevision сохраняет версию со следующим номером. Номер считается в той же транзакции,
+// что и изменение льготы, уникальный индекс (benefit_id, version) не дает двум изменениям получить один номер
+func insertBenefitRevision(ctx context.Context, tx *sqlx.Tx, revision *domain.BenefitRevision) error {
+	const versionQuery = `
+		SELECT COALESCE(MAX(version), 0) + 1 FROM benefit_revision WHERE benefit_id = uuid_to_bin(?) FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &revision.Version, versionQuery, revision.BenefitID); err != nil {
+		return fmt.Errorf("db get next benefit revision version: %w", err)
+	}
+
+	const query = `
+		INSERT INTO benefit_revision (id, benefit_id, version, action, restored_from, snapshot, author_user_id, author_api_key_id)
+		VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, uuid_to_bin(?), uuid_to_bin(?))
+	`
+	_, err := tx.ExecContext(ctx, query, revision.ID, revision.BenefitID, revision.Version, revision.Action, revision.RestoredFrom,
+		revision.Snapshot, revision.AuthorUserID, revision.AuthorAPIKeyID)
+	if err != nil {
+		return fmt.Errorf("db insert benefit revision: %w", err)
+	}
+
 	return nil
 }
 
@@ -603,6 +1002,8 @@ func (r *benefitRepository) GetFilterStats(ctx context.Context, filters *Benefit
 	baseQuery += `
 		WHERE b.deleted_at IS NULL`
 
+	baseQuery, baseArgs = appendBenefitVisibilityFilter(baseQuery, baseArgs, filters)
+
 	// Если нужно фильтровать только избранные
 	if filters != nil && filters.FilterFavoritesOnly != nil && *filters.FilterFavoritesOnly && filters.UserID != nil {
 		baseQuery += ` AND f.id IS NOT NULL`
@@ -622,6 +1023,12 @@ func (r *benefitRepository) GetFilterStats(ctx context.Context, filters *Benefit
 			baseArgs = append(baseArgs, *filters.CityID)
 		}
 
+		// Фильтр по месту проживания: льготы без привязки к городу и льготы этого города
+		if filters.ResidenceCityID != nil {
+			baseQuery += ` AND (b.city_id IS NULL OR b.city_id = UUID_TO_BIN(?))`
+			baseArgs = append(baseArgs, *filters.ResidenceCityID)
+		}
+
 		// Фильтр по целевым группам
 		if len(filters.TargetGroups) > 0 {
 			baseQuery += ` AND (`
@@ -753,9 +1160,10 @@ func (r *benefitRepository) CountAvailableForUser(ctx context.Context, targetGro
 	query := `
 		SELECT COUNT(*) 
 		FROM benefit b
-		WHERE b.deleted_at IS NULL`
+		WHERE b.deleted_at IS NULL AND b.status = ?`
 
-	args := []interface{}{}
+	args := []interface{}{domain.BenefitStatusPublished}
+	query, args = appendBenefitValidityFilter(query, args, time.Now())
 
 	// Добавляем условие для групп (OR логика - хотя бы одна группа должна совпадать)
 	query += ` AND (`
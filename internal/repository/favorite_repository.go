@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -16,6 +17,7 @@ type FavoriteRepository interface {
 	GetTotalCount(ctx context.Context) (int64, error)
 	Create(ctx context.Context, favorite *domain.Favorite) error
 	Update(ctx context.Context, favorite *domain.Favorite) error
+	GetBenefitEndingNotices(ctx context.Context, from, until time.Time) ([]domain.BenefitEndingNotice, error)
 }
 
 type favoriteRepository struct {
@@ -88,3 +90,24 @@ func (r *favoriteRepository) GetTotalCount(ctx context.Context) (int64, error) {
 	}
 	return count, nil
 }
+
+// GetBenefitEndingNotices возвращает пользователей с email, у которых в избранном есть опубликованные льготы
+// с окончанием срока действия в промежутке [from, until]
+func (r *favoriteRepository) GetBenefitEndingNotices(ctx context.Context, from, until time.Time) ([]domain.BenefitEndingNotice, error) {
+	const query = `
+		SELECT bin_to_uuid(b.id) AS benefit_id, bin_to_uuid(u.id) AS user_id, u.email, b.title, b.valid_to
+		FROM favorite f
+		INNER JOIN benefit b ON b.id = f.benefit_id
+		INNER JOIN user u ON u.id = f.user_id
+		WHERE f.deleted_at IS NULL
+			AND b.deleted_at IS NULL AND b.status = ?
+			AND b.valid_to >= ? AND b.valid_to <= ?
+			AND u.deleted_at IS NULL AND u.deactivated_at IS NULL
+			AND u.email IS NOT NULL AND u.email <> ''
+	`
+	notices := []domain.BenefitEndingNotice{}
+	if err := r.db.SelectContext(ctx, &notices, query, domain.BenefitStatusPublished, from, until); err != nil {
+		return nil, fmt.Errorf("db select benefit ending notices: %w", err)
+	}
+	return notices, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/db"
+	"github.com/vibe-gaming/backend/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type householdMemberRepository struct {
+	db *sqlx.DB
+}
+
+func newHouseholdMemberRepository(db *sqlx.DB) *householdMemberRepository {
+	return &householdMemberRepository{
+		db: db,
+	}
+}
+
+const householdMemberColumns = `bin_to_uuid(id) AS id, bin_to_uuid(user_id) AS user_id, relation, relation_status,
+	relation_verified_at, first_name, last_name, middle_name, birth_date, snils, group_type, created_at, updated_at`
+
+// Create добавляет члена семьи и события о выбранных для него группах одной транзакцией
+func (r *householdMemberRepository) Create(ctx context.Context, member *domain.HouseholdMember, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	const query = `
+	INSERT INTO household_member (id, user_id, relation, relation_status, first_name, last_name, middle_name, birth_date, snils, group_type)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	_, err = tx.ExecContext(ctx, query,
+		member.ID,
+		member.UserID,
+		member.Relation,
+		member.RelationStatus,
+		member.FirstName,
+		member.LastName,
+		member.MiddleName,
+		member.BirthDate,
+		member.SNILS,
+		member.GroupType,
+	)
+	if err != nil {
+		//nolint:errorlint
+		if mysqlError, ok := err.(*mysql.MySQLError); ok && mysqlError.Number == db.DuplicateEntry {
+			return domain.ErrDuplicateEntry
+		}
+		return fmt.Errorf("db insert household member: %w", err)
+	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *householdMemberRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.HouseholdMember, error) {
+	query := `SELECT ` + householdMemberColumns + ` FROM household_member WHERE id = uuid_to_bin(?);`
+
+	var member domain.HouseholdMember
+	if err := r.db.GetContext(ctx, &member, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select household member by id failed: %w", err)
+	}
+
+	return &member, nil
+}
+
+func (r *householdMemberRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.HouseholdMember, error) {
+	query := `SELECT ` + householdMemberColumns + ` FROM household_member
+	WHERE user_id = uuid_to_bin(?)
+	ORDER BY created_at ASC, id ASC;`
+
+	members := []domain.HouseholdMember{}
+	if err := r.db.SelectContext(ctx, &members, query, userID); err != nil {
+		return nil, fmt.Errorf("select household members by user id failed: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetBySNILS возвращает членов семьи с этим СНИЛС на всех аккаунтах
+func (r *householdMemberRepository) GetBySNILS(ctx context.Context, snils string) ([]domain.HouseholdMember, error) {
+	query := `SELECT ` + householdMemberColumns + ` FROM household_member
+	WHERE snils = ?
+	ORDER BY created_at ASC, id ASC;`
+
+	members := []domain.HouseholdMember{}
+	if err := r.db.SelectContext(ctx, &members, query, snils); err != nil {
+		return nil, fmt.Errorf("select household members by snils failed: %w", err)
+	}
+
+	return members, nil
+}
+
+// UpdateRelationStatus сохраняет статус подтверждения родства. verifiedAt заполняется только для verified
+func (r *householdMemberRepository) UpdateRelationStatus(ctx context.Context, id uuid.UUID, status domain.HouseholdRelationStatus, verifiedAt *time.Time) error {
+	const query = `UPDATE household_member SET relation_status = ?, relation_verified_at = ? WHERE id = uuid_to_bin(?);`
+
+	result, err := r.db.ExecContext(ctx, query, status, verifiedAt, id)
+	if err != nil {
+		return fmt.Errorf("update household member relation status failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM household_member WHERE id = uuid_to_bin(?));`, id); err != nil {
+			return fmt.Errorf("select household member exists failed: %w", err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+// UpdateGroups сохраняет группы члена семьи и события о переходах их статусов одной транзакцией
+func (r *householdMemberRepository) UpdateGroups(ctx context.Context, id uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	const query = `
+	UPDATE household_member SET group_type = ? WHERE id = uuid_to_bin(?);
+	`
+	result, err := tx.ExecContext(ctx, query, groups, id)
+	if err != nil {
+		return fmt.Errorf("update household member groups failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		// UPDATE без изменений тоже дает 0 строк, поэтому отличаем удаленного члена семьи отдельным запросом
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM household_member WHERE id = uuid_to_bin(?));`, id); err != nil {
+			return fmt.Errorf("select household member exists failed: %w", err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет члена семьи пользователя и записывает события о снятых с него группах одной транзакцией
+func (r *householdMemberRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	const query = `DELETE FROM household_member WHERE id = uuid_to_bin(?) AND user_id = uuid_to_bin(?);`
+
+	result, err := tx.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete household member failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetWithVerifiedGroups возвращает членов семьи активных пользователей, у которых есть подтвержденные группы,
+// порциями по limit в порядке id. Следующая порция запрашивается с afterID последнего члена семьи предыдущей
+func (r *householdMemberRepository) GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.HouseholdMember, error) {
+	const query = `
+	SELECT bin_to_uuid(m.id) AS id, bin_to_uuid(m.user_id) AS user_id, m.relation, m.relation_status,
+		m.relation_verified_at, m.first_name, m.last_name, m.middle_name, m.birth_date, m.snils, m.group_type,
+		m.created_at, m.updated_at
+	FROM household_member m
+	JOIN user u ON u.id = m.user_id AND u.deleted_at IS NULL
+	WHERE m.id > uuid_to_bin(?)
+		AND JSON_SEARCH(m.group_type, 'one', 'verified', NULL, '$[*].status') IS NOT NULL
+	ORDER BY m.id
+	LIMIT ?;
+	`
+	members := []domain.HouseholdMember{}
+	if err := r.db.SelectContext(ctx, &members, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("select household members with verified groups failed: %w", err)
+	}
+
+	return members, nil
+}
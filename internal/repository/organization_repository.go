@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/vibe-gaming/backend/internal/domain"
 	"github.com/vibe-gaming/backend/pkg/logger"
@@ -17,6 +21,10 @@ type OrganizationRepository interface {
 	GetAllByCityID(ctx context.Context, cityID string) ([]domain.Organization, error)
 	Update(ctx context.Context, organization *domain.Organization) error
 	Delete(ctx context.Context, id string) error
+	CreateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error
+	GetBuildingByID(ctx context.Context, id uuid.UUID) (*domain.OrganizationBuilding, error)
+	UpdateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error
+	DeleteBuilding(ctx context.Context, id uuid.UUID) error
 }
 
 type organizationRepository struct {
@@ -126,11 +134,15 @@ func (r *organizationRepository) GetAllByCityID(ctx context.Context, cityID stri
 	INNER JOIN benefit b ON b.organization_id = o.id
 	WHERE o.deleted_at IS NULL 
 		AND b.deleted_at IS NULL
+		AND b.status = 'published'
+		AND (b.valid_from IS NULL OR b.valid_from <= ?)
+		AND (b.valid_to IS NULL OR b.valid_to >= ?)
 		AND b.city_id = UUID_TO_BIN(?)
 	ORDER BY o.name ASC
 	`
+	now := time.Now()
 	var organizations []domain.Organization
-	err := r.db.SelectContext(ctx, &organizations, query, cityID)
+	err := r.db.SelectContext(ctx, &organizations, query, now, domain.BenefitExpiryBoundary(now), cityID)
 	if err != nil {
 		logger.Error("failed to get organizations by city", zap.Error(err), zap.String("city_id", cityID))
 		return nil, fmt.Errorf("failed to get organizations by city: %w", err)
@@ -159,3 +171,79 @@ func (r *organizationRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (r *organizationRepository) CreateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error {
+	const query = `
+	INSERT INTO organization_building (id, organization_id, address, latitude, longitude, phone_number, start_time, end_time, is_open, tags, type)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	_, err := r.db.ExecContext(ctx, query, building.ID, building.OrganizationID, building.Address, building.Latitude, building.Longitude, building.PhoneNumber, building.StartTime, building.EndTime, building.IsOpen, building.Tags, building.Type)
+	if err != nil {
+		return fmt.Errorf("db insert organization building: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) GetBuildingByID(ctx context.Context, id uuid.UUID) (*domain.OrganizationBuilding, error) {
+	const query = `
+	SELECT
+		BIN_TO_UUID(id) as id,
+		BIN_TO_UUID(organization_id) as organization_id,
+		created_at,
+		updated_at,
+		deleted_at,
+		address,
+		latitude,
+		longitude,
+		phone_number,
+		start_time,
+		end_time,
+		is_open,
+		tags,
+		type
+	FROM organization_building
+	WHERE id = uuid_to_bin(?) AND deleted_at IS NULL
+	`
+	var building domain.OrganizationBuilding
+	if err := r.db.GetContext(ctx, &building, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization building: %w", err)
+	}
+	return &building, nil
+}
+
+func (r *organizationRepository) UpdateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error {
+	const query = `
+	UPDATE organization_building
+	SET address = ?, latitude = ?, longitude = ?, phone_number = ?, start_time = ?, end_time = ?, is_open = ?, tags = ?, type = ?
+	WHERE id = uuid_to_bin(?) AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, building.Address, building.Latitude, building.Longitude, building.PhoneNumber, building.StartTime, building.EndTime, building.IsOpen, building.Tags, building.Type, building.ID)
+	if err != nil {
+		return fmt.Errorf("db update organization building: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) DeleteBuilding(ctx context.Context, id uuid.UUID) error {
+	const query = `
+	UPDATE organization_building SET deleted_at = NOW() WHERE id = uuid_to_bin(?) AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("db delete organization building: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("db delete organization building: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/db"
+	"github.com/vibe-gaming/backend/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type partnerAPIKeyRepository struct {
+	db *sqlx.DB
+}
+
+func newPartnerAPIKeyRepository(db *sqlx.DB) *partnerAPIKeyRepository {
+	return &partnerAPIKeyRepository{
+		db: db,
+	}
+}
+
+const partnerAPIKeyColumns = `bin_to_uuid(id) AS id, bin_to_uuid(organization_id) AS organization_id, name, key_prefix, key_hash,
+	scopes, bin_to_uuid(created_by) AS created_by, expires_at, last_used_at, last_used_ip, revoked_at, created_at`
+
+func (r *partnerAPIKeyRepository) Create(ctx context.Context, key *domain.PartnerAPIKey) error {
+	const query = `
+	INSERT INTO partner_api_key (id, organization_id, name, key_prefix, key_hash, scopes, created_by, expires_at)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, uuid_to_bin(?), ?);
+	`
+	_, err := r.db.ExecContext(ctx, query, key.ID, key.OrganizationID, key.Name, key.KeyPrefix, key.KeyHash, key.Scopes, key.CreatedBy, key.ExpiresAt)
+	if err != nil {
+		//nolint:errorlint
+		if mysqlError, ok := err.(*mysql.MySQLError); ok && mysqlError.Number == db.DuplicateEntry {
+			return domain.ErrDuplicateEntry
+		}
+		return fmt.Errorf("db insert partner api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *partnerAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.PartnerAPIKey, error) {
+	query := `SELECT ` + partnerAPIKeyColumns + ` FROM partner_api_key WHERE key_prefix = ?;`
+
+	var key domain.PartnerAPIKey
+	if err := r.db.GetContext(ctx, &key, query, prefix); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select partner api key by prefix failed: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetByOrganizationID возвращает все ключи организации, включая отозванные
+func (r *partnerAPIKeyRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]domain.PartnerAPIKey, error) {
+	query := `SELECT ` + partnerAPIKeyColumns + ` FROM partner_api_key
+	WHERE organization_id = uuid_to_bin(?)
+	ORDER BY created_at DESC;`
+
+	keys := []domain.PartnerAPIKey{}
+	if err := r.db.SelectContext(ctx, &keys, query, organizationID); err != nil {
+		return nil, fmt.Errorf("select partner api keys failed: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke отзывает ключ организации. Если ключа нет или он уже отозван, возвращает ErrNotFound
+func (r *partnerAPIKeyRepository) Revoke(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error {
+	const query = `
+	UPDATE partner_api_key SET revoked_at = NOW()
+	WHERE id = uuid_to_bin(?) AND organization_id = uuid_to_bin(?) AND revoked_at IS NULL;
+	`
+	result, err := r.db.ExecContext(ctx, query, id, organizationID)
+	if err != nil {
+		return fmt.Errorf("revoke partner api key failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *partnerAPIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, ip string) error {
+	const query = `UPDATE partner_api_key SET last_used_at = NOW(), last_used_ip = ? WHERE id = uuid_to_bin(?);`
+
+	if _, err := r.db.ExecContext(ctx, query, ip, id); err != nil {
+		return fmt.Errorf("update partner api key last used failed: %w", err)
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/vibe-gaming/backend/internal/domain"
 
 	"github.com/jmoiron/sqlx"
@@ -21,10 +24,10 @@ func newRefreshSessionRepository(db *sqlx.DB) *refreshSessionRepository {
 
 func (r *refreshSessionRepository) Create(ctx context.Context, session *domain.RefreshSession) error {
 	const query = `
-				INSERT INTO refresh_session (id, user_id, refresh_token, user_agent, ip, expires_in)
-				VALUES (uuid_to_bin(?), uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?)
+				INSERT INTO refresh_session (id, user_id, family_id, refresh_token, user_agent, ip, expires_in)
+				VALUES (uuid_to_bin(?), uuid_to_bin(?), uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?)
 				`
-	_, err := r.db.ExecContext(ctx, query, session.ID, session.UserID, session.RefreshToken, session.UserAgent, session.IP, session.ExpiresIn)
+	_, err := r.db.ExecContext(ctx, query, session.ID, session.UserID, session.FamilyID, session.RefreshToken, session.UserAgent, session.IP, session.ExpiresIn)
 
 	if err != nil {
 		return fmt.Errorf("db insert user: %w", err)
@@ -33,3 +36,110 @@ func (r *refreshSessionRepository) Create(ctx context.Context, session *domain.R
 	return nil
 
 }
+
+// GetByRefreshToken возвращает сессию по refresh токену, в том числе уже использованную или удалённую,
+// чтобы сервис мог распознать повторное предъявление токена
+func (r *refreshSessionRepository) GetByRefreshToken(ctx context.Context, refreshToken uuid.UUID) (*domain.RefreshSession, error) {
+	const query = `
+	SELECT id, user_id, family_id, refresh_token, user_agent, ip, expires_in, used_at, created_at, updated_at, deleted_at
+	FROM refresh_session WHERE refresh_token = uuid_to_bin(?);
+	`
+	var session domain.RefreshSession
+	if err := r.db.GetContext(ctx, &session, query, refreshToken); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select refresh session by token failed: %w", err)
+	}
+
+	return &session, nil
+}
+
+// MarkUsed помечает токен использованным. Обновление условное, поэтому из двух
+// параллельных запросов с одним токеном успешен только один, второй получит ErrNoRowsAffected
+func (r *refreshSessionRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	const query = `
+	UPDATE refresh_session SET used_at = NOW() WHERE id = uuid_to_bin(?) AND used_at IS NULL AND deleted_at IS NULL;
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("update refresh session used_at failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// DeleteByFamilyID отзывает все токены, полученные ротацией из одного входа
+func (r *refreshSessionRepository) DeleteByFamilyID(ctx context.Context, familyID uuid.UUID) error {
+	const query = `
+	UPDATE refresh_session SET deleted_at = NOW() WHERE family_id = uuid_to_bin(?) AND deleted_at IS NULL;
+	`
+	if _, err := r.db.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("delete refresh sessions by family id failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveByUserID возвращает активные сессии пользователя: по одной на цепочку ротации.
+// Время последнего использования - момент выпуска текущего refresh токена цепочки
+func (r *refreshSessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserSession, error) {
+	const query = `
+	SELECT bin_to_uuid(rs.family_id) AS family_id, rs.user_agent, rs.ip,
+		(SELECT MIN(f.created_at) FROM refresh_session f WHERE f.family_id = rs.family_id) AS signed_in_at,
+		rs.created_at AS last_used_at, rs.expires_in AS expires_at
+	FROM refresh_session rs
+	WHERE rs.user_id = uuid_to_bin(?) AND rs.used_at IS NULL AND rs.deleted_at IS NULL AND rs.expires_in > NOW()
+	ORDER BY rs.created_at DESC;
+	`
+	sessions := []domain.UserSession{}
+	if err := r.db.SelectContext(ctx, &sessions, query, userID); err != nil {
+		return nil, fmt.Errorf("select active refresh sessions failed: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// DeleteByUserIDAndFamilyID отзывает сессию пользователя. Проверка user_id не даёт отозвать чужую сессию
+func (r *refreshSessionRepository) DeleteByUserIDAndFamilyID(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) error {
+	const query = `
+	UPDATE refresh_session SET deleted_at = NOW()
+	WHERE user_id = uuid_to_bin(?) AND family_id = uuid_to_bin(?) AND deleted_at IS NULL;
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, familyID)
+	if err != nil {
+		return fmt.Errorf("delete refresh sessions by user id and family id failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUserID отзывает все сессии пользователя
+func (r *refreshSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	const query = `
+	UPDATE refresh_session SET deleted_at = NOW() WHERE user_id = uuid_to_bin(?) AND deleted_at IS NULL;
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete refresh sessions by user id failed: %w", err)
+	}
+
+	return nil
+}
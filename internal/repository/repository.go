@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vibe-gaming/backend/internal/domain"
@@ -10,24 +11,34 @@ import (
 )
 
 type Repositories struct {
-	Users          Users
-	RefreshSession RefreshSession
-	Benefits       BenefitRepository
-	Cities         Cities
-	Favorite       FavoriteRepository
-	UserDocument   UserDocumentRepository
-	Organization   OrganizationRepository
+	Users            Users
+	RefreshSession   RefreshSession
+	Benefits         BenefitRepository
+	Cities           Cities
+	Favorite         FavoriteRepository
+	UserDocument     UserDocumentRepository
+	Organization     OrganizationRepository
+	UserRoles        UserRoles
+	StaffCredentials StaffCredentials
+	PartnerAPIKeys   PartnerAPIKeys
+	VerificationDocs VerificationDocuments
+	HouseholdMembers HouseholdMembers
 }
 
 func NewRepositories(db *sqlx.DB) *Repositories {
 	return &Repositories{
-		Users:          newUserRepository(db),
-		RefreshSession: newRefreshSessionRepository(db),
-		Benefits:       NewBenefitRepository(db),
-		Cities:         newCityRepository(db),
-		Favorite:       NewFavoriteRepository(db),
-		UserDocument:   NewUserDocumentRepository(db),
-		Organization:   NewOrganizationRepository(db),
+		Users:            newUserRepository(db),
+		RefreshSession:   newRefreshSessionRepository(db),
+		Benefits:         NewBenefitRepository(db),
+		Cities:           newCityRepository(db),
+		Favorite:         NewFavoriteRepository(db),
+		UserDocument:     NewUserDocumentRepository(db),
+		Organization:     NewOrganizationRepository(db),
+		UserRoles:        newUserRoleRepository(db),
+		StaffCredentials: newStaffCredentialRepository(db),
+		PartnerAPIKeys:   newPartnerAPIKeyRepository(db),
+		VerificationDocs: newVerificationDocumentRepository(db),
+		HouseholdMembers: newHouseholdMemberRepository(db),
 	}
 }
 
@@ -35,15 +46,78 @@ type Users interface {
 	GetByExternalID(ctx context.Context, esiaOID string) (*domain.User, error)
 	Create(ctx context.Context, user *domain.User) error
 	GetOneByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.UserGroupList) error
+	UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error
 	UpdateRegisteredAt(ctx context.Context, userID uuid.UUID) error
-	UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList) error
+	UpdateEligibilityProfile(ctx context.Context, user *domain.User) error
+	UpdateProfile(ctx context.Context, user *domain.User, changes []domain.UserProfileChange, events []domain.UserGroupEvent) error
+	GetProfileChanges(ctx context.Context, userID uuid.UUID) ([]domain.UserProfileChange, error)
+	UpdateContact(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, value string, change domain.UserProfileChange) error
+	UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error
+	GetGroupEvents(ctx context.Context, userID uuid.UUID) ([]domain.UserGroupEvent, error)
+	GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.User, error)
 	Count(ctx context.Context) (int64, error)
 	GetUserGroupsStats(ctx context.Context) (map[string]int64, error)
+	SetDeactivated(ctx context.Context, userID uuid.UUID, deactivated bool) error
+	Delete(ctx context.Context, userID uuid.UUID) error
 }
 
 type RefreshSession interface {
 	Create(ctx context.Context, session *domain.RefreshSession) error
+	GetByRefreshToken(ctx context.Context, refreshToken uuid.UUID) (*domain.RefreshSession, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteByFamilyID(ctx context.Context, familyID uuid.UUID) error
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserSession, error)
+	DeleteByUserIDAndFamilyID(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type UserRoles interface {
+	Create(ctx context.Context, role *domain.UserRole) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserRole, error)
+	Delete(ctx context.Context, userID uuid.UUID, role domain.Role, organizationID *uuid.UUID) error
+}
+
+type StaffCredentials interface {
+	Create(ctx context.Context, user *domain.User, credential *domain.StaffCredential) error
+	GetByLogin(ctx context.Context, login string) (*domain.StaffCredential, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.StaffCredential, error)
+	UpdatePasswordHash(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	RegisterFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockDuration time.Duration) error
+	RegisterSuccessfulLogin(ctx context.Context, userID uuid.UUID) error
+	SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTOTP(ctx context.Context, userID uuid.UUID) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []domain.StaffRecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type PartnerAPIKeys interface {
+	Create(ctx context.Context, key *domain.PartnerAPIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*domain.PartnerAPIKey, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]domain.PartnerAPIKey, error)
+	Revoke(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, ip string) error
+}
+
+type VerificationDocuments interface {
+	Create(ctx context.Context, document *domain.VerificationDocument) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.VerificationDocument, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.VerificationDocument, error)
+	GetByStatus(ctx context.Context, status domain.VerificationDocumentStatus, limit, offset int) ([]domain.VerificationDocument, error)
+	CountByStatus(ctx context.Context, status domain.VerificationDocumentStatus) (int64, error)
+	Review(ctx context.Context, document *domain.VerificationDocument) error
+}
+
+type HouseholdMembers interface {
+	Create(ctx context.Context, member *domain.HouseholdMember, events []domain.UserGroupEvent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.HouseholdMember, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.HouseholdMember, error)
+	GetBySNILS(ctx context.Context, snils string) ([]domain.HouseholdMember, error)
+	UpdateRelationStatus(ctx context.Context, id uuid.UUID, status domain.HouseholdRelationStatus, verifiedAt *time.Time) error
+	UpdateGroups(ctx context.Context, id uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID, events []domain.UserGroupEvent) error
+	GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.HouseholdMember, error)
 }
 
 type Cities interface {
@@ -54,7 +128,8 @@ type Cities interface {
 
 type UserDocument interface {
 	Create(ctx context.Context, document *domain.UserDocument) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.UserDocument, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error)
 	Update(ctx context.Context, document *domain.UserDocument) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 }
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/db"
+	"github.com/vibe-gaming/backend/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type staffCredentialRepository struct {
+	db *sqlx.DB
+}
+
+func newStaffCredentialRepository(db *sqlx.DB) *staffCredentialRepository {
+	return &staffCredentialRepository{
+		db: db,
+	}
+}
+
+const staffCredentialColumns = `bin_to_uuid(user_id) AS user_id, login, password_hash, totp_secret, totp_enabled, totp_last_step,
+	failed_attempts, locked_until, last_login_at, created_at, updated_at`
+
+// Create создает пользователя-сотрудника вместе с учетными данными в одной транзакции
+func (r *staffCredentialRepository) Create(ctx context.Context, user *domain.User, credential *domain.StaffCredential) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	const userQuery = `
+	INSERT INTO user (id, first_name, last_name, middle_name, email)
+	VALUES (uuid_to_bin(?), ?, ?, ?, ?);
+	`
+	_, err = tx.ExecContext(ctx, userQuery, user.ID, user.FirstName.String, user.LastName.String, user.MiddleName.String, user.Email.String)
+	if err != nil {
+		return fmt.Errorf("db insert staff user: %w", err)
+	}
+
+	const credentialQuery = `
+	INSERT INTO staff_credential (user_id, login, password_hash)
+	VALUES (uuid_to_bin(?), ?, ?);
+	`
+	_, err = tx.ExecContext(ctx, credentialQuery, credential.UserID, credential.Login, credential.PasswordHash)
+	if err != nil {
+		//nolint:errorlint
+		if mysqlError, ok := err.(*mysql.MySQLError); ok && mysqlError.Number == db.DuplicateEntry {
+			return domain.ErrDuplicateEntry
+		}
+		return fmt.Errorf("db insert staff credential: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *staffCredentialRepository) GetByLogin(ctx context.Context, login string) (*domain.StaffCredential, error) {
+	query := `SELECT ` + staffCredentialColumns + ` FROM staff_credential WHERE login = ?;`
+
+	var credential domain.StaffCredential
+	if err := r.db.GetContext(ctx, &credential, query, login); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select staff credential by login failed: %w", err)
+	}
+
+	return &credential, nil
+}
+
+func (r *staffCredentialRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.StaffCredential, error) {
+	query := `SELECT ` + staffCredentialColumns + ` FROM staff_credential WHERE user_id = uuid_to_bin(?);`
+
+	var credential domain.StaffCredential
+	if err := r.db.GetContext(ctx, &credential, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select staff credential by user id failed: %w", err)
+	}
+
+	return &credential, nil
+}
+
+func (r *staffCredentialRepository) UpdatePasswordHash(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	const query = `UPDATE staff_credential SET password_hash = ? WHERE user_id = uuid_to_bin(?);`
+
+	if _, err := r.db.ExecContext(ctx, query, passwordHash, userID); err != nil {
+		return fmt.Errorf("update staff password hash failed: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterFailedAttempt увеличивает счетчик неудачных попыток. Когда счетчик достигает maxAttempts,
+// учетная запись блокируется на lockDuration, а счетчик сбрасывается.
+// MySQL применяет присваивания в SET по порядку, поэтому locked_until вычисляется до изменения счетчика
+func (r *staffCredentialRepository) RegisterFailedAttempt(ctx context.Context, userID uuid.UUID, maxAttempts int, lockDuration time.Duration) error {
+	const query = `
+	UPDATE staff_credential SET
+		locked_until = IF(failed_attempts + 1 >= ?, NOW() + INTERVAL ? SECOND, locked_until),
+		failed_attempts = IF(failed_attempts + 1 >= ?, 0, failed_attempts + 1)
+	WHERE user_id = uuid_to_bin(?);
+	`
+	if _, err := r.db.ExecContext(ctx, query, maxAttempts, int(lockDuration.Seconds()), maxAttempts, userID); err != nil {
+		return fmt.Errorf("register staff failed attempt failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *staffCredentialRepository) RegisterSuccessfulLogin(ctx context.Context, userID uuid.UUID) error {
+	const query = `
+	UPDATE staff_credential SET failed_attempts = 0, locked_until = NULL, last_login_at = NOW()
+	WHERE user_id = uuid_to_bin(?);
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("register staff login failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetTOTPSecret сохраняет новый секрет TOTP. До подтверждения кодом второй фактор не включен
+func (r *staffCredentialRepository) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	const query = `
+	UPDATE staff_credential SET totp_secret = ?, totp_enabled = FALSE, totp_last_step = 0
+	WHERE user_id = uuid_to_bin(?);
+	`
+	if _, err := r.db.ExecContext(ctx, query, secret, userID); err != nil {
+		return fmt.Errorf("set staff totp secret failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *staffCredentialRepository) EnableTOTP(ctx context.Context, userID uuid.UUID) error {
+	const query = `UPDATE staff_credential SET totp_enabled = TRUE WHERE user_id = uuid_to_bin(?);`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("enable staff totp failed: %w", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep запоминает шаг принятого TOTP кода. Если код этого или более позднего шага уже
+// использовался, возвращает ErrNoRowsAffected
+func (r *staffCredentialRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	const query = `
+	UPDATE staff_credential SET totp_last_step = ?
+	WHERE user_id = uuid_to_bin(?) AND totp_last_step < ?;
+	`
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return fmt.Errorf("update staff totp step failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes удаляет старые коды восстановления и сохраняет новые
+func (r *staffCredentialRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []domain.StaffRecoveryCode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM staff_recovery_code WHERE user_id = uuid_to_bin(?);`, userID); err != nil {
+		return fmt.Errorf("delete staff recovery codes failed: %w", err)
+	}
+
+	const query = `
+	INSERT INTO staff_recovery_code (id, user_id, code_hash)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?);
+	`
+	for _, code := range codes {
+		if _, err := tx.ExecContext(ctx, query, code.ID, userID, code.CodeHash); err != nil {
+			return fmt.Errorf("insert staff recovery code failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode помечает код восстановления использованным. Если кода нет или он уже использован,
+// возвращает ErrNotFound
+func (r *staffCredentialRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	const query = `
+	UPDATE staff_recovery_code SET used_at = NOW()
+	WHERE user_id = uuid_to_bin(?) AND code_hash = ? AND used_at IS NULL;
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("use staff recovery code failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *staffCredentialRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	const query = `SELECT COUNT(*) FROM staff_recovery_code WHERE user_id = uuid_to_bin(?) AND used_at IS NULL;`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("count staff recovery codes failed: %w", err)
+	}
+
+	return count, nil
+}
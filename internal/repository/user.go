@@ -26,7 +26,7 @@ func newUserRepository(db *sqlx.DB) *userRepository {
 
 func (r *userRepository) GetByExternalID(ctx context.Context, externalID string) (*domain.User, error) {
 	const query = `
-	SELECT id, external_id, first_name, last_name, middle_name, snils, email, phone_number, created_at, updated_at, deleted_at FROM user WHERE external_id = ?;
+	SELECT id, external_id, first_name, last_name, middle_name, snils, email, phone_number, group_type, birth_date, gender, inn, trusted, citizenship, profile_synced_at, email_confirmed_at, phone_confirmed_at, created_at, updated_at, deleted_at, deactivated_at FROM user WHERE external_id = ?;
 	`
 	var user domain.User
 	if err := r.db.GetContext(ctx, &user, query, externalID); err != nil {
@@ -42,8 +42,8 @@ func (r *userRepository) GetByExternalID(ctx context.Context, externalID string)
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	const query = `
 	INSERT INTO user
-	(id, external_id, first_name, last_name, middle_name, snils, email, phone_number)
-	VALUES(uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?);
+	(id, external_id, first_name, last_name, middle_name, snils, email, phone_number, birth_date, gender, inn, trusted, citizenship, profile_synced_at)
+	VALUES(uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW());
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -55,6 +55,11 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		user.SNILS.String,
 		user.Email.String,
 		user.PhoneNumber.String,
+		user.BirthDate,
+		user.Gender,
+		user.INN,
+		user.Trusted,
+		user.Citizenship,
 	)
 
 	if err != nil {
@@ -79,7 +84,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) GetOneByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	const query = `
-	SELECT id, external_id, first_name, last_name, middle_name, snils, email, phone_number, city_id, group_type, registered_at, created_at, updated_at, deleted_at FROM user WHERE id = uuid_to_bin(?);
+	SELECT id, external_id, first_name, last_name, middle_name, snils, email, phone_number, city_id, group_type, birth_date, gender, inn, trusted, citizenship, profile_synced_at, email_confirmed_at, phone_confirmed_at, monthly_income, children_count, disability_category, registered_at, created_at, updated_at, deleted_at, deactivated_at FROM user WHERE id = uuid_to_bin(?);
 	`
 	var user domain.User
 	if err := r.db.GetContext(ctx, &user, query, id); err != nil {
@@ -91,14 +96,128 @@ func (r *userRepository) GetOneByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &user, nil
 }
 
-func (r *userRepository) UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.UserGroupList) error {
+func (r *userRepository) UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
 	const query = `
 	UPDATE user SET city_id = uuid_to_bin(?), group_type = ? WHERE id = uuid_to_bin(?);
 	`
-	_, err := r.db.ExecContext(ctx, query, cityID, groups, userID)
+	_, err = tx.ExecContext(ctx, query, cityID, groups, userID)
 	if err != nil {
 		return fmt.Errorf("update user by id failed: %w", err)
 	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProfile сохраняет профиль из ЕСИА, журнал изменившихся полей и переходы статусов групп одной транзакцией
+func (r *userRepository) UpdateProfile(ctx context.Context, user *domain.User, changes []domain.UserProfileChange, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	const userQuery = `
+	UPDATE user SET first_name = ?, last_name = ?, middle_name = ?, snils = ?, email = ?, phone_number = ?,
+		birth_date = ?, gender = ?, inn = ?, trusted = ?, citizenship = ?, group_type = ?, profile_synced_at = NOW()
+	WHERE id = uuid_to_bin(?);
+	`
+	_, err = tx.ExecContext(ctx, userQuery,
+		user.FirstName.String,
+		user.LastName.String,
+		user.MiddleName.String,
+		user.SNILS.String,
+		user.Email.String,
+		user.PhoneNumber.String,
+		user.BirthDate,
+		user.Gender,
+		user.INN,
+		user.Trusted,
+		user.Citizenship,
+		user.GroupType,
+		user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user profile failed: %w", err)
+	}
+
+	const changeQuery = `
+	INSERT INTO user_profile_change (id, user_id, field, old_value, new_value)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?);
+	`
+	for _, change := range changes {
+		_, err = tx.ExecContext(ctx, changeQuery, change.ID, change.UserID, change.Field, change.OldValue, change.NewValue)
+		if err != nil {
+			return fmt.Errorf("insert user profile change failed: %w", err)
+		}
+	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetProfileChanges(ctx context.Context, userID uuid.UUID) ([]domain.UserProfileChange, error) {
+	const query = `
+	SELECT bin_to_uuid(id) AS id, bin_to_uuid(user_id) AS user_id, field, old_value, new_value, created_at
+	FROM user_profile_change WHERE user_id = uuid_to_bin(?) ORDER BY created_at DESC, id DESC;
+	`
+	changes := []domain.UserProfileChange{}
+	if err := r.db.SelectContext(ctx, &changes, query, userID); err != nil {
+		return nil, fmt.Errorf("select user profile changes failed: %w", err)
+	}
+
+	return changes, nil
+}
+
+// UpdateContact сохраняет email или телефон, подтвержденный пользователем, и записывает изменение в журнал профиля
+func (r *userRepository) UpdateContact(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, value string, change domain.UserProfileChange) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	userQuery := `UPDATE user SET email = ?, email_confirmed_at = NOW() WHERE id = uuid_to_bin(?);`
+	if contactType == domain.ContactTypePhone {
+		userQuery = `UPDATE user SET phone_number = ?, phone_confirmed_at = NOW() WHERE id = uuid_to_bin(?);`
+	}
+	if _, err = tx.ExecContext(ctx, userQuery, value, userID); err != nil {
+		return fmt.Errorf("update user contact failed: %w", err)
+	}
+
+	const changeQuery = `
+	INSERT INTO user_profile_change (id, user_id, field, old_value, new_value)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?);
+	`
+	_, err = tx.ExecContext(ctx, changeQuery, change.ID, change.UserID, change.Field, change.OldValue, change.NewValue)
+	if err != nil {
+		return fmt.Errorf("insert user profile change failed: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -113,17 +232,109 @@ func (r *userRepository) UpdateRegisteredAt(ctx context.Context, userID uuid.UUI
 	return nil
 }
 
-func (r *userRepository) UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList) error {
+// UpdateEligibilityProfile сохраняет указанные пользователем доход, количество детей и категорию инвалидности
+func (r *userRepository) UpdateEligibilityProfile(ctx context.Context, user *domain.User) error {
+	const query = `
+	UPDATE user SET monthly_income = ?, children_count = ?, disability_category = ? WHERE id = uuid_to_bin(?);
+	`
+	_, err := r.db.ExecContext(ctx, query, user.MonthlyIncome, user.ChildrenCount, user.DisabilityCategory, user.ID)
+	if err != nil {
+		return fmt.Errorf("update user eligibility profile failed: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserGroups сохраняет группы пользователя и события о переходах их статусов одной транзакцией
+func (r *userRepository) UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList, events []domain.UserGroupEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
 	const query = `
 	UPDATE user SET group_type = ? WHERE id = uuid_to_bin(?);
 	`
-	_, err := r.db.ExecContext(ctx, query, groups, userID)
+	_, err = tx.ExecContext(ctx, query, groups, userID)
 	if err != nil {
 		return fmt.Errorf("update user groups by id failed: %w", err)
 	}
+
+	if err := insertUserGroupEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx failed: %w", err)
+	}
+
 	return nil
 }
 
+// GetGroupEvents возвращает журнал переходов статусов групп пользователя, новые события первыми
+func (r *userRepository) GetGroupEvents(ctx context.Context, userID uuid.UUID) ([]domain.UserGroupEvent, error) {
+	const query = `
+	SELECT bin_to_uuid(id) AS id, bin_to_uuid(user_id) AS user_id, bin_to_uuid(household_member_id) AS household_member_id,
+		group_type, from_status, to_status, source,
+		bin_to_uuid(actor_id) AS actor_id, external_request_id, message, payload, created_at
+	FROM user_group_event WHERE user_id = uuid_to_bin(?) ORDER BY created_at DESC, id DESC;
+	`
+	events := []domain.UserGroupEvent{}
+	if err := r.db.SelectContext(ctx, &events, query, userID); err != nil {
+		return nil, fmt.Errorf("select user group events failed: %w", err)
+	}
+
+	return events, nil
+}
+
+// insertUserGroupEvents дописывает события в журнал. События только добавляются, не изменяются и не удаляются
+func insertUserGroupEvents(ctx context.Context, tx *sqlx.Tx, events []domain.UserGroupEvent) error {
+	const query = `
+	INSERT INTO user_group_event (id, user_id, household_member_id, group_type, from_status, to_status, source, actor_id,
+		external_request_id, message, payload)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, uuid_to_bin(?), ?, ?, ?);
+	`
+	for _, event := range events {
+		_, err := tx.ExecContext(ctx, query,
+			event.ID,
+			event.UserID,
+			event.HouseholdMemberID,
+			event.GroupType,
+			event.FromStatus,
+			event.ToStatus,
+			event.Source,
+			event.ActorID,
+			event.ExternalRequestID,
+			event.Message,
+			event.Payload,
+		)
+		if err != nil {
+			return fmt.Errorf("insert user group event failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetWithVerifiedGroups возвращает пользователей, у которых есть подтвержденные группы, порциями по limit
+// в порядке id. Следующая порция запрашивается с afterID последнего пользователя предыдущей
+func (r *userRepository) GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.User, error) {
+	const query = `
+	SELECT id, external_id, first_name, last_name, middle_name, snils, email, phone_number, group_type
+	FROM user
+	WHERE id > uuid_to_bin(?) AND deleted_at IS NULL
+		AND JSON_SEARCH(group_type, 'one', 'verified', NULL, '$[*].status') IS NOT NULL
+	ORDER BY id
+	LIMIT ?;
+	`
+	users := []domain.User{}
+	if err := r.db.SelectContext(ctx, &users, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("select users with verified groups failed: %w", err)
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	const query = `SELECT COUNT(*) FROM user WHERE deleted_at IS NULL`
 	var count int64
@@ -175,3 +386,47 @@ func (r *userRepository) GetUserGroupsStats(ctx context.Context) (map[string]int
 	
 	return result, nil
 }
+
+// SetDeactivated блокирует пользователя или снимает блокировку
+func (r *userRepository) SetDeactivated(ctx context.Context, userID uuid.UUID, deactivated bool) error {
+	query := `UPDATE user SET deactivated_at = NOW() WHERE id = uuid_to_bin(?) AND deleted_at IS NULL AND deactivated_at IS NULL;`
+	if !deactivated {
+		query = `UPDATE user SET deactivated_at = NULL WHERE id = uuid_to_bin(?) AND deleted_at IS NULL AND deactivated_at IS NOT NULL;`
+	}
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("update user deactivated_at failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	const query = `UPDATE user SET deleted_at = NOW() WHERE id = uuid_to_bin(?) AND deleted_at IS NULL;`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("delete user failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
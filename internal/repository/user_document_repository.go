@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -11,9 +13,10 @@ import (
 
 type UserDocumentRepository interface {
 	Create(ctx context.Context, document *domain.UserDocument) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.UserDocument, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error)
 	Update(ctx context.Context, document *domain.UserDocument) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 }
 
 type userDocumentRepository struct {
@@ -26,48 +29,95 @@ func NewUserDocumentRepository(db *sqlx.DB) UserDocumentRepository {
 	}
 }
 
+const userDocumentColumns = `bin_to_uuid(id) AS id, bin_to_uuid(user_id) AS user_id, document_type, document_number,
+	issued_at, issued_by, expires_at, created_at, updated_at, deleted_at`
+
 func (r *userDocumentRepository) Create(ctx context.Context, document *domain.UserDocument) error {
 	const query = `
-		INSERT INTO user_document (id, user_id, document_type, document_number, created_at, updated_at, deleted_at)
-		VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, ?)
+		INSERT INTO user_document (id, user_id, document_type, document_number, issued_at, issued_by, expires_at, created_at, updated_at, deleted_at)
+		VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.ExecContext(ctx, query, document.ID, document.UserID, document.DocumentType, document.DocumentNumber, document.CreatedAt, document.UpdatedAt, document.DeletedAt)
+	_, err := r.db.ExecContext(ctx, query,
+		document.ID,
+		document.UserID,
+		document.DocumentType,
+		document.DocumentNumber,
+		document.IssuedAt,
+		document.IssuedBy,
+		document.ExpiresAt,
+		document.CreatedAt,
+		document.UpdatedAt,
+		document.DeletedAt,
+	)
 	if err != nil {
 		return fmt.Errorf("db insert user document: %w", err)
 	}
 	return nil
 }
 
+func (r *userDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserDocument, error) {
+	query := `SELECT ` + userDocumentColumns + ` FROM user_document WHERE id = uuid_to_bin(?) AND deleted_at IS NULL`
+
+	var document domain.UserDocument
+	if err := r.db.GetContext(ctx, &document, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select user document by id failed: %w", err)
+	}
+	return &document, nil
+}
+
+// GetByUserID возвращает неудаленные документы пользователя в порядке добавления
 func (r *userDocumentRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error) {
-	const query = `
-		SELECT id, user_id, document_type, document_number, created_at, updated_at, deleted_at FROM user_document WHERE user_id = uuid_to_bin(?)
-	`
-	var documents []domain.UserDocument
+	query := `SELECT ` + userDocumentColumns + ` FROM user_document
+		WHERE user_id = uuid_to_bin(?) AND deleted_at IS NULL
+		ORDER BY created_at ASC`
+
+	documents := []domain.UserDocument{}
 	err := r.db.SelectContext(ctx, &documents, query, userID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("select user documents by user id failed: %w", err)
 	}
 	return documents, nil
 }
 
 func (r *userDocumentRepository) Update(ctx context.Context, document *domain.UserDocument) error {
 	const query = `
-		UPDATE user_document SET document_type = ?, document_number = ?, updated_at = ?, deleted_at = ? WHERE id = uuid_to_bin(?)
+		UPDATE user_document SET document_number = ?, issued_at = ?, issued_by = ?, expires_at = ?, updated_at = ?
+		WHERE id = uuid_to_bin(?) AND deleted_at IS NULL
 	`
-	_, err := r.db.ExecContext(ctx, query, document.DocumentType, document.DocumentNumber, document.UpdatedAt, document.DeletedAt, document.ID)
+	_, err := r.db.ExecContext(ctx, query,
+		document.DocumentNumber,
+		document.IssuedAt,
+		document.IssuedBy,
+		document.ExpiresAt,
+		document.UpdatedAt,
+		document.ID,
+	)
 	if err != nil {
 		return fmt.Errorf("db update user document: %w", err)
 	}
 	return nil
 }
 
-func (r *userDocumentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete помечает документ пользователя удаленным. Если документа нет или он чужой, возвращает ErrNotFound
+func (r *userDocumentRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
 	const query = `
-		UPDATE user_document SET deleted_at = NOW() WHERE id = uuid_to_bin(?)
+		UPDATE user_document SET deleted_at = NOW() WHERE id = uuid_to_bin(?) AND user_id = uuid_to_bin(?) AND deleted_at IS NULL
 	`
-	_, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, userID)
 	if err != nil {
 		return fmt.Errorf("db delete user document: %w", err)
 	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
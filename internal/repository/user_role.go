@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/db"
+	"github.com/vibe-gaming/backend/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type userRoleRepository struct {
+	db *sqlx.DB
+}
+
+func newUserRoleRepository(db *sqlx.DB) *userRoleRepository {
+	return &userRoleRepository{
+		db: db,
+	}
+}
+
+func (r *userRoleRepository) Create(ctx context.Context, role *domain.UserRole) error {
+	const query = `
+	INSERT INTO user_role (id, user_id, role, organization_id, granted_by)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), ?, uuid_to_bin(?), uuid_to_bin(?));
+	`
+	_, err := r.db.ExecContext(ctx, query, role.ID, role.UserID, role.Role, role.OrganizationID, role.GrantedBy)
+	if err != nil {
+		//nolint:errorlint
+		if mysqlError, ok := err.(*mysql.MySQLError); ok && mysqlError.Number == db.DuplicateEntry {
+			return domain.ErrDuplicateEntry
+		}
+		return fmt.Errorf("db insert user role: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRoleRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserRole, error) {
+	const query = `
+	SELECT bin_to_uuid(id) AS id, bin_to_uuid(user_id) AS user_id, role,
+		bin_to_uuid(organization_id) AS organization_id, bin_to_uuid(granted_by) AS granted_by, created_at
+	FROM user_role WHERE user_id = uuid_to_bin(?)
+	ORDER BY created_at;
+	`
+	roles := []domain.UserRole{}
+	if err := r.db.SelectContext(ctx, &roles, query, userID); err != nil {
+		return nil, fmt.Errorf("select user roles failed: %w", err)
+	}
+
+	return roles, nil
+}
+
+// Delete отзывает роль. organizationID учитывается только для ролей, выданных на организацию
+func (r *userRoleRepository) Delete(ctx context.Context, userID uuid.UUID, role domain.Role, organizationID *uuid.UUID) error {
+	query := `DELETE FROM user_role WHERE user_id = uuid_to_bin(?) AND role = ? AND organization_id IS NULL`
+	args := []interface{}{userID, role}
+	if organizationID != nil {
+		query = `DELETE FROM user_role WHERE user_id = uuid_to_bin(?) AND role = ? AND organization_id = uuid_to_bin(?)`
+		args = append(args, *organizationID)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("db delete user role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type verificationDocumentRepository struct {
+	db *sqlx.DB
+}
+
+func newVerificationDocumentRepository(db *sqlx.DB) *verificationDocumentRepository {
+	return &verificationDocumentRepository{
+		db: db,
+	}
+}
+
+// verificationDocumentSelect читает документы вместе с членом семьи, родство с которым подтверждает документ,
+// и числом других аккаунтов, добавивших члена семьи с тем же СНИЛС
+const verificationDocumentSelect = `SELECT bin_to_uuid(d.id) AS id, bin_to_uuid(d.user_id) AS user_id,
+	bin_to_uuid(d.household_member_id) AS household_member_id, COALESCE(d.group_type, '') AS group_type, d.storage_key,
+	d.file_name, d.content_type, d.size, d.status, d.reject_reason, bin_to_uuid(d.reviewed_by) AS reviewed_by,
+	d.reviewed_at, d.created_at,
+	m.relation AS member_relation, CONCAT_WS(' ', m.last_name, m.first_name, m.middle_name) AS member_name,
+	m.snils AS member_snils,
+	(SELECT COUNT(DISTINCT o.user_id) FROM household_member o WHERE o.snils = m.snils AND o.user_id <> m.user_id) AS snils_collisions
+	FROM verification_document d
+	LEFT JOIN household_member m ON m.id = d.household_member_id`
+
+type verificationDocumentRow struct {
+	domain.VerificationDocument
+	MemberRelation  sql.NullString `db:"member_relation"`
+	MemberName      sql.NullString `db:"member_name"`
+	MemberSNILS     sql.NullString `db:"member_snils"`
+	SNILSCollisions int            `db:"snils_collisions"`
+}
+
+func (r *verificationDocumentRow) toDomain() domain.VerificationDocument {
+	document := r.VerificationDocument
+	if r.MemberSNILS.Valid {
+		document.Member = &domain.VerificationDocumentMember{
+			Relation:        domain.HouseholdRelation(r.MemberRelation.String),
+			Name:            r.MemberName.String,
+			SNILS:           r.MemberSNILS.String,
+			SNILSCollisions: r.SNILSCollisions,
+		}
+	}
+	return document
+}
+
+func (r *verificationDocumentRepository) selectDocuments(ctx context.Context, query string, args ...any) ([]domain.VerificationDocument, error) {
+	rows := []verificationDocumentRow{}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	documents := make([]domain.VerificationDocument, 0, len(rows))
+	for i := range rows {
+		documents = append(documents, rows[i].toDomain())
+	}
+	return documents, nil
+}
+
+func (r *verificationDocumentRepository) Create(ctx context.Context, document *domain.VerificationDocument) error {
+	const query = `
+	INSERT INTO verification_document (id, user_id, household_member_id, group_type, storage_key, file_name, content_type, size, status, created_at)
+	VALUES (uuid_to_bin(?), uuid_to_bin(?), uuid_to_bin(?), NULLIF(?, ''), ?, ?, ?, ?, ?, ?);
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		document.ID,
+		document.UserID,
+		document.HouseholdMemberID,
+		document.GroupType,
+		document.StorageKey,
+		document.FileName,
+		document.ContentType,
+		document.Size,
+		document.Status,
+		document.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("db insert verification document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *verificationDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.VerificationDocument, error) {
+	query := verificationDocumentSelect + ` WHERE d.id = uuid_to_bin(?);`
+
+	var row verificationDocumentRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("select verification document by id failed: %w", err)
+	}
+
+	document := row.toDomain()
+	return &document, nil
+}
+
+func (r *verificationDocumentRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.VerificationDocument, error) {
+	query := verificationDocumentSelect + `
+	WHERE d.user_id = uuid_to_bin(?)
+	ORDER BY d.created_at DESC;`
+
+	documents, err := r.selectDocuments(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("select verification documents by user id failed: %w", err)
+	}
+
+	return documents, nil
+}
+
+// GetByStatus возвращает очередь модерации: старые документы первыми
+func (r *verificationDocumentRepository) GetByStatus(ctx context.Context, status domain.VerificationDocumentStatus, limit, offset int) ([]domain.VerificationDocument, error) {
+	query := verificationDocumentSelect + `
+	WHERE d.status = ?
+	ORDER BY d.created_at ASC
+	LIMIT ? OFFSET ?;`
+
+	documents, err := r.selectDocuments(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("select verification documents by status failed: %w", err)
+	}
+
+	return documents, nil
+}
+
+func (r *verificationDocumentRepository) CountByStatus(ctx context.Context, status domain.VerificationDocumentStatus) (int64, error) {
+	const query = `SELECT COUNT(*) FROM verification_document WHERE status = ?;`
+
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query, status); err != nil {
+		return 0, fmt.Errorf("count verification documents failed: %w", err)
+	}
+
+	return count, nil
+}
+
+// Review сохраняет решение модератора. Решение принимается один раз: если документ уже рассмотрен,
+// возвращает ErrNoRowsAffected
+func (r *verificationDocumentRepository) Review(ctx context.Context, document *domain.VerificationDocument) error {
+	const query = `
+	UPDATE verification_document SET status = ?, reject_reason = ?, reviewed_by = uuid_to_bin(?), reviewed_at = ?
+	WHERE id = uuid_to_bin(?) AND status = 'pending';
+	`
+	result, err := r.db.ExecContext(ctx, query, document.Status, document.RejectReason, document.ReviewedBy, document.ReviewedAt, document.ID)
+	if err != nil {
+		return fmt.Errorf("update verification document review failed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNoRowsAffected
+	}
+
+	return nil
+}
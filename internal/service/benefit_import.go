@@ -0,0 +1,485 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+)
+
+// maxExternalIDLength - длина колонки benefit.external_id
+const maxExternalIDLength = 255
+
+// BenefitImportRecord - льгота в файле импорта. Поля совпадают с телом создания льготы, дополнительно:
+// external_id - обязательный ключ, по которому повторный импорт обновляет льготу,
+// city и organization - названия города и организации, если их id неизвестны
+type BenefitImportRecord struct {
+	ExternalID       string                   `json:"external_id"`
+	Title            string                   `json:"title"`
+	Description      string                   `json:"description"`
+	ValidFrom        *string                  `json:"valid_from,omitempty"`
+	ValidTo          *string                  `json:"valid_to,omitempty"`
+	Type             string                   `json:"type"`
+	TargetGroups     []string                 `json:"target_groups"`
+	Longitude        *float64                 `json:"longitude,omitempty"`
+	Latitude         *float64                 `json:"latitude,omitempty"`
+	CityID           *string                  `json:"city_id,omitempty"`
+	City             *string                  `json:"city,omitempty"`
+	Region           []int                    `json:"region,omitempty"`
+	Category         *string                  `json:"category,omitempty"`
+	Requirement      string                   `json:"requirement"`
+	HowToUse         *string                  `json:"how_to_use,omitempty"`
+	SourceURL        string                   `json:"source_url"`
+	Tags             []string                 `json:"tags,omitempty"`
+	OrganizationID   *string                  `json:"organization_id,omitempty"`
+	Organization     *string                  `json:"organization,omitempty"`
+	EligibilityRules *domain.EligibilityRules `json:"eligibility_rules,omitempty"`
+}
+
+// BenefitImportRow - разобранная строка файла импорта
+type BenefitImportRow struct {
+	// Line - номер строки таблицы (первая строка - заголовок) или номер элемента JSON массива, с 1
+	Line   int
+	Record BenefitImportRecord
+	// Errors - ошибки разбора значений ячеек
+	Errors []string
+}
+
+type BenefitImportOptions struct {
+	// DryRun - только проверить строки и вернуть отчет, ничего не сохраняя
+	DryRun bool
+	// OrganizationID - импорт от имени организации: льготы без организации получают ее,
+	// льготы других организаций отклоняются
+	OrganizationID *uuid.UUID
+	Author         domain.BenefitAuthor
+}
+
+type BenefitImportAction string
+
+const (
+	BenefitImportActionCreate    BenefitImportAction = "create"
+	BenefitImportActionUpdate    BenefitImportAction = "update"
+	BenefitImportActionUnchanged BenefitImportAction = "unchanged"
+)
+
+type BenefitImportReport struct {
+	DryRun    bool `json:"dry_run"`
+	Total     int  `json:"total"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Unchanged int  `json:"unchanged"`
+	Failed    int  `json:"failed"`
+	// Rows - результат по каждой строке файла
+	Rows []BenefitImportRowResult `json:"rows"`
+}
+
+type BenefitImportRowResult struct {
+	Line       int    `json:"line"`
+	ExternalID string `json:"external_id,omitempty"`
+	// Action - что сделано (в dry run - что будет сделано) со строкой без ошибок
+	Action    BenefitImportAction `json:"action,omitempty"`
+	BenefitID *uuid.UUID          `json:"benefit_id,omitempty"`
+	// Status - статус публикации льготы после импорта: опубликованная льгота, измененная без права публикации, уходит на проверку
+	Status domain.BenefitStatus `json:"status,omitempty"`
+	Errors []string             `json:"errors,omitempty"`
+}
+
+type benefitImportService struct {
+	benefitRepository      repository.BenefitRepository
+	cityRepository         repository.Cities
+	organizationRepository repository.OrganizationRepository
+}
+
+// NewBenefitImportService создает сервис импорта льгот. Экспортируется для CLI импорта,
+// которому не нужны остальные сервисы
+func NewBenefitImportService(repos *repository.Repositories) BenefitImport {
+	return &benefitImportService{
+		benefitRepository:      repos.Benefits,
+		cityRepository:         repos.Cities,
+		organizationRepository: repos.Organization,
+	}
+}
+
+// Import проверяет строки импорта и сохраняет строки без ошибок одной транзакцией.
+// Льгота ищется по external_id: новая создается черновиком, найденная обновляется с сохранением статуса.
+// Строка без изменений не сохраняется, чтобы повторный импорт не плодил версии
+func (s *benefitImportService) Import(ctx context.Context, rows []BenefitImportRow, options BenefitImportOptions) (*BenefitImportReport, error) {
+	resolver, err := s.newImportResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	externalIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if externalID := strings.TrimSpace(row.Record.ExternalID); externalID != "" {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+	existing, err := s.benefitRepository.GetByExternalIDs(ctx, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get benefits by external ids failed: %w", err)
+	}
+	existingByExternalID := make(map[string]*domain.Benefit, len(existing))
+	for i := range existing {
+		if existing[i].ExternalID != nil {
+			existingByExternalID[*existing[i].ExternalID] = &existing[i]
+		}
+	}
+
+	report := &BenefitImportReport{
+		DryRun: options.DryRun,
+		Total:  len(rows),
+		Rows:   make([]BenefitImportRowResult, 0, len(rows)),
+	}
+	items := make([]repository.BenefitUpsert, 0, len(rows))
+	firstLine := make(map[string]int, len(rows))
+	now := time.Now()
+
+	for _, row := range rows {
+		externalID := strings.TrimSpace(row.Record.ExternalID)
+		result := BenefitImportRowResult{Line: row.Line, ExternalID: externalID}
+
+		imported, recordErrs := resolver.benefitFromRecord(&row.Record)
+		errs := append(append([]string{}, row.Errors...), recordErrs...)
+
+		if line, ok := firstLine[externalID]; ok && externalID != "" {
+			errs = append(errs, fmt.Sprintf("duplicate external_id, first used in line %d", line))
+		} else {
+			firstLine[externalID] = row.Line
+		}
+
+		current := existingByExternalID[externalID]
+		if current != nil && current.DeletedAt != nil {
+			errs = append(errs, "benefit with this external_id is deleted, restore it from revisions first")
+		}
+
+		if options.OrganizationID != nil && imported != nil {
+			if imported.OrganizationID == nil {
+				imported.OrganizationID = options.OrganizationID
+			}
+			if *imported.OrganizationID != *options.OrganizationID {
+				errs = append(errs, "benefit must belong to the importing organization")
+			}
+			if current != nil && (current.OrganizationID == nil || *current.OrganizationID != *options.OrganizationID) {
+				errs = append(errs, "existing benefit belongs to another organization")
+			}
+		}
+
+		if len(errs) > 0 {
+			result.Errors = errs
+			report.Failed++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+
+		item, action, err := prepareBenefitUpsert(current, imported, externalID, options.Author, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Action = action
+		result.Status = item.Benefit.Status
+		// id новой льготы в dry run не сохраняется, показывать его нет смысла
+		if action != BenefitImportActionCreate || !options.DryRun {
+			result.BenefitID = &item.Benefit.ID
+		}
+
+		switch action {
+		case BenefitImportActionCreate:
+			report.Created++
+			items = append(items, item)
+		case BenefitImportActionUpdate:
+			report.Updated++
+			items = append(items, item)
+		case BenefitImportActionUnchanged:
+			report.Unchanged++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if options.DryRun || len(items) == 0 {
+		return report, nil
+	}
+
+	if err := s.benefitRepository.Upsert(ctx, items); err != nil {
+		return nil, fmt.Errorf("upsert imported benefits failed: %w", err)
+	}
+
+	return report, nil
+}
+
+// prepareBenefitUpsert готовит создание новой льготы или изменение найденной current и их версию
+func prepareBenefitUpsert(current *domain.Benefit, imported *domain.Benefit, externalID string, author domain.BenefitAuthor, now time.Time,
+) (repository.BenefitUpsert, BenefitImportAction, error) {
+	if current == nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return repository.BenefitUpsert{}, "", fmt.Errorf("generate benefit id failed: %w", err)
+		}
+		imported.ID = id
+		imported.ExternalID = &externalID
+		imported.CreatedAt = now
+		imported.UpdatedAt = now
+		// Как и при создании через API, льгота не видна гражданам, пока ее не отправят на проверку и не опубликуют
+		imported.Status = domain.BenefitStatusDraft
+
+		revision, err := domain.NewBenefitRevision(imported, domain.BenefitRevisionActionCreate, author)
+		if err != nil {
+			return repository.BenefitUpsert{}, "", err
+		}
+		return repository.BenefitUpsert{Benefit: imported, Revision: revision, Create: true}, BenefitImportActionCreate, nil
+	}
+
+	// Даты из файла и из БД могут быть в разных часовых поясах: один и тот же момент не считается изменением
+	imported.ValidFrom = sameInstant(current.ValidFrom, imported.ValidFrom)
+	imported.ValidTo = sameInstant(current.ValidTo, imported.ValidTo)
+
+	before := domain.NewBenefitSnapshot(current)
+	after := domain.NewBenefitSnapshot(imported)
+	changes, err := domain.DiffBenefitSnapshots(before, after)
+	if err != nil {
+		return repository.BenefitUpsert{}, "", err
+	}
+	if len(changes) == 0 {
+		return repository.BenefitUpsert{Benefit: current}, BenefitImportActionUnchanged, nil
+	}
+
+	after.ApplyTo(current)
+	current.UpdatedAt = now
+	// Как и при правке через API, без права публикации льгота возвращается на проверку
+	current.ResetReviewAfterEdit(author)
+
+	revision, err := domain.NewBenefitRevision(current, domain.BenefitRevisionActionUpdate, author)
+	if err != nil {
+		return repository.BenefitUpsert{}, "", err
+	}
+	return repository.BenefitUpsert{Benefit: current, Revision: revision}, BenefitImportActionUpdate, nil
+}
+
+// importResolver проверяет записи импорта и находит города и организации по id или названию
+type importResolver struct {
+	cityIDs             map[uuid.UUID]bool
+	cityByName          map[string]uuid.UUID
+	organizationIDs     map[uuid.UUID]bool
+	organizationsByName map[string][]uuid.UUID
+}
+
+func (s *benefitImportService) newImportResolver(ctx context.Context) (*importResolver, error) {
+	cities, err := s.cityRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get cities failed: %w", err)
+	}
+	organizations, err := s.organizationRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get organizations failed: %w", err)
+	}
+
+	resolver := &importResolver{
+		cityIDs:             make(map[uuid.UUID]bool, len(cities)),
+		cityByName:          make(map[string]uuid.UUID, len(cities)),
+		organizationIDs:     make(map[uuid.UUID]bool, len(organizations)),
+		organizationsByName: make(map[string][]uuid.UUID, len(organizations)),
+	}
+	for _, city := range cities {
+		resolver.cityIDs[city.ID] = true
+		resolver.cityByName[normalizeImportName(city.Name)] = city.ID
+	}
+	for _, organization := range organizations {
+		name := normalizeImportName(organization.Name)
+		resolver.organizationIDs[organization.ID] = true
+		resolver.organizationsByName[name] = append(resolver.organizationsByName[name], organization.ID)
+	}
+
+	return resolver, nil
+}
+
+// normalizeImportName - названия сравниваются без учета регистра и лишних пробелов
+func normalizeImportName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// benefitFromRecord проверяет запись по тем же правилам, что и создание льготы через API, и собирает domain.Benefit.
+// Возвращаются все ошибки записи, чтобы их можно было исправить за один раз
+func (r *importResolver) benefitFromRecord(record *BenefitImportRecord) (*domain.Benefit, []string) {
+	var errs []string
+
+	externalID := strings.TrimSpace(record.ExternalID)
+	if externalID == "" {
+		errs = append(errs, "external_id is required")
+	} else if len(externalID) > maxExternalIDLength {
+		errs = append(errs, fmt.Sprintf("external_id must be at most %d characters", maxExternalIDLength))
+	}
+	required := []struct{ field, value string }{
+		{"title", record.Title},
+		{"description", record.Description},
+		{"requirement", record.Requirement},
+		{"source_url", record.SourceURL},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			errs = append(errs, r.field+" is required")
+		}
+	}
+
+	if !domain.BenefitLevel(record.Type).IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid benefit type: %q. Valid values: federal, regional, commercial", record.Type))
+	}
+
+	if len(record.TargetGroups) == 0 {
+		errs = append(errs, "target_groups is required")
+	}
+	targetGroups := make(domain.TargetGroupList, 0, len(record.TargetGroups))
+	for _, group := range record.TargetGroups {
+		if !domain.TargetGroup(group).IsValid() {
+			errs = append(errs, fmt.Sprintf("invalid target group: %s", group))
+			continue
+		}
+		targetGroups = append(targetGroups, domain.TargetGroup(group))
+	}
+
+	var category *domain.Category
+	if record.Category != nil && *record.Category != "" {
+		value := domain.Category(*record.Category)
+		if !value.IsValid() {
+			errs = append(errs, fmt.Sprintf("invalid category: %s", *record.Category))
+		}
+		category = &value
+	}
+
+	tags := make(domain.BenefitTagList, 0, len(record.Tags))
+	for _, tag := range record.Tags {
+		if !domain.BenefitTag(tag).IsValid() {
+			errs = append(errs, fmt.Sprintf("invalid tag: %s", tag))
+			continue
+		}
+		tags = append(tags, domain.BenefitTag(tag))
+	}
+
+	if record.EligibilityRules != nil {
+		if err := record.EligibilityRules.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	validFrom, err := parseImportDate(record.ValidFrom)
+	if err != nil {
+		errs = append(errs, "invalid valid_from date format. Use YYYY-MM-DD")
+	}
+	validTo, err := parseImportDate(record.ValidTo)
+	if err != nil {
+		errs = append(errs, "invalid valid_to date format. Use YYYY-MM-DD")
+	}
+	if validFrom != nil && validTo != nil && validTo.Before(*validFrom) {
+		errs = append(errs, "valid_to must not be before valid_from")
+	}
+
+	cityID, err := r.resolveCity(record)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	organizationID, err := r.resolveOrganization(record)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	region := domain.RegionList(record.Region)
+	if region == nil {
+		region = domain.RegionList{}
+	}
+
+	return &domain.Benefit{
+		Title:            record.Title,
+		Description:      record.Description,
+		ValidFrom:        validFrom,
+		ValidTo:          validTo,
+		Type:             domain.BenefitLevel(record.Type),
+		TargetGroupIDs:   targetGroups,
+		Longitude:        record.Longitude,
+		Latitude:         record.Latitude,
+		CityID:           cityID,
+		Region:           region,
+		Category:         category,
+		Requirement:      record.Requirement,
+		HowToUse:         record.HowToUse,
+		SourceURL:        record.SourceURL,
+		Tags:             tags,
+		OrganizationID:   organizationID,
+		EligibilityRules: record.EligibilityRules,
+	}, nil
+}
+
+func (r *importResolver) resolveCity(record *BenefitImportRecord) (*uuid.UUID, error) {
+	if record.CityID != nil && *record.CityID != "" {
+		id, err := uuid.Parse(*record.CityID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid city_id format")
+		}
+		if !r.cityIDs[id] {
+			return nil, fmt.Errorf("city not found: %s", *record.CityID)
+		}
+		return &id, nil
+	}
+
+	if record.City != nil && strings.TrimSpace(*record.City) != "" {
+		id, ok := r.cityByName[normalizeImportName(*record.City)]
+		if !ok {
+			return nil, fmt.Errorf("city not found: %s", *record.City)
+		}
+		return &id, nil
+	}
+
+	return nil, nil
+}
+
+func (r *importResolver) resolveOrganization(record *BenefitImportRecord) (*uuid.UUID, error) {
+	if record.OrganizationID != nil && *record.OrganizationID != "" {
+		id, err := uuid.Parse(*record.OrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid organization_id format")
+		}
+		if !r.organizationIDs[id] {
+			return nil, fmt.Errorf("organization not found: %s", *record.OrganizationID)
+		}
+		return &id, nil
+	}
+
+	if record.Organization != nil && strings.TrimSpace(*record.Organization) != "" {
+		ids := r.organizationsByName[normalizeImportName(*record.Organization)]
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("organization not found: %s", *record.Organization)
+		case 1:
+			return &ids[0], nil
+		default:
+			return nil, fmt.Errorf("several organizations are named %q, use organization_id", *record.Organization)
+		}
+	}
+
+	return nil, nil
+}
+
+// sameInstant возвращает current, если imported - тот же момент времени
+func sameInstant(current, imported *time.Time) *time.Time {
+	if current != nil && imported != nil && current.Equal(*imported) {
+		return current
+	}
+	return imported
+}
+
+func parseImportDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
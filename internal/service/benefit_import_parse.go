@@ -0,0 +1,265 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// BenefitImportFormat - формат файла импорта льгот
+type BenefitImportFormat string
+
+const (
+	BenefitImportFormatCSV  BenefitImportFormat = "csv"
+	BenefitImportFormatXLSX BenefitImportFormat = "xlsx"
+	BenefitImportFormatJSON BenefitImportFormat = "json"
+)
+
+func (f BenefitImportFormat) IsValid() bool {
+	switch f {
+	case BenefitImportFormatCSV, BenefitImportFormatXLSX, BenefitImportFormatJSON:
+		return true
+	}
+	return false
+}
+
+// BenefitImportFormatFromFileName определяет формат по расширению файла
+func BenefitImportFormatFromFileName(name string) BenefitImportFormat {
+	return BenefitImportFormat(strings.ToLower(strings.TrimPrefix(filepath.Ext(name), ".")))
+}
+
+// ParseBenefitImport читает файл импорта. CSV и XLSX: первая строка - названия колонок, как поля JSON
+// (title, target_groups, city, ...), списки target_groups, region и tags перечисляются через ";" или ",",
+// eligibility_rules - JSON объект. В XLSX читается первый лист. JSON - массив объектов.
+// Ошибка возвращается, если файл целиком не читается, ошибки значений попадают в строку
+func ParseBenefitImport(format BenefitImportFormat, r io.Reader) ([]BenefitImportRow, error) {
+	switch format {
+	case BenefitImportFormatCSV:
+		return parseBenefitImportCSV(r)
+	case BenefitImportFormatXLSX:
+		return parseBenefitImportXLSX(r)
+	case BenefitImportFormatJSON:
+		return parseBenefitImportJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %q. Valid values: csv, xlsx, json", format)
+	}
+}
+
+func parseBenefitImportJSON(r io.Reader) ([]BenefitImportRow, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("invalid json: expected array of benefits: %w", err)
+	}
+
+	rows := make([]BenefitImportRow, 0, len(items))
+	for i, item := range items {
+		row := BenefitImportRow{Line: i + 1}
+		if err := json.Unmarshal(item, &row.Record); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid benefit object: %s", err))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseBenefitImportCSV(r io.Reader) ([]BenefitImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	// Excel сохраняет CSV в UTF-8 с BOM
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+	return parseBenefitImportTable(records)
+}
+
+func parseBenefitImportXLSX(r io.Reader) ([]BenefitImportRow, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("invalid xlsx: no sheets")
+	}
+	// Без форматирования: даты приходят номером дня Excel, числа - без разделителей разрядов
+	records, err := file.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %w", err)
+	}
+
+	if len(records) > 0 {
+		header := records[0]
+		for i := 1; i < len(records); i++ {
+			for col, value := range records[i] {
+				if col >= len(header) {
+					break
+				}
+				field := strings.ToLower(strings.TrimSpace(header[col]))
+				if field == "valid_from" || field == "valid_to" {
+					records[i][col] = excelDateToISO(value)
+				}
+			}
+		}
+	}
+
+	return parseBenefitImportTable(records)
+}
+
+// excelDateToISO переводит дату из ячейки с форматом даты (номер дня Excel) в YYYY-MM-DD.
+// Остальные значения возвращаются как есть
+func excelDateToISO(value string) string {
+	serial, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return value
+	}
+	date, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return value
+	}
+	return date.Format("2006-01-02")
+}
+
+// benefitImportColumns - колонки таблицы импорта и запись значения ячейки в поле BenefitImportRecord
+var benefitImportColumns = map[string]func(record *BenefitImportRecord, value string) error{
+	"external_id":     func(rec *BenefitImportRecord, v string) error { rec.ExternalID = v; return nil },
+	"title":           func(rec *BenefitImportRecord, v string) error { rec.Title = v; return nil },
+	"description":     func(rec *BenefitImportRecord, v string) error { rec.Description = v; return nil },
+	"valid_from":      func(rec *BenefitImportRecord, v string) error { rec.ValidFrom = &v; return nil },
+	"valid_to":        func(rec *BenefitImportRecord, v string) error { rec.ValidTo = &v; return nil },
+	"type":            func(rec *BenefitImportRecord, v string) error { rec.Type = v; return nil },
+	"target_groups":   func(rec *BenefitImportRecord, v string) error { rec.TargetGroups = splitImportList(v); return nil },
+	"longitude":       func(rec *BenefitImportRecord, v string) error { return parseImportFloat(v, &rec.Longitude) },
+	"latitude":        func(rec *BenefitImportRecord, v string) error { return parseImportFloat(v, &rec.Latitude) },
+	"city_id":         func(rec *BenefitImportRecord, v string) error { rec.CityID = &v; return nil },
+	"city":            func(rec *BenefitImportRecord, v string) error { rec.City = &v; return nil },
+	"region":          parseImportRegion,
+	"category":        func(rec *BenefitImportRecord, v string) error { rec.Category = &v; return nil },
+	"requirement":     func(rec *BenefitImportRecord, v string) error { rec.Requirement = v; return nil },
+	"how_to_use":      func(rec *BenefitImportRecord, v string) error { rec.HowToUse = &v; return nil },
+	"source_url":      func(rec *BenefitImportRecord, v string) error { rec.SourceURL = v; return nil },
+	"tags":            func(rec *BenefitImportRecord, v string) error { rec.Tags = splitImportList(v); return nil },
+	"organization_id": func(rec *BenefitImportRecord, v string) error { rec.OrganizationID = &v; return nil },
+	"organization":    func(rec *BenefitImportRecord, v string) error { rec.Organization = &v; return nil },
+	"eligibility_rules": func(rec *BenefitImportRecord, v string) error {
+		var rules domain.EligibilityRules
+		if err := json.Unmarshal([]byte(v), &rules); err != nil {
+			return errors.New("must be a JSON object")
+		}
+		rec.EligibilityRules = &rules
+		return nil
+	},
+}
+
+// parseBenefitImportTable разбирает строки CSV или XLSX. Первая строка - заголовок, пустые строки пропускаются
+func parseBenefitImportTable(records [][]string) ([]BenefitImportRow, error) {
+	if len(records) == 0 {
+		return nil, errors.New("file is empty: header row is required")
+	}
+
+	header := make([]string, len(records[0]))
+	seen := make(map[string]bool, len(records[0]))
+	for i, column := range records[0] {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if _, ok := benefitImportColumns[column]; !ok {
+			return nil, fmt.Errorf("unknown column: %q", column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("duplicate column: %q", column)
+		}
+		seen[column] = true
+		header[i] = column
+	}
+	if !seen["external_id"] {
+		return nil, errors.New("column external_id is required")
+	}
+
+	rows := make([]BenefitImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if isEmptyImportRecord(record) {
+			continue
+		}
+
+		row := BenefitImportRow{Line: i + 2}
+		for col, value := range record {
+			if col >= len(header) || header[col] == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if !utf8.ValidString(value) {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s: invalid encoding, save the file as UTF-8", header[col]))
+				continue
+			}
+			if err := benefitImportColumns[header[col]](&row.Record, value); err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s: %s", header[col], err))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func isEmptyImportRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitImportList разбирает список, перечисленный через ";" или ","
+func splitImportList(value string) []string {
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' })
+	list := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			list = append(list, part)
+		}
+	}
+	return list
+}
+
+// parseImportFloat разбирает число, в том числе с запятой вместо точки, как его сохраняет русский Excel
+func parseImportFloat(value string, target **float64) error {
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil {
+		return errors.New("must be a number")
+	}
+	*target = &parsed
+	return nil
+}
+
+func parseImportRegion(record *BenefitImportRecord, value string) error {
+	for _, part := range splitImportList(value) {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("region id must be a number: %s", part)
+		}
+		record.Region = append(record.Region, id)
+	}
+	return nil
+}
@@ -24,7 +24,7 @@ type FilterStats = repository.FilterStats
 type BenefitService struct {
 	benefitRepository      repository.BenefitRepository
 	favoriteRepository     repository.FavoriteRepository
-	usersRepository        repository.Users
+	household              Household
 	organizationRepository repository.OrganizationRepository
 	gigachatClient         interface {
 		EnhanceSearchQuery(ctx context.Context, query string) ([]string, error)
@@ -34,7 +34,7 @@ type BenefitService struct {
 func newBenefitService(
 	benefitRepository repository.BenefitRepository,
 	favoriteRepository repository.FavoriteRepository,
-	userRepository repository.Users,
+	household Household,
 	organizationRepository repository.OrganizationRepository,
 	gigachatClient interface {
 		EnhanceSearchQuery(ctx context.Context, query string) ([]string, error)
@@ -43,7 +43,7 @@ func newBenefitService(
 	return &BenefitService{
 		benefitRepository:      benefitRepository,
 		favoriteRepository:     favoriteRepository,
-		usersRepository:        userRepository,
+		household:              household,
 		organizationRepository: organizationRepository,
 		gigachatClient:         gigachatClient,
 	}
@@ -275,6 +275,10 @@ func (s *BenefitService) GetByID(ctx context.Context, id string, userID *uuid.UU
 	if err != nil {
 		return nil, err
 	}
+	// Черновики, архив и льготы вне срока действия гражданам не показываются, редакторы смотрят их через GetByIDWithoutIncrement
+	if !benefit.IsVisibleToCitizens(time.Now()) {
+		return nil, domain.ErrNotFound
+	}
 
 	if benefit.OrganizationID != nil {
 		organization, err := s.organizationRepository.GetByID(ctx, benefit.OrganizationID.String())
@@ -289,11 +293,12 @@ func (s *BenefitService) GetByID(ctx context.Context, id string, userID *uuid.UU
 		benefit.Tags = domain.BenefitTagList{}
 	}
 
-	benefit.Views++
-	err = s.benefitRepository.Update(ctx, benefit)
-	if err != nil {
+	// Просмотры увеличиваются отдельным запросом: полное обновление перезаписало бы правки,
+	// сохраненные между чтением и записью, и создало бы лишнюю версию льготы
+	if err := s.benefitRepository.IncrementViews(ctx, benefit.ID); err != nil {
 		return nil, err
 	}
+	benefit.Views++
 
 	return benefit, nil
 }
@@ -419,29 +424,40 @@ func (s *BenefitService) GetFilterStats(ctx context.Context, filters *BenefitFil
 
 func (s *BenefitService) GetUserBenefitsStats(ctx context.Context, userID uuid.UUID) (*repository.UserBenefitsStats, error) {
 
-	user, err := s.usersRepository.GetOneByID(ctx, userID)
+	// Собираем подтвержденные группы пользователя и членов его семьи
+	householdGroups, err := s.household.GetHouseholdGroups(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-
-	// Собираем подтвержденные группы пользователя
-	targetGroups := []string{}
-	for _, group := range user.GroupType {
-		if group.Status == domain.VerificationStatusVerified {
-			targetGroups = append(targetGroups, string(group.Type))
-		}
-	}
+	targetGroups := householdGroups.Types()
 
 	logger.Info("Getting user benefits stats",
 		zap.String("user_id", userID.String()),
 		zap.Strings("target_groups", targetGroups))
 
-	// Считаем доступные льготы для групп пользователя (OR логика)
+	// Считаем доступные льготы для групп всей семьи (OR логика)
 	totalBenefits, err := s.benefitRepository.CountAvailableForUser(ctx, targetGroups)
 	if err != nil {
 		return nil, err
 	}
 
+	// Для каждого члена семьи с подтвержденными группами считаем, сколько льгот открывают именно его группы
+	members := make([]repository.UserBenefitsMemberStats, 0)
+	for _, holder := range householdGroups.Holders() {
+		groups := holder.Types()
+		count, err := s.benefitRepository.CountAvailableForUser(ctx, groups)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, repository.UserBenefitsMemberStats{
+			MemberID:      holder[0].MemberID,
+			Relation:      holder[0].Relation,
+			Name:          holder[0].Name,
+			Groups:        groups,
+			TotalBenefits: count,
+		})
+	}
+
 	// Считаем избранные льготы
 	favoritesCount, err := s.favoriteRepository.GetByUserCount(ctx, userID)
 	if err != nil {
@@ -451,6 +467,7 @@ func (s *BenefitService) GetUserBenefitsStats(ctx context.Context, userID uuid.U
 	return &repository.UserBenefitsStats{
 		TotalBenefits:  totalBenefits,
 		TotalFavorites: favoritesCount,
+		Members:        members,
 	}, nil
 }
 
@@ -513,20 +530,37 @@ func (s *BenefitService) GetBenefitTypesStats(ctx context.Context) (map[string]i
 	return stats.Levels, nil
 }
 
-func (s *BenefitService) Update(ctx context.Context, benefit *domain.Benefit) error {
+func (s *BenefitService) Update(ctx context.Context, benefit *domain.Benefit, author domain.BenefitAuthor) error {
 	benefit.UpdatedAt = time.Now()
 	// Убеждаемся, что теги не nil
 	if benefit.Tags == nil {
 		benefit.Tags = domain.BenefitTagList{}
 	}
-	return s.benefitRepository.Update(ctx, benefit)
+	// Публикация одобрена для прежнего текста: после правки без права публикации льгота ждет нового одобрения
+	benefit.ResetReviewAfterEdit(author)
+
+	revision, err := domain.NewBenefitRevision(benefit, domain.BenefitRevisionActionUpdate, author)
+	if err != nil {
+		return err
+	}
+	return s.benefitRepository.Update(ctx, benefit, revision)
 }
 
-func (s *BenefitService) Delete(ctx context.Context, id string) error {
-	return s.benefitRepository.Delete(ctx, id)
+// Delete удаляет льготу. В версии сохраняется ее последнее состояние, чтобы льготу можно было восстановить
+func (s *BenefitService) Delete(ctx context.Context, id string, author domain.BenefitAuthor) error {
+	benefit, err := s.benefitRepository.GetByID(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	revision, err := domain.NewBenefitRevision(benefit, domain.BenefitRevisionActionDelete, author)
+	if err != nil {
+		return err
+	}
+	return s.benefitRepository.Delete(ctx, id, revision)
 }
 
-func (s *BenefitService) Create(ctx context.Context, benefit *domain.Benefit) error {
+func (s *BenefitService) Create(ctx context.Context, benefit *domain.Benefit, author domain.BenefitAuthor) error {
 	if benefit.ID == uuid.Nil {
 		newID, err := uuid.NewV7()
 		if err != nil {
@@ -542,6 +576,184 @@ func (s *BenefitService) Create(ctx context.Context, benefit *domain.Benefit) er
 	if benefit.UpdatedAt.IsZero() {
 		benefit.UpdatedAt = now
 	}
+	// Новая льгота не видна гражданам, пока ее не отправят на проверку и не опубликуют
+	benefit.Status = domain.BenefitStatusDraft
+	benefit.PublishAt = nil
+	benefit.UnpublishAt = nil
+
+	revision, err := domain.NewBenefitRevision(benefit, domain.BenefitRevisionActionCreate, author)
+	if err != nil {
+		return err
+	}
+	return s.benefitRepository.Create(ctx, benefit, revision)
+}
+
+// BenefitStatusChange - новый статус публикации льготы и расписание
+type BenefitStatusChange struct {
+	Status domain.BenefitStatus
+	// PublishAt - только при публикации льготы на проверке: в будущем льгота останется на проверке
+	// и будет опубликована планировщиком
+	PublishAt *time.Time
+	// UnpublishAt - только при публикации: когда планировщик переведет льготу в архив
+	UnpublishAt *time.Time
+}
+
+// ChangeStatus переводит льготу в другой статус публикации. Публикация и расписание требуют
+// права публикации. Повторная публикация опубликованной льготы меняет только unpublish_at
+func (s *BenefitService) ChangeStatus(ctx context.Context, benefit *domain.Benefit, change BenefitStatusChange, author domain.BenefitAuthor) error {
+	if !change.Status.IsValid() {
+		return ErrInvalidBenefitStatus
+	}
+
+	from := benefit.Status
+	reschedule := from == domain.BenefitStatusPublished && change.Status == domain.BenefitStatusPublished
+	if !reschedule && !from.CanTransitionTo(change.Status) {
+		return ErrBenefitStatusTransition
+	}
+
+	hasSchedule := change.PublishAt != nil || change.UnpublishAt != nil
+	if (from.TransitionRequiresPublish(change.Status) || reschedule || hasSchedule) && !author.CanPublish {
+		return ErrBenefitPublishForbidden
+	}
+	if hasSchedule && change.Status != domain.BenefitStatusPublished {
+		return ErrInvalidBenefitSchedule
+	}
+
+	now := time.Now()
+	publishAt := change.PublishAt
+	if publishAt != nil && !publishAt.After(now) {
+		publishAt = nil
+	}
+	if publishAt != nil && from != domain.BenefitStatusInReview {
+		return ErrInvalidBenefitSchedule
+	}
+	if change.UnpublishAt != nil {
+		start := now
+		if publishAt != nil {
+			start = *publishAt
+		}
+		if !change.UnpublishAt.After(start) {
+			return ErrInvalidBenefitSchedule
+		}
+	}
+
+	benefit.Status = change.Status
+	benefit.PublishAt = nil
+	benefit.UnpublishAt = change.UnpublishAt
+	if publishAt != nil {
+		benefit.Status = domain.BenefitStatusInReview
+		benefit.PublishAt = publishAt
+	}
+	benefit.UpdatedAt = now
+
+	if err := s.benefitRepository.UpdateStatus(ctx, benefit, from); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrBenefitStatusChanged
+		}
+		return fmt.Errorf("update benefit status failed: %w", err)
+	}
+
+	return nil
+}
 
-	return s.benefitRepository.Create(ctx, benefit)
+// PublishScheduled публикует одобренные льготы, у которых наступило время публикации
+func (s *BenefitService) PublishScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return s.benefitRepository.PublishScheduled(ctx, now)
+}
+
+// ArchiveScheduled переводит в архив льготы, у которых наступило время снятия с публикации
+func (s *BenefitService) ArchiveScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return s.benefitRepository.ArchiveScheduled(ctx, now)
+}
+
+// ArchiveExpired переводит в архив опубликованные льготы, срок действия которых закончился
+func (s *BenefitService) ArchiveExpired(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return s.benefitRepository.ArchiveExpired(ctx, now)
+}
+
+// GetEndingNotices возвращает уведомления для пользователей, у которых в избранном есть льготы,
+// срок действия которых заканчивается в ближайшие before
+func (s *BenefitService) GetEndingNotices(ctx context.Context, now time.Time, before time.Duration) ([]domain.BenefitEndingNotice, error) {
+	return s.favoriteRepository.GetBenefitEndingNotices(ctx, domain.BenefitExpiryBoundary(now), now.Add(before))
+}
+
+// GetRevisions возвращает историю изменений льготы, в том числе удаленной
+func (s *BenefitService) GetRevisions(ctx context.Context, benefitID uuid.UUID) (*domain.Benefit, []domain.BenefitRevision, error) {
+	benefit, err := s.benefitRepository.GetByIDWithDeleted(ctx, benefitID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, ErrBenefitNotFound
+		}
+		return nil, nil, fmt.Errorf("get benefit failed: %w", err)
+	}
+
+	revisions, err := s.benefitRepository.GetRevisions(ctx, benefitID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get benefit revisions failed: %w", err)
+	}
+
+	return benefit, revisions, nil
+}
+
+// DiffRevisions возвращает поля, которые отличаются в версиях from и to
+func (s *BenefitService) DiffRevisions(ctx context.Context, benefitID uuid.UUID, from, to int) ([]domain.BenefitFieldChange, error) {
+	fromRevision, err := s.getRevision(ctx, benefitID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.getRevision(ctx, benefitID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.DiffBenefitSnapshots(fromRevision.Snapshot, toRevision.Snapshot)
+}
+
+// RestoreRevision возвращает льготе поля из версии version. Удаленная льгота восстанавливается.
+// Восстановление сохраняется новой версией, история не переписывается
+func (s *BenefitService) RestoreRevision(ctx context.Context, benefitID uuid.UUID, version int, author domain.BenefitAuthor) (*domain.Benefit, *domain.BenefitRevision, error) {
+	benefit, err := s.benefitRepository.GetByIDWithDeleted(ctx, benefitID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, ErrBenefitNotFound
+		}
+		return nil, nil, fmt.Errorf("get benefit failed: %w", err)
+	}
+
+	source, err := s.getRevision(ctx, benefitID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source.Snapshot.ApplyTo(benefit)
+	benefit.DeletedAt = nil
+	benefit.UpdatedAt = time.Now()
+	if benefit.Tags == nil {
+		benefit.Tags = domain.BenefitTagList{}
+	}
+	// Восстановленная версия проходит проверку так же, как правка
+	benefit.ResetReviewAfterEdit(author)
+
+	revision, err := domain.NewBenefitRevision(benefit, domain.BenefitRevisionActionRestore, author)
+	if err != nil {
+		return nil, nil, err
+	}
+	revision.RestoredFrom = &source.Version
+
+	if err := s.benefitRepository.Update(ctx, benefit, revision); err != nil {
+		return nil, nil, fmt.Errorf("restore benefit revision failed: %w", err)
+	}
+
+	return benefit, revision, nil
+}
+
+func (s *BenefitService) getRevision(ctx context.Context, benefitID uuid.UUID, version int) (*domain.BenefitRevision, error) {
+	revision, err := s.benefitRepository.GetRevision(ctx, benefitID, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrBenefitRevisionNotFound
+		}
+		return nil, fmt.Errorf("get benefit revision failed: %w", err)
+	}
+	return revision, nil
 }
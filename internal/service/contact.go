@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/queue/client"
+	"github.com/vibe-gaming/backend/internal/queue/task"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/email"
+	"github.com/vibe-gaming/backend/pkg/otp"
+)
+
+const (
+	// contactChangeKeyPrefix - hash с новым контактом, хешем кода и числом неверных попыток
+	contactChangeKeyPrefix = "contact:change:"
+	// contactChangeCooldownKeyPrefix - ключ живет ResendInterval после отправки кода
+	contactChangeCooldownKeyPrefix = "contact:change:cooldown:"
+	// contactChangeLimitKeyPrefix - счетчик отправленных кодов за час
+	contactChangeLimitKeyPrefix = "contact:change:limit:"
+	contactChangeLimitWindow    = time.Hour
+)
+
+var contactPhoneRegex = regexp.MustCompile(`^7\d{10}$`)
+
+// ContactChangeChallenge - отправленный код подтверждения нового контакта
+type ContactChangeChallenge struct {
+	ExpiresAt   time.Time
+	ResendAfter time.Time
+}
+
+type contactService struct {
+	userRepository repository.Users
+	otpGenerator   otp.Generator
+	redis          redis.UniversalClient
+	config         config.ContactChangeConfig
+	codeLength     int
+}
+
+func newContactService(userRepository repository.Users,
+	otpGenerator otp.Generator,
+	redis redis.UniversalClient,
+	config config.ContactChangeConfig,
+	codeLength int,
+) *contactService {
+	return &contactService{
+		userRepository: userRepository,
+		otpGenerator:   otpGenerator,
+		redis:          redis,
+		config:         config,
+		codeLength:     codeLength,
+	}
+}
+
+// RequestChange отправляет код подтверждения на новый email или телефон. Повторно код можно запросить
+// не раньше ResendInterval и не больше MaxCodesPerHour раз за час. Новый код заменяет отправленный ранее
+func (s *contactService) RequestChange(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, value string) (*ContactChangeChallenge, error) {
+	value, err := normalizeContact(contactType, value)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+	if current := userContact(user, contactType); current.Valid && current.String == value {
+		return nil, ErrContactNotChanged
+	}
+
+	keySuffix := userID.String() + ":" + string(contactType)
+
+	ok, err := s.redis.SetNX(ctx, contactChangeCooldownKeyPrefix+keySuffix, 1, s.config.ResendInterval).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis set contact change cooldown failed: %w", err)
+	}
+	if !ok {
+		return nil, ErrContactCodeResendTooEarly
+	}
+
+	sent, err := s.redis.Incr(ctx, contactChangeLimitKeyPrefix+keySuffix).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis incr contact change limit failed: %w", err)
+	}
+	if sent == 1 {
+		if err := s.redis.Expire(ctx, contactChangeLimitKeyPrefix+keySuffix, contactChangeLimitWindow).Err(); err != nil {
+			return nil, fmt.Errorf("redis expire contact change limit failed: %w", err)
+		}
+	}
+	if sent > int64(s.config.MaxCodesPerHour) {
+		return nil, ErrContactCodeRateLimited
+	}
+
+	code, err := s.otpGenerator.RandomCode(s.codeLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate contact code failed: %w", err)
+	}
+
+	key := contactChangeKeyPrefix + keySuffix
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, "value", value, "code_hash", hashContactCode(code), "attempts", 0)
+		pipe.Expire(ctx, key, s.config.CodeTTL)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redis save contact change failed: %w", err)
+	}
+
+	if err := s.enqueueCode(ctx, contactType, value, code); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ContactChangeChallenge{
+		ExpiresAt:   now.Add(s.config.CodeTTL),
+		ResendAfter: now.Add(s.config.ResendInterval),
+	}, nil
+}
+
+// ConfirmChange сохраняет новый контакт, если код верный. После MaxAttempts неверных кодов
+// код сбрасывается и нужно запросить новый
+func (s *contactService) ConfirmChange(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, code string) (*domain.User, error) {
+	key := contactChangeKeyPrefix + userID.String() + ":" + string(contactType)
+
+	challenge, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get contact change failed: %w", err)
+	}
+	value := challenge["value"]
+	if value == "" {
+		// Ключ мог истечь между чтением и увеличением счетчика попыток, тогда от него остается только счетчик
+		if len(challenge) > 0 {
+			if err := s.redis.Del(ctx, key).Err(); err != nil {
+				return nil, fmt.Errorf("redis delete contact change failed: %w", err)
+			}
+		}
+		return nil, ErrContactCodeNotFound
+	}
+
+	// Попытка учитывается до сравнения кода, чтобы параллельные запросы не обходили лимит
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis incr contact change attempts failed: %w", err)
+	}
+	if attempts > int64(s.config.MaxAttempts) {
+		if err := s.redis.Del(ctx, key).Err(); err != nil {
+			return nil, fmt.Errorf("redis delete contact change failed: %w", err)
+		}
+		return nil, ErrContactCodeAttemptsExceeded
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashContactCode(code)), []byte(challenge["code_hash"])) != 1 {
+		if attempts == int64(s.config.MaxAttempts) {
+			if err := s.redis.Del(ctx, key).Err(); err != nil {
+				return nil, fmt.Errorf("redis delete contact change failed: %w", err)
+			}
+			return nil, ErrContactCodeAttemptsExceeded
+		}
+		return nil, ErrInvalidContactCode
+	}
+
+	// Код одноразовый: удаляем его до сохранения, чтобы повторный запрос с тем же кодом не прошел
+	deleted, err := s.redis.Del(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis delete contact change failed: %w", err)
+	}
+	if deleted == 0 {
+		return nil, ErrContactCodeNotFound
+	}
+
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	changeID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate profile change id failed: %w", err)
+	}
+	change := domain.UserProfileChange{
+		ID:       changeID,
+		UserID:   userID,
+		Field:    contactType.ProfileField(),
+		OldValue: userContact(user, contactType),
+		NewValue: sql.NullString{String: value, Valid: true},
+	}
+	if err := s.userRepository.UpdateContact(ctx, userID, contactType, value, change); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if contactType == domain.ContactTypePhone {
+		user.PhoneNumber = change.NewValue
+		user.PhoneConfirmedAt = &now
+	} else {
+		user.Email = change.NewValue
+		user.EmailConfirmedAt = &now
+	}
+
+	return user, nil
+}
+
+// enqueueCode ставит отправку кода в очередь: email через шаблон письма с кодом, телефон через SMS
+func (s *contactService) enqueueCode(ctx context.Context, contactType domain.ContactType, value string, code string) error {
+	asynqClient := client.GetClient(ctx)
+	if asynqClient == nil {
+		return errors.New("task queue client is not configured")
+	}
+
+	var (
+		sendTask *asynq.Task
+		err      error
+	)
+	if contactType == domain.ContactTypePhone {
+		sendTask, err = task.NewSendSMSTask(value, code)
+	} else {
+		sendTask, err = task.NewSendEmailTask(value, code)
+	}
+	if err != nil {
+		return fmt.Errorf("create send contact code task failed: %w", err)
+	}
+
+	// Код бесполезен после истечения, поэтому задача не выполняется позже
+	if _, err := asynqClient.EnqueueContext(ctx, sendTask, asynq.Deadline(time.Now().Add(s.config.CodeTTL))); err != nil {
+		return fmt.Errorf("enqueue send contact code task failed: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeContact приводит email к нижнему регистру, из телефона убирает все, кроме цифр, и проверяет формат
+func normalizeContact(contactType domain.ContactType, value string) (string, error) {
+	switch contactType {
+	case domain.ContactTypeEmail:
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !email.IsEmailValid(value) {
+			return "", ErrInvalidContactValue
+		}
+	case domain.ContactTypePhone:
+		value = strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, value)
+		if !contactPhoneRegex.MatchString(value) {
+			return "", ErrInvalidContactValue
+		}
+	default:
+		return "", ErrInvalidContactValue
+	}
+
+	return value, nil
+}
+
+func userContact(user *domain.User, contactType domain.ContactType) sql.NullString {
+	if contactType == domain.ContactTypePhone {
+		return user.PhoneNumber
+	}
+	return user.Email
+}
+
+// hashContactCode хеширует код, чтобы в Redis не лежали действующие коды
+func hashContactCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+
+	return hex.EncodeToString(sum[:])
+}
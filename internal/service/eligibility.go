@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+)
+
+type eligibilityService struct {
+	benefitRepository repository.BenefitRepository
+	userRepository    repository.Users
+	cityRepository    repository.Cities
+	household         Household
+}
+
+func newEligibilityService(
+	benefitRepository repository.BenefitRepository,
+	userRepository repository.Users,
+	cityRepository repository.Cities,
+	household Household,
+) *eligibilityService {
+	return &eligibilityService{
+		benefitRepository: benefitRepository,
+		userRepository:    userRepository,
+		cityRepository:    cityRepository,
+		household:         household,
+	}
+}
+
+// eligibilityProfile - данные пользователя и его семьи, по которым проверяются условия льгот
+type eligibilityProfile struct {
+	user *domain.User
+	// city - город проживания, nil если пользователь его не выбрал
+	city *domain.City
+	// groups - подтвержденные группы семьи, pendingGroups - группы семьи, ожидающие проверки
+	groups        domain.HouseholdGroups
+	pendingGroups []domain.GroupType
+	// members - члены семьи по ID, по ним проверяются личные условия, если право дает группа члена семьи
+	members map[uuid.UUID]*domain.HouseholdMember
+}
+
+// eligibilityPerson - человек, по которому проверяются личные условия льготы: возраст и инвалидность
+type eligibilityPerson struct {
+	// member - член семьи, nil для самого пользователя
+	member     *domain.HouseholdMember
+	birthDate  *time.Time
+	disability *domain.DisabilityCategory
+}
+
+func newUserEligibilityPerson(user *domain.User) eligibilityPerson {
+	return eligibilityPerson{
+		birthDate:  user.BirthDate,
+		disability: user.DisabilityCategory,
+	}
+}
+
+// newMemberEligibilityPerson - категория инвалидности членов семьи не хранится, поэтому для условия
+// об инвалидности у члена семьи всегда не хватает данных
+func newMemberEligibilityPerson(member *domain.HouseholdMember) eligibilityPerson {
+	return eligibilityPerson{
+		member:    member,
+		birthDate: member.BirthDate,
+	}
+}
+
+// whose - чей возраст или категория упоминается в пояснении
+func (p eligibilityPerson) whose() string {
+	if p.member == nil {
+		return "ваш"
+	}
+	return fmt.Sprintf("у члена семьи (%s, %s)", p.member.Relation.Title(), p.member.FullName())
+}
+
+// CheckBenefit проверяет право пользователя на одну льготу
+func (s *eligibilityService) CheckBenefit(ctx context.Context, userID uuid.UUID, benefitID string) (*domain.EligibilityResult, error) {
+	benefit, err := s.benefitRepository.GetByID(ctx, benefitID, nil)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !benefit.IsVisibleToCitizens(now) {
+		return nil, domain.ErrNotFound
+	}
+
+	profile, err := s.getProfile(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := evaluateEligibility(benefit, profile, now)
+	return &result, nil
+}
+
+// CheckBenefits проверяет право пользователя на страницу списка льгот
+func (s *eligibilityService) CheckBenefits(ctx context.Context, userID uuid.UUID, page, limit int) ([]domain.EligibilityResult, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	now := time.Now()
+	profile, err := s.getProfile(ctx, userID, now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	benefits, err := s.benefitRepository.GetAll(ctx, limit, (page-1)*limit, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.benefitRepository.Count(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]domain.EligibilityResult, 0, len(benefits))
+	for _, benefit := range benefits {
+		results = append(results, evaluateEligibility(benefit, profile, now))
+	}
+
+	return results, total, nil
+}
+
+func (s *eligibilityService) getProfile(ctx context.Context, userID uuid.UUID, now time.Time) (*eligibilityProfile, error) {
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	members, err := s.household.GetMembers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &eligibilityProfile{
+		user:    user,
+		groups:  domain.NewHouseholdGroups(user, members, now),
+		members: make(map[uuid.UUID]*domain.HouseholdMember, len(members)),
+	}
+	for i := range members {
+		profile.members[members[i].ID] = &members[i]
+	}
+
+	groupLists := []domain.UserGroupList{user.GroupType}
+	for i := range members {
+		groupLists = append(groupLists, members[i].GroupType)
+	}
+	for _, groups := range groupLists {
+		for _, group := range groups {
+			if group.Status == domain.VerificationStatusPending {
+				profile.pendingGroups = append(profile.pendingGroups, group.Type)
+			}
+		}
+	}
+
+	if user.CityID != nil {
+		city, err := s.cityRepository.GetOneByID(ctx, *user.CityID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("get city by id failed: %w", err)
+		}
+		profile.city = city
+	}
+
+	return profile, nil
+}
+
+// evaluateEligibility проверяет условия льготы по профилю. Проверяются только заданные в льготе условия,
+// у льготы без условий результат eligible. Личные условия (возраст, инвалидность) проверяются по тому члену семьи,
+// чья группа дает право на льготу, семейные (доход, дети) - по профилю пользователя
+func evaluateEligibility(benefit *domain.Benefit, profile *eligibilityProfile, now time.Time) domain.EligibilityResult {
+	var checks []domain.EligibilityCheck
+
+	rules := benefit.EligibilityRules
+	person := newUserEligibilityPerson(profile.user)
+
+	if len(benefit.TargetGroupIDs) > 0 {
+		var holder domain.HouseholdGroups
+		holder, person = qualifyingHolder(benefit.TargetGroupIDs, rules, profile, now)
+		checks = append(checks, checkTargetGroups(benefit.TargetGroupIDs, holder, profile))
+	}
+
+	if rules != nil {
+		if rules.MinAge != nil || rules.MaxAge != nil {
+			checks = append(checks, checkAge(rules, person, now))
+		}
+		if len(rules.CityIDs) > 0 || len(rules.RegionIDs) > 0 {
+			checks = append(checks, checkResidence(rules, profile.city))
+		}
+		if rules.MaxMonthlyIncome != nil {
+			checks = append(checks, checkIncome(*rules.MaxMonthlyIncome, profile.user))
+		}
+		if rules.MinChildren != nil {
+			checks = append(checks, checkChildren(*rules.MinChildren, profile.user))
+		}
+		if len(rules.DisabilityCategories) > 0 {
+			checks = append(checks, checkDisability(rules.DisabilityCategories, person))
+		}
+	}
+
+	return domain.NewEligibilityResult(benefit, checks)
+}
+
+// qualifyingHolder выбирает, чья подтвержденная группа дает право на льготу и по кому проверять личные условия.
+// Из нескольких членов семьи с подходящей группой берется первый, кто проходит личные условия, затем тот,
+// у кого для них не хватает данных. Если подходящей группы нет ни у кого, личные условия проверяются по пользователю
+func qualifyingHolder(targetGroups domain.TargetGroupList, rules *domain.EligibilityRules, profile *eligibilityProfile,
+	now time.Time,
+) (domain.HouseholdGroups, eligibilityPerson) {
+	holders := profile.groups.Qualifying(targetGroups).Holders()
+	if len(holders) == 0 {
+		return nil, newUserEligibilityPerson(profile.user)
+	}
+
+	var (
+		best       domain.HouseholdGroups
+		bestPerson eligibilityPerson
+		bestStatus domain.EligibilityStatus
+	)
+	for _, holder := range holders {
+		person := newUserEligibilityPerson(profile.user)
+		if memberID := holder[0].MemberID; memberID != nil {
+			member, ok := profile.members[*memberID]
+			if !ok {
+				continue
+			}
+			person = newMemberEligibilityPerson(member)
+		}
+
+		status := domain.EligibilityChecksStatus(personalChecks(rules, person, now))
+		if status == domain.EligibilityStatusEligible {
+			return holder, person
+		}
+		if best == nil || status == domain.EligibilityStatusMissingData && bestStatus == domain.EligibilityStatusNotEligible {
+			best, bestPerson, bestStatus = holder, person, status
+		}
+	}
+	if best == nil {
+		return nil, newUserEligibilityPerson(profile.user)
+	}
+
+	return best, bestPerson
+}
+
+// personalChecks - условия льготы, которые относятся к конкретному человеку, а не к семье
+func personalChecks(rules *domain.EligibilityRules, person eligibilityPerson, now time.Time) []domain.EligibilityCheck {
+	if rules == nil {
+		return nil
+	}
+
+	var checks []domain.EligibilityCheck
+	if rules.MinAge != nil || rules.MaxAge != nil {
+		checks = append(checks, checkAge(rules, person, now))
+	}
+	if len(rules.DisabilityCategories) > 0 {
+		checks = append(checks, checkDisability(rules.DisabilityCategories, person))
+	}
+	return checks
+}
+
+// checkTargetGroups - holder - подтвержденные подходящие группы одного члена семьи, nil если их нет
+func checkTargetGroups(targetGroups domain.TargetGroupList, holder domain.HouseholdGroups, profile *eligibilityProfile) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionTargetGroup}
+
+	if len(holder) > 0 {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Подтверждена группа «%s»: %s", holder[0].GroupType.Title(), holder[0].Name)
+		return check
+	}
+
+	titles := make([]string, 0, len(targetGroups))
+	for _, target := range targetGroups {
+		groupType := domain.GroupType(target)
+		if slices.Contains(profile.pendingGroups, groupType) {
+			check.Status = domain.EligibilityStatusMissingData
+			check.Reason = fmt.Sprintf("Группа «%s» ожидает подтверждения", groupType.Title())
+			return check
+		}
+		titles = append(titles, groupType.Title())
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Льгота предназначена для групп: %s. Ни у вас, ни у членов семьи нет подтвержденной группы из этого списка",
+		strings.Join(titles, ", "))
+	return check
+}
+
+func checkAge(rules *domain.EligibilityRules, person eligibilityPerson, now time.Time) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionAge}
+
+	var limits []string
+	if rules.MinAge != nil {
+		limits = append(limits, fmt.Sprintf("от %d", *rules.MinAge))
+	}
+	if rules.MaxAge != nil {
+		limits = append(limits, fmt.Sprintf("до %d", *rules.MaxAge))
+	}
+	required := strings.Join(limits, " ") + " лет"
+
+	if person.birthDate == nil {
+		check.Status = domain.EligibilityStatusMissingData
+		if person.member != nil {
+			check.Reason = fmt.Sprintf("Льгота для граждан в возрасте %s. Дата рождения %s не указана", required, person.whose())
+			return check
+		}
+		check.Reason = fmt.Sprintf("Льгота для граждан в возрасте %s. Дата рождения не указана, она обновляется из Госуслуг при входе", required)
+		return check
+	}
+
+	age := ageAt(*person.birthDate, now)
+	if (rules.MinAge == nil || age >= *rules.MinAge) && (rules.MaxAge == nil || age <= *rules.MaxAge) {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Возраст %s, %s возраст: %d", required, person.whose(), age)
+		return check
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Льгота для граждан в возрасте %s, %s возраст: %d", required, person.whose(), age)
+	return check
+}
+
+func checkResidence(rules *domain.EligibilityRules, city *domain.City) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionResidence}
+
+	if city == nil {
+		check.Status = domain.EligibilityStatusMissingData
+		check.Reason = "Льгота действует только в отдельных городах или регионах. Укажите город проживания в профиле"
+		return check
+	}
+
+	if slices.Contains(rules.CityIDs, city.ID) || slices.Contains(rules.RegionIDs, city.RegionID) {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Льгота действует в вашем городе: %s", city.Name)
+		return check
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Льгота не действует в вашем городе: %s", city.Name)
+	return check
+}
+
+func checkIncome(maxIncome int64, user *domain.User) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionIncome}
+
+	if user.MonthlyIncome == nil {
+		check.Status = domain.EligibilityStatusMissingData
+		check.Reason = fmt.Sprintf("Льгота для семей со среднедушевым доходом до %d ₽ в месяц. Укажите доход в профиле", maxIncome)
+		return check
+	}
+
+	if *user.MonthlyIncome <= maxIncome {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Среднедушевой доход %d ₽ не превышает %d ₽ в месяц", *user.MonthlyIncome, maxIncome)
+		return check
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Среднедушевой доход %d ₽ превышает предел %d ₽ в месяц", *user.MonthlyIncome, maxIncome)
+	return check
+}
+
+func checkChildren(minChildren int, user *domain.User) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionChildren}
+
+	if user.ChildrenCount == nil {
+		check.Status = domain.EligibilityStatusMissingData
+		check.Reason = fmt.Sprintf("Льгота для семей, где детей не меньше %d. Укажите количество детей в профиле", minChildren)
+		return check
+	}
+
+	if *user.ChildrenCount >= minChildren {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Детей в семье: %d, требуется не меньше %d", *user.ChildrenCount, minChildren)
+		return check
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Льгота для семей, где детей не меньше %d, у вас указано: %d", minChildren, *user.ChildrenCount)
+	return check
+}
+
+func checkDisability(categories []domain.DisabilityCategory, person eligibilityPerson) domain.EligibilityCheck {
+	check := domain.EligibilityCheck{Criterion: domain.EligibilityCriterionDisability}
+
+	titles := make([]string, 0, len(categories))
+	for _, category := range categories {
+		titles = append(titles, category.Title())
+	}
+	required := strings.Join(titles, ", ")
+
+	if person.disability == nil {
+		check.Status = domain.EligibilityStatusMissingData
+		if person.member != nil {
+			check.Reason = fmt.Sprintf("Льгота для категорий инвалидности: %s. Категория инвалидности %s не известна", required, person.whose())
+			return check
+		}
+		check.Reason = fmt.Sprintf("Льгота для категорий инвалидности: %s. Укажите категорию инвалидности в профиле", required)
+		return check
+	}
+
+	if slices.Contains(categories, *person.disability) {
+		check.Status = domain.EligibilityStatusEligible
+		check.Reason = fmt.Sprintf("Категория инвалидности подходит: %s", person.disability.Title())
+		return check
+	}
+
+	check.Status = domain.EligibilityStatusNotEligible
+	check.Reason = fmt.Sprintf("Льгота для категорий инвалидности: %s, у вас указано: %s", required, person.disability.Title())
+	return check
+}
+
+// ageAt - полных лет на дату now
+func ageAt(birthDate time.Time, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || now.Month() == birthDate.Month() && now.Day() < birthDate.Day() {
+		age--
+	}
+	return age
+}
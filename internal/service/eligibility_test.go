@@ -0,0 +1,187 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+)
+
+func TestEvaluateEligibility(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	bornYearsAgo := func(years int) *time.Time {
+		value := now.AddDate(-years, 0, -1)
+		return &value
+	}
+	intPtr := func(v int) *int { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+	expiresAt := now.Add(24 * time.Hour)
+	verified := func(groups ...domain.GroupType) domain.UserGroupList {
+		list := make(domain.UserGroupList, 0, len(groups))
+		for _, group := range groups {
+			list = append(list, domain.UserGroup{Type: group, Status: domain.VerificationStatusVerified, ExpiresAt: &expiresAt})
+		}
+		return list
+	}
+	groupOne := domain.DisabilityCategoryGroup1
+
+	newUser := func(age int, groups domain.UserGroupList) *domain.User {
+		return &domain.User{
+			FirstName: sql.NullString{String: "Иван", Valid: true},
+			BirthDate: bornYearsAgo(age),
+			GroupType: groups,
+		}
+	}
+	newChild := func(birthDate *time.Time, status domain.HouseholdRelationStatus, groups domain.UserGroupList) domain.HouseholdMember {
+		return domain.HouseholdMember{
+			ID:             uuid.New(),
+			Relation:       domain.HouseholdRelationChild,
+			RelationStatus: status,
+			FirstName:      "Петр",
+			BirthDate:      birthDate,
+			GroupType:      groups,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		benefit    *domain.Benefit
+		user       *domain.User
+		members    []domain.HouseholdMember
+		wantStatus domain.EligibilityStatus
+		// wantChecks - статусы условий в порядке проверки
+		wantChecks []domain.EligibilityStatus
+	}{
+		{
+			name:       "without conditions",
+			benefit:    &domain.Benefit{},
+			user:       newUser(40, nil),
+			wantStatus: domain.EligibilityStatusEligible,
+		},
+		{
+			name: "age checked against disabled child",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Disabled},
+				EligibilityRules: &domain.EligibilityRules{MaxAge: intPtr(17)},
+			},
+			user:       newUser(40, nil),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(10), domain.HouseholdRelationStatusVerified, verified(domain.UserGroupDisabled))},
+			wantStatus: domain.EligibilityStatusEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusEligible},
+		},
+		{
+			name: "holder passing personal conditions is preferred",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Disabled},
+				EligibilityRules: &domain.EligibilityRules{MaxAge: intPtr(17)},
+			},
+			user:       newUser(40, verified(domain.UserGroupDisabled)),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(10), domain.HouseholdRelationStatusVerified, verified(domain.UserGroupDisabled))},
+			wantStatus: domain.EligibilityStatusEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusEligible},
+		},
+		{
+			name: "user age does not count for child benefit",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Pensioners},
+				EligibilityRules: &domain.EligibilityRules{MinAge: intPtr(60)},
+			},
+			user:       newUser(70, nil),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(30), domain.HouseholdRelationStatusVerified, verified(domain.UserGroupPensioners))},
+			wantStatus: domain.EligibilityStatusNotEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusNotEligible},
+		},
+		{
+			name: "user disability category does not count for child",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Disabled},
+				EligibilityRules: &domain.EligibilityRules{DisabilityCategories: []domain.DisabilityCategory{groupOne}},
+			},
+			user: func() *domain.User {
+				user := newUser(40, nil)
+				user.DisabilityCategory = &groupOne
+				return user
+			}(),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(10), domain.HouseholdRelationStatusVerified, verified(domain.UserGroupDisabled))},
+			wantStatus: domain.EligibilityStatusMissingData,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusMissingData},
+		},
+		{
+			name: "child without birth date",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Children},
+				EligibilityRules: &domain.EligibilityRules{MaxAge: intPtr(17)},
+			},
+			user:       newUser(40, nil),
+			members:    []domain.HouseholdMember{newChild(nil, domain.HouseholdRelationStatusVerified, verified(domain.UserGroupChildren))},
+			wantStatus: domain.EligibilityStatusMissingData,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusMissingData},
+		},
+		{
+			name: "family conditions checked against user",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Children},
+				EligibilityRules: &domain.EligibilityRules{MaxMonthlyIncome: int64Ptr(20000), MinChildren: intPtr(1)},
+			},
+			user: func() *domain.User {
+				user := newUser(40, nil)
+				user.MonthlyIncome = int64Ptr(15000)
+				user.ChildrenCount = intPtr(1)
+				return user
+			}(),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(10), domain.HouseholdRelationStatusVerified, verified(domain.UserGroupChildren))},
+			wantStatus: domain.EligibilityStatusEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusEligible, domain.EligibilityStatusEligible, domain.EligibilityStatusEligible},
+		},
+		{
+			name: "member without confirmed relation",
+			benefit: &domain.Benefit{
+				TargetGroupIDs: domain.TargetGroupList{domain.Disabled},
+			},
+			user:       newUser(40, nil),
+			members:    []domain.HouseholdMember{newChild(bornYearsAgo(10), domain.HouseholdRelationStatusPending, verified(domain.UserGroupDisabled))},
+			wantStatus: domain.EligibilityStatusNotEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusNotEligible},
+		},
+		{
+			name: "no qualifying group, age checked against user",
+			benefit: &domain.Benefit{
+				TargetGroupIDs:   domain.TargetGroupList{domain.Veterans},
+				EligibilityRules: &domain.EligibilityRules{MinAge: intPtr(18)},
+			},
+			user:       newUser(40, nil),
+			wantStatus: domain.EligibilityStatusNotEligible,
+			wantChecks: []domain.EligibilityStatus{domain.EligibilityStatusNotEligible, domain.EligibilityStatusEligible},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &eligibilityProfile{
+				user:    tt.user,
+				groups:  domain.NewHouseholdGroups(tt.user, tt.members, now),
+				members: make(map[uuid.UUID]*domain.HouseholdMember, len(tt.members)),
+			}
+			for i := range tt.members {
+				profile.members[tt.members[i].ID] = &tt.members[i]
+			}
+
+			result := evaluateEligibility(tt.benefit, profile, now)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s, checks %+v", result.Status, tt.wantStatus, result.Checks)
+			}
+			if len(result.Checks) != len(tt.wantChecks) {
+				t.Fatalf("checks = %+v, want %d checks", result.Checks, len(tt.wantChecks))
+			}
+			for i, want := range tt.wantChecks {
+				if result.Checks[i].Status != want {
+					t.Errorf("check %s status = %s, want %s: %s",
+						result.Checks[i].Criterion, result.Checks[i].Status, want, result.Checks[i].Reason)
+				}
+			}
+		})
+	}
+}
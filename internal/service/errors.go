@@ -6,6 +6,83 @@ var (
 	ErrUserAlreadyExist         = errors.New("user already exist")
 	ErrUserNotFound             = errors.New("user not found")
 	ErrVerificationCodeNotFound = errors.New("verification code not found")
+	ErrUserDeactivated          = errors.New("user is deactivated")
+	ErrUserAlreadyDeactivated   = errors.New("user already deactivated")
+	ErrUserNotDeactivated       = errors.New("user is not deactivated")
 
 	ErrCityNotFound = errors.New("city not found")
+
+	ErrRefreshTokenNotFound   = errors.New("refresh token not found")
+	ErrRefreshTokenExpired    = errors.New("refresh token expired")
+	ErrRefreshTokenReused     = errors.New("refresh token reused")
+	ErrRefreshSessionMismatch = errors.New("refresh session client mismatch")
+	ErrSessionNotFound        = errors.New("session not found")
+
+	ErrInvalidRole              = errors.New("invalid role")
+	ErrRoleOrganizationMismatch = errors.New("organization is required for organization scoped roles only")
+	ErrRoleAlreadyGranted       = errors.New("role already granted")
+	ErrRoleNotFound             = errors.New("role not found")
+	ErrOrganizationNotFound     = errors.New("organization not found")
+
+	ErrStaffNotFound             = errors.New("staff account not found")
+	ErrStaffLoginTaken           = errors.New("staff login already taken")
+	ErrStaffInvalidCredentials   = errors.New("invalid login or password")
+	ErrStaffAccountLocked        = errors.New("staff account temporarily locked")
+	ErrStaffMFAChallengeNotFound = errors.New("mfa challenge not found or expired")
+	ErrStaffInvalidTOTPCode      = errors.New("invalid totp code")
+	ErrStaffInvalidRecoveryCode  = errors.New("invalid recovery code")
+	ErrStaffTOTPAlreadyEnabled   = errors.New("totp already enabled")
+	ErrStaffTOTPNotEnrolled      = errors.New("totp is not enrolled")
+
+	ErrInvalidAPIKey         = errors.New("api key is invalid, expired or revoked")
+	ErrInvalidAPIKeyScope    = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiry   = errors.New("api key expiration must be in the future")
+	ErrPartnerAPIKeyNotFound = errors.New("partner api key not found")
+
+	ErrVerificationDocumentNotFound = errors.New("verification document not found")
+	ErrVerificationDocumentReviewed = errors.New("verification document already reviewed")
+	ErrGroupNotAwaitingVerification = errors.New("user group is not awaiting verification")
+	ErrInvalidGroupType             = errors.New("invalid group type")
+	ErrInvalidDocumentFile          = errors.New("document must be a pdf, jpeg or png file")
+	ErrDocumentTooLarge             = errors.New("document is too large")
+	ErrRejectReasonRequired         = errors.New("reject reason is required")
+
+	ErrHouseholdMemberNotFound   = errors.New("household member not found")
+	ErrHouseholdMemberExists     = errors.New("household member with this snils already added")
+	ErrHouseholdMembersLimit     = errors.New("household members limit reached")
+	ErrInvalidHouseholdRelation  = errors.New("invalid household relation")
+	ErrInvalidSNILS              = errors.New("invalid snils")
+	ErrHouseholdGroupNotVerified = errors.New("group is not verified for household member")
+
+	ErrHouseholdRelationNotVerified    = errors.New("relation with household member is not confirmed")
+	ErrHouseholdRelationNotAwaitingDoc = errors.New("relation with household member is not awaiting a document")
+	ErrHouseholdMemberClaimed          = errors.New("relation with this snils is already confirmed on other accounts")
+
+	ErrUserDocumentNotFound      = errors.New("user document not found")
+	ErrUserDocumentExists        = errors.New("user already has a document of this type")
+	ErrInvalidUserDocumentType   = errors.New("invalid user document type")
+	ErrInvalidUserDocumentNumber = errors.New("document number is required")
+	ErrInvalidUserDocumentDates  = errors.New("invalid document issue or expiry date")
+	ErrInvalidPassport           = errors.New("invalid passport series or number")
+
+	ErrInvalidDisabilityCategory = errors.New("invalid disability category")
+
+	ErrInvalidQuestionnaireAnswers = errors.New("invalid questionnaire answers")
+	ErrQuestionnaireDraftNotFound  = errors.New("questionnaire draft not found or expired")
+
+	ErrInvalidContactValue         = errors.New("invalid email or phone number")
+	ErrContactNotChanged           = errors.New("new contact matches the current one")
+	ErrContactCodeResendTooEarly   = errors.New("contact code was sent recently")
+	ErrContactCodeRateLimited      = errors.New("too many contact codes requested")
+	ErrContactCodeNotFound         = errors.New("contact code not found or expired")
+	ErrInvalidContactCode          = errors.New("invalid contact code")
+	ErrContactCodeAttemptsExceeded = errors.New("too many invalid contact codes")
+
+	ErrBenefitNotFound         = errors.New("benefit not found")
+	ErrBenefitRevisionNotFound = errors.New("benefit revision not found")
+	ErrInvalidBenefitStatus    = errors.New("invalid benefit status")
+	ErrBenefitStatusTransition = errors.New("benefit status transition not allowed")
+	ErrBenefitPublishForbidden = errors.New("benefit publishing requires publish permission")
+	ErrInvalidBenefitSchedule  = errors.New("invalid benefit publication schedule")
+	ErrBenefitStatusChanged    = errors.New("benefit status was changed concurrently")
 )
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/queue/client"
+	"github.com/vibe-gaming/backend/internal/queue/task"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"github.com/vibe-gaming/backend/pkg/pdf"
+	"github.com/vibe-gaming/backend/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// householdMembersLimit - сколько членов семьи можно добавить одному пользователю
+const householdMembersLimit = 10
+
+type HouseholdMemberInput struct {
+	Relation   domain.HouseholdRelation
+	FirstName  string
+	LastName   string
+	MiddleName string
+	BirthDate  *time.Time
+	SNILS      string
+	Groups     domain.GroupTypeList
+}
+
+type householdService struct {
+	memberRepository repository.HouseholdMembers
+	userRepository   repository.Users
+}
+
+func newHouseholdService(memberRepository repository.HouseholdMembers, userRepository repository.Users) *householdService {
+	return &householdService{
+		memberRepository: memberRepository,
+		userRepository:   userRepository,
+	}
+}
+
+// AddMember добавляет члена семьи и отправляет его группы на проверку по СНИЛС. Группы члена семьи
+// учитываются только после подтверждения родства документом
+func (s *householdService) AddMember(ctx context.Context, userID uuid.UUID, input HouseholdMemberInput) (*domain.HouseholdMember, error) {
+	if !input.Relation.IsValid() {
+		return nil, ErrInvalidHouseholdRelation
+	}
+	for _, group := range input.Groups {
+		if !group.IsValid() {
+			return nil, ErrInvalidGroupType
+		}
+	}
+
+	snils, ok := domain.NormalizeSNILS(input.SNILS)
+	if !ok || !validator.IsValidSNILS(snils) {
+		return nil, ErrInvalidSNILS
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userSNILS, ok := domain.NormalizeSNILS(user.SNILS.String); ok && userSNILS == snils {
+		return nil, ErrInvalidSNILS
+	}
+
+	members, err := s.memberRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get household members failed: %w", err)
+	}
+	if len(members) >= householdMembersLimit {
+		return nil, ErrHouseholdMembersLimit
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate household member id failed: %w", err)
+	}
+
+	member := &domain.HouseholdMember{
+		ID:             id,
+		UserID:         userID,
+		Relation:       input.Relation,
+		RelationStatus: domain.HouseholdRelationStatusUnconfirmed,
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		MiddleName:     sql.NullString{String: input.MiddleName, Valid: input.MiddleName != ""},
+		BirthDate:      input.BirthDate,
+		SNILS:          snils,
+		GroupType:      newPendingGroups(nil, input.Groups),
+	}
+
+	if err := checkRelationClaims(ctx, s.memberRepository, member); err != nil {
+		return nil, err
+	}
+
+	events, err := newHouseholdMemberGroupEvents(member, nil, member.GroupType, domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceUser,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepository.Create(ctx, member, events); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return nil, ErrHouseholdMemberExists
+		}
+		return nil, fmt.Errorf("create household member failed: %w", err)
+	}
+
+	s.enqueueMemberCheck(ctx, member, member.GroupType)
+
+	return s.memberRepository.GetByID(ctx, member.ID)
+}
+
+func (s *householdService) GetMembers(ctx context.Context, userID uuid.UUID) ([]domain.HouseholdMember, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.memberRepository.GetByUserID(ctx, userID)
+}
+
+func (s *householdService) GetMember(ctx context.Context, id uuid.UUID) (*domain.HouseholdMember, error) {
+	member, err := s.memberRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrHouseholdMemberNotFound
+		}
+		return nil, fmt.Errorf("get household member by id failed: %w", err)
+	}
+
+	return member, nil
+}
+
+// UpdateMemberGroups меняет список групп члена семьи. Подтвержденные и ожидающие проверки группы сохраняются как есть,
+// новые, отклоненные, истекшие и непроверенные отправляются на проверку заново
+func (s *householdService) UpdateMemberGroups(ctx context.Context, userID uuid.UUID, memberID uuid.UUID, groups domain.GroupTypeList) (*domain.HouseholdMember, error) {
+	for _, group := range groups {
+		if !group.IsValid() {
+			return nil, ErrInvalidGroupType
+		}
+	}
+
+	member, err := s.getUserMember(ctx, userID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := newPendingGroups(member.GroupType, groups)
+	var recheck domain.UserGroupList
+	for _, group := range updated {
+		if group.Status == domain.VerificationStatusPending {
+			recheck = append(recheck, group)
+		}
+	}
+
+	update := domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceUser,
+	}
+	if err := s.UpdateGroups(ctx, memberID, updated, update); err != nil {
+		return nil, err
+	}
+
+	s.enqueueMemberCheck(ctx, member, recheck)
+
+	return s.GetMember(ctx, memberID)
+}
+
+// UpdateGroups сохраняет новые статусы групп члена семьи и записывает переходы в журнал пользователя.
+// Через этот метод проходят и выбор групп пользователем, и решения сервиса проверки, и истечение сроков
+func (s *householdService) UpdateGroups(ctx context.Context, memberID uuid.UUID, groups domain.UserGroupList, update domain.UserGroupUpdate) error {
+	member, err := s.GetMember(ctx, memberID)
+	if err != nil {
+		return err
+	}
+
+	events, err := newHouseholdMemberGroupEvents(member, member.GroupType, groups, update)
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.UpdateGroups(ctx, memberID, groups, events); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrHouseholdMemberNotFound
+		}
+		return fmt.Errorf("update household member groups failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMember удаляет члена семьи. Его группы записываются в журнал как убранные
+func (s *householdService) DeleteMember(ctx context.Context, userID uuid.UUID, memberID uuid.UUID) error {
+	member, err := s.getUserMember(ctx, userID, memberID)
+	if err != nil {
+		return err
+	}
+
+	events, err := newHouseholdMemberGroupEvents(member, member.GroupType, nil, domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceUser,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Delete(ctx, userID, memberID, events); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrHouseholdMemberNotFound
+		}
+		return fmt.Errorf("delete household member failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetHouseholdGroups возвращает подтвержденные группы пользователя и всех членов его семьи
+func (s *householdService) GetHouseholdGroups(ctx context.Context, userID uuid.UUID) (domain.HouseholdGroups, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get household members failed: %w", err)
+	}
+
+	return domain.NewHouseholdGroups(user, members, time.Now()), nil
+}
+
+// GenerateMemberCertificatePDF генерирует удостоверение на члена семьи. Родство и группа члена семьи должны быть подтверждены
+func (s *householdService) GenerateMemberCertificatePDF(ctx context.Context, userID uuid.UUID, memberID uuid.UUID, groupType domain.GroupType) ([]byte, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.getUserMember(ctx, userID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.RelationVerified() {
+		return nil, ErrHouseholdRelationNotVerified
+	}
+
+	verified := false
+	now := time.Now()
+	for _, group := range member.GroupType {
+		if group.Type == groupType && group.IsVerified(now) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrHouseholdGroupNotVerified
+	}
+
+	pdfBytes, err := pdf.NewGenerator().GenerateHouseholdCertificatePDF(member, user, groupType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	logger.Info("Household member certificate PDF generated successfully",
+		zap.String("user_id", userID.String()),
+		zap.String("member_id", memberID.String()),
+		zap.String("group_type", string(groupType)),
+		zap.Int("size", len(pdfBytes)))
+
+	return pdfBytes, nil
+}
+
+func (s *householdService) GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.HouseholdMember, error) {
+	return s.memberRepository.GetWithVerifiedGroups(ctx, afterID, limit)
+}
+
+func (s *householdService) getUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// getUserMember возвращает члена семьи пользователя. Чужой член семьи неотличим от несуществующего
+func (s *householdService) getUserMember(ctx context.Context, userID uuid.UUID, memberID uuid.UUID) (*domain.HouseholdMember, error) {
+	member, err := s.GetMember(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.UserID != userID {
+		return nil, ErrHouseholdMemberNotFound
+	}
+
+	return member, nil
+}
+
+// enqueueMemberCheck ставит в очередь проверку групп члена семьи. Ошибки только логируются,
+// группы остаются в ожидании и могут быть отправлены на проверку повторно
+func (s *householdService) enqueueMemberCheck(ctx context.Context, member *domain.HouseholdMember, groups domain.UserGroupList) {
+	if len(groups) == 0 {
+		return
+	}
+
+	groupTypes := make([]string, len(groups))
+	for i, g := range groups {
+		groupTypes[i] = string(g.Type)
+	}
+
+	asynqClient := client.GetClient(ctx)
+	if asynqClient == nil {
+		return
+	}
+
+	checkTask, err := task.NewCheckHouseholdMemberGroupTask(member.UserID, member.ID, member.SNILS, groupTypes)
+	if err != nil {
+		logger.Error("failed to create check household member group task", zap.Error(err))
+		return
+	}
+	if _, err := asynqClient.Enqueue(checkTask); err != nil {
+		logger.Error("failed to enqueue check household member group task", zap.Error(err))
+	}
+}
+
+// checkRelationClaims не дает подтвердить родство с одним СНИЛС на большем числе аккаунтов, чем допускает отношение:
+// супруг(а) может быть только у одного пользователя, ребенок - у двух родителей
+func checkRelationClaims(ctx context.Context, memberRepository repository.HouseholdMembers, member *domain.HouseholdMember) error {
+	members, err := memberRepository.GetBySNILS(ctx, member.SNILS)
+	if err != nil {
+		return fmt.Errorf("get household members by snils failed: %w", err)
+	}
+
+	claimedBy := make(map[uuid.UUID]bool)
+	for i := range members {
+		other := &members[i]
+		if other.UserID != member.UserID && other.Relation == member.Relation && other.RelationVerified() {
+			claimedBy[other.UserID] = true
+		}
+	}
+	if len(claimedBy) >= member.Relation.AccountsLimit() {
+		logger.Warn("household member snils is already claimed",
+			zap.String("user_id", member.UserID.String()),
+			zap.String("member_id", member.ID.String()),
+			zap.String("relation", string(member.Relation)),
+			zap.Int("accounts", len(claimedBy)))
+		return ErrHouseholdMemberClaimed
+	}
+
+	return nil
+}
+
+// newPendingGroups собирает группы из выбранных типов: подтвержденные и ожидающие проверки берутся из current,
+// остальные получают статус pending
+func newPendingGroups(current domain.UserGroupList, groups domain.GroupTypeList) domain.UserGroupList {
+	result := make(domain.UserGroupList, 0, len(groups))
+	seen := make(map[domain.GroupType]bool, len(groups))
+	for _, groupType := range groups {
+		if seen[groupType] {
+			continue
+		}
+		seen[groupType] = true
+		group := domain.UserGroup{
+			Type:   groupType,
+			Status: domain.VerificationStatusPending,
+		}
+		for _, existing := range current {
+			if existing.Type == groupType &&
+				(existing.Status == domain.VerificationStatusVerified || existing.Status == domain.VerificationStatusPending) {
+				group = existing
+				break
+			}
+		}
+		result = append(result, group)
+	}
+
+	return result
+}
+
+func newHouseholdMemberGroupEvents(member *domain.HouseholdMember, before, after domain.UserGroupList, update domain.UserGroupUpdate) ([]domain.UserGroupEvent, error) {
+	events, err := newUserGroupEvents(member.UserID, before, after, update)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].HouseholdMemberID = &member.ID
+	}
+
+	return events, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+)
+
+type householdMembersBySNILS struct {
+	repository.HouseholdMembers
+	members []domain.HouseholdMember
+}
+
+func (r *householdMembersBySNILS) GetBySNILS(_ context.Context, snils string) ([]domain.HouseholdMember, error) {
+	var members []domain.HouseholdMember
+	for _, member := range r.members {
+		if member.SNILS == snils {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func TestCheckRelationClaims(t *testing.T) {
+	const snils = "112-233-445 95"
+	userID := uuid.New()
+	claim := func(relation domain.HouseholdRelation, status domain.HouseholdRelationStatus) domain.HouseholdMember {
+		return domain.HouseholdMember{ID: uuid.New(), UserID: uuid.New(), Relation: relation, RelationStatus: status, SNILS: snils}
+	}
+
+	tests := []struct {
+		name     string
+		relation domain.HouseholdRelation
+		others   []domain.HouseholdMember
+		wantErr  error
+	}{
+		{name: "new snils", relation: domain.HouseholdRelationSpouse},
+		{
+			name:     "spouse added elsewhere without proof",
+			relation: domain.HouseholdRelationSpouse,
+			others:   []domain.HouseholdMember{claim(domain.HouseholdRelationSpouse, domain.HouseholdRelationStatusPending)},
+		},
+		{
+			name:     "spouse confirmed on other account",
+			relation: domain.HouseholdRelationSpouse,
+			others:   []domain.HouseholdMember{claim(domain.HouseholdRelationSpouse, domain.HouseholdRelationStatusVerified)},
+			wantErr:  ErrHouseholdMemberClaimed,
+		},
+		{
+			name:     "child confirmed by other parent",
+			relation: domain.HouseholdRelationChild,
+			others:   []domain.HouseholdMember{claim(domain.HouseholdRelationChild, domain.HouseholdRelationStatusVerified)},
+		},
+		{
+			name:     "child confirmed by two other accounts",
+			relation: domain.HouseholdRelationChild,
+			others: []domain.HouseholdMember{
+				claim(domain.HouseholdRelationChild, domain.HouseholdRelationStatusVerified),
+				claim(domain.HouseholdRelationChild, domain.HouseholdRelationStatusVerified),
+			},
+			wantErr: ErrHouseholdMemberClaimed,
+		},
+		{
+			name:     "confirmed with other relation",
+			relation: domain.HouseholdRelationSpouse,
+			others:   []domain.HouseholdMember{claim(domain.HouseholdRelationChild, domain.HouseholdRelationStatusVerified)},
+		},
+		{
+			name:     "same account",
+			relation: domain.HouseholdRelationSpouse,
+			others: []domain.HouseholdMember{{
+				ID: uuid.New(), UserID: userID, Relation: domain.HouseholdRelationSpouse,
+				RelationStatus: domain.HouseholdRelationStatusVerified, SNILS: snils,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := &domain.HouseholdMember{ID: uuid.New(), UserID: userID, Relation: tt.relation, SNILS: snils}
+			members := &householdMembersBySNILS{members: append(tt.others, *member)}
+
+			if err := checkRelationClaims(context.Background(), members, member); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkRelationClaims() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/vibe-gaming/backend/internal/domain"
 	"github.com/vibe-gaming/backend/internal/repository"
 )
@@ -50,3 +52,31 @@ func (s *organizationService) GetAllByCityID(ctx context.Context, cityID string)
 	return s.organizationRepository.GetAllByCityID(ctx, cityID)
 }
 
+func (s *organizationService) CreateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error {
+	if building.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate organization building id failed: %w", err)
+		}
+		building.ID = id
+	}
+	if building.Tags == nil {
+		building.Tags = domain.OrganizationTagList{}
+	}
+	return s.organizationRepository.CreateBuilding(ctx, building)
+}
+
+func (s *organizationService) GetBuildingByID(ctx context.Context, id uuid.UUID) (*domain.OrganizationBuilding, error) {
+	return s.organizationRepository.GetBuildingByID(ctx, id)
+}
+
+func (s *organizationService) UpdateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error {
+	if building.Tags == nil {
+		building.Tags = domain.OrganizationTagList{}
+	}
+	return s.organizationRepository.UpdateBuilding(ctx, building)
+}
+
+func (s *organizationService) DeleteBuilding(ctx context.Context, id uuid.UUID) error {
+	return s.organizationRepository.DeleteBuilding(ctx, id)
+}
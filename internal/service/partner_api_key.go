@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// Ключ имеет вид htk_<prefix>_<secret>. Префикс хранится открыто и нужен для поиска ключа,
+	// по секрету ключ проверяется
+	partnerAPIKeyTag          = "htk"
+	partnerAPIKeyPrefixLength = 4
+	partnerAPIKeySecretLength = 32
+	// partnerAPIKeyTouchInterval - как часто обновляется время последнего использования,
+	// чтобы не писать в БД на каждый запрос партнера
+	partnerAPIKeyTouchInterval = time.Minute
+)
+
+type PartnerAPIKeyCreateInput struct {
+	OrganizationID uuid.UUID
+	Name           string
+	Scopes         []domain.APIKeyScope
+	ExpiresAt      *time.Time
+	CreatedBy      uuid.UUID
+}
+
+type partnerAPIKeyService struct {
+	partnerAPIKeyRepository repository.PartnerAPIKeys
+	organizationRepository  repository.OrganizationRepository
+}
+
+func newPartnerAPIKeyService(partnerAPIKeyRepository repository.PartnerAPIKeys,
+	organizationRepository repository.OrganizationRepository,
+) *partnerAPIKeyService {
+	return &partnerAPIKeyService{
+		partnerAPIKeyRepository: partnerAPIKeyRepository,
+		organizationRepository:  organizationRepository,
+	}
+}
+
+// Create выпускает ключ организации. Возвращает сохраненный ключ и сам ключ в открытом виде,
+// который больше нигде не хранится
+func (s *partnerAPIKeyService) Create(ctx context.Context, input PartnerAPIKeyCreateInput) (*domain.PartnerAPIKey, string, error) {
+	if len(input.Scopes) == 0 {
+		return nil, "", ErrInvalidAPIKeyScope
+	}
+	scopes := make(domain.APIKeyScopeList, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidAPIKeyScope
+		}
+		if !scopes.Has(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyExpiry
+	}
+
+	if _, err := s.organizationRepository.GetByID(ctx, input.OrganizationID.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrOrganizationNotFound
+		}
+		return nil, "", fmt.Errorf("get organization failed: %w", err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate partner api key id failed: %w", err)
+	}
+
+	prefix, err := randomToken(partnerAPIKeyPrefixLength)
+	if err != nil {
+		return nil, "", fmt.Errorf("generate partner api key prefix failed: %w", err)
+	}
+
+	secret, err := randomToken(partnerAPIKeySecretLength)
+	if err != nil {
+		return nil, "", fmt.Errorf("generate partner api key secret failed: %w", err)
+	}
+
+	rawKey := partnerAPIKeyTag + "_" + prefix + "_" + secret
+	createdBy := input.CreatedBy
+
+	key := &domain.PartnerAPIKey{
+		ID:             id,
+		OrganizationID: input.OrganizationID,
+		Name:           input.Name,
+		KeyPrefix:      prefix,
+		KeyHash:        hashPartnerAPIKey(rawKey),
+		Scopes:         scopes,
+		CreatedBy:      &createdBy,
+		ExpiresAt:      input.ExpiresAt,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.partnerAPIKeyRepository.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("create partner api key failed: %w", err)
+	}
+
+	return key, rawKey, nil
+}
+
+func (s *partnerAPIKeyService) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]domain.PartnerAPIKey, error) {
+	return s.partnerAPIKeyRepository.GetByOrganizationID(ctx, organizationID)
+}
+
+func (s *partnerAPIKeyService) Revoke(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error {
+	if err := s.partnerAPIKeyRepository.Revoke(ctx, organizationID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrPartnerAPIKeyNotFound
+		}
+		return fmt.Errorf("revoke partner api key failed: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate проверяет ключ из запроса партнера. Неизвестный, отозванный и истекший ключ
+// не различаются и дают ErrInvalidAPIKey
+func (s *partnerAPIKeyService) Authenticate(ctx context.Context, rawKey string, ip string) (*domain.PartnerAPIKey, error) {
+	parts := strings.Split(rawKey, "_")
+	if len(parts) != 3 || parts[0] != partnerAPIKeyTag || parts[1] == "" || parts[2] == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.partnerAPIKeyRepository.GetByPrefix(ctx, parts[1])
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("get partner api key failed: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashPartnerAPIKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if !key.IsActive() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > partnerAPIKeyTouchInterval {
+		if err := s.partnerAPIKeyRepository.UpdateLastUsed(ctx, key.ID, ip); err != nil {
+			logger.Error("update partner api key last used failed", zap.Error(err), zap.String("key_id", key.ID.String()))
+		}
+	}
+
+	return key, nil
+}
+
+// hashPartnerAPIKey - у ключа 256 бит случайности, поэтому медленный хеш паролей не нужен
+func hashPartnerAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
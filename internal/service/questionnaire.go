@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+)
+
+const (
+	questionnaireDraftKeyPrefix = "questionnaire:draft:"
+	// questionnaireDraftTTL - сколько хранится черновик анкеты, заполненной до входа
+	questionnaireDraftTTL = 7 * 24 * time.Hour
+)
+
+// QuestionnaireResult - следующий вопрос анкеты и, когда анкета заполнена, подходящие льготы
+type QuestionnaireResult struct {
+	Answers      domain.QuestionnaireAnswers
+	NextQuestion *domain.Question
+	Groups       domain.GroupTypeList
+	Benefits     []*domain.Benefit
+	Total        int64
+}
+
+// Completed - на все вопросы получены ответы
+func (r *QuestionnaireResult) Completed() bool {
+	return r.NextQuestion == nil
+}
+
+type QuestionnaireDraft struct {
+	ID        uuid.UUID
+	ExpiresAt time.Time
+}
+
+type questionnaireService struct {
+	benefits       Benefits
+	users          Users
+	cityRepository repository.Cities
+	redis          redis.UniversalClient
+}
+
+func newQuestionnaireService(benefits Benefits, users Users, cityRepository repository.Cities, redis redis.UniversalClient) *questionnaireService {
+	return &questionnaireService{
+		benefits:       benefits,
+		users:          users,
+		cityRepository: cityRepository,
+		redis:          redis,
+	}
+}
+
+// Evaluate возвращает следующий вопрос по уже данным ответам. Анкета не хранит состояние: клиент каждый раз
+// присылает все ответы. Когда вопросов не осталось, подбирает льготы по группам из ответов и городу проживания
+func (s *questionnaireService) Evaluate(ctx context.Context, answers domain.QuestionnaireAnswers, page, limit int) (*QuestionnaireResult, error) {
+	if err := s.validate(ctx, &answers); err != nil {
+		return nil, err
+	}
+
+	result := &QuestionnaireResult{
+		Answers:      answers,
+		NextQuestion: answers.NextQuestion(),
+		Groups:       answers.GroupTypes(),
+	}
+	if !result.Completed() {
+		return result, nil
+	}
+
+	groupTypes := make([]string, 0, len(result.Groups))
+	for _, group := range result.Groups {
+		groupTypes = append(groupTypes, string(group))
+	}
+	filterByUserGroups := true
+	cityID := answers.CityID.String()
+	filters := &BenefitFilters{
+		FilterByUserGroups: &filterByUserGroups,
+		UserGroupTypes:     groupTypes,
+		ResidenceCityID:    &cityID,
+	}
+
+	benefits, total, err := s.benefits.GetAll(ctx, page, limit, filters)
+	if err != nil {
+		return nil, fmt.Errorf("get benefits failed: %w", err)
+	}
+	result.Benefits = benefits
+	result.Total = total
+
+	return result, nil
+}
+
+// SaveDraft сохраняет ответы анкеты, чтобы после входа перенести их в профиль
+func (s *questionnaireService) SaveDraft(ctx context.Context, answers domain.QuestionnaireAnswers) (*QuestionnaireDraft, error) {
+	if err := s.validate(ctx, &answers); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(answers)
+	if err != nil {
+		return nil, fmt.Errorf("marshal questionnaire draft failed: %w", err)
+	}
+
+	draft := &QuestionnaireDraft{
+		ID:        uuid.New(),
+		ExpiresAt: time.Now().Add(questionnaireDraftTTL),
+	}
+	if err := s.redis.Set(ctx, questionnaireDraftKeyPrefix+draft.ID.String(), value, questionnaireDraftTTL).Err(); err != nil {
+		return nil, fmt.Errorf("redis save questionnaire draft failed: %w", err)
+	}
+
+	return draft, nil
+}
+
+// ApplyDraft переносит черновик анкеты в профиль пользователя и удаляет черновик
+func (s *questionnaireService) ApplyDraft(ctx context.Context, userID uuid.UUID, draftID uuid.UUID) error {
+	key := questionnaireDraftKeyPrefix + draftID.String()
+
+	value, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrQuestionnaireDraftNotFound
+		}
+		return fmt.Errorf("redis get questionnaire draft failed: %w", err)
+	}
+
+	var answers domain.QuestionnaireAnswers
+	if err := json.Unmarshal([]byte(value), &answers); err != nil {
+		return fmt.Errorf("unmarshal questionnaire draft failed: %w", err)
+	}
+
+	if err := s.users.ApplyQuestionnaire(ctx, userID, &answers); err != nil {
+		return err
+	}
+
+	if err := s.redis.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("redis delete questionnaire draft failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *questionnaireService) validate(ctx context.Context, answers *domain.QuestionnaireAnswers) error {
+	if err := answers.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidQuestionnaireAnswers, err)
+	}
+
+	if answers.CityID != nil {
+		if _, err := s.cityRepository.GetOneByID(ctx, *answers.CityID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return ErrCityNotFound
+			}
+			return fmt.Errorf("get city by id failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+)
+
+type roleService struct {
+	userRoleRepository     repository.UserRoles
+	userRepository         repository.Users
+	organizationRepository repository.OrganizationRepository
+}
+
+func newRoleService(userRoleRepository repository.UserRoles,
+	userRepository repository.Users,
+	organizationRepository repository.OrganizationRepository,
+) *roleService {
+	return &roleService{
+		userRoleRepository:     userRoleRepository,
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+	}
+}
+
+func (s *roleService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserRole, error) {
+	return s.userRoleRepository.GetByUserID(ctx, userID)
+}
+
+// Grant выдает роль пользователю. Роль organization_manager выдается только на существующую организацию,
+// остальные роли - без организации
+func (s *roleService) Grant(ctx context.Context, role *domain.UserRole) error {
+	if !role.Role.IsValid() || role.Role == domain.RoleCitizen {
+		return ErrInvalidRole
+	}
+
+	if role.Role.IsOrganizationScoped() != (role.OrganizationID != nil) {
+		return ErrRoleOrganizationMismatch
+	}
+
+	if _, err := s.userRepository.GetOneByID(ctx, role.UserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("get user failed: %w", err)
+	}
+
+	if role.OrganizationID != nil {
+		if _, err := s.organizationRepository.GetByID(ctx, role.OrganizationID.String()); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrOrganizationNotFound
+			}
+			return fmt.Errorf("get organization failed: %w", err)
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate user role id failed: %w", err)
+	}
+	role.ID = id
+
+	if err := s.userRoleRepository.Create(ctx, role); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrRoleAlreadyGranted
+		}
+		return fmt.Errorf("create user role failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *roleService) Revoke(ctx context.Context, userID uuid.UUID, role domain.Role, organizationID *uuid.UUID) error {
+	if err := s.userRoleRepository.Delete(ctx, userID, role, organizationID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrRoleNotFound
+		}
+		return fmt.Errorf("delete user role failed: %w", err)
+	}
+
+	return nil
+}
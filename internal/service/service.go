@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/domain"
@@ -10,8 +12,10 @@ import (
 	"github.com/vibe-gaming/backend/pkg/auth"
 	"github.com/vibe-gaming/backend/pkg/hash"
 	"github.com/vibe-gaming/backend/pkg/otp"
+	"github.com/vibe-gaming/backend/pkg/storage"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 )
 
 type Services struct {
@@ -20,64 +24,143 @@ type Services struct {
 	Cities        Cities
 	Favorites     Favorites
 	Organizations Organizations
+	Roles         Roles
+	Staff         Staff
+	PartnerKeys   PartnerAPIKeys
+	// VerificationDocuments - подтверждение групп документами и очередь модерации
+	VerificationDocuments VerificationDocuments
+	// Household - члены семьи пользователя, чьи подтвержденные группы тоже открывают льготы
+	Household Household
+	// UserDocuments - паспорт, СНИЛС и регистрация пользователя
+	UserDocuments UserDocuments
+	// Eligibility - проверка права пользователя на льготы по условиям льгот и профилю
+	Eligibility Eligibility
+	// Questionnaire - анкета "На что я имею право?" для неавторизованных пользователей
+	Questionnaire Questionnaire
+	// Contacts - смена email и телефона пользователя по одноразовому коду
+	Contacts Contacts
+	// BenefitImport - загрузка льгот из CSV, XLSX и JSON с обновлением по external_id
+	BenefitImport BenefitImport
 }
 
 type Deps struct {
 	Config                 *config.Config
 	Hasher                 hash.PasswordHasher
 	TokenManager           auth.TokenManager
+	TokenDenylist          auth.Denylist
 	OtpGenerator           otp.Generator
 	OrganizationRepository repository.OrganizationRepository
 	Repos                  *repository.Repositories
+	Redis                  redis.UniversalClient
 	EsiaClient             *esia.Client
+	Storage                storage.Storage
 	GigachatClient         interface {
 		EnhanceSearchQuery(ctx context.Context, query string) ([]string, error)
 	}
 }
 
 func NewServices(deps Deps) *Services {
+	users := newUserService(deps.Repos.Users,
+		deps.Repos.RefreshSession,
+		deps.Repos.Cities,
+		deps.Repos.UserDocument,
+		deps.Repos.UserRoles,
+		deps.Hasher,
+		deps.TokenManager,
+		deps.TokenDenylist,
+		deps.OtpGenerator,
+		deps.EsiaClient,
+		deps.Config.Auth,
+		deps.Config,
+	)
+
+	household := newHouseholdService(deps.Repos.HouseholdMembers, deps.Repos.Users)
+
+	benefits := newBenefitService(deps.Repos.Benefits, deps.Repos.Favorite, household, deps.Repos.Organization, deps.GigachatClient)
+
 	return &Services{
-		Users: newUserService(deps.Repos.Users,
-			deps.Repos.RefreshSession,
-			deps.Repos.Cities,
-			deps.Repos.UserDocument,
-			deps.Hasher,
-			deps.TokenManager,
-			deps.OtpGenerator,
-			deps.EsiaClient,
-			deps.Config.Auth,
-			deps.Config,
-		),
-		Benefits:      newBenefitService(deps.Repos.Benefits, deps.Repos.Favorite, deps.Repos.Users, deps.Repos.Organization, deps.GigachatClient),
+		Users:         users,
+		Benefits:      benefits,
 		Cities:        newCityService(deps.Repos.Cities),
 		Favorites:     newFavoriteService(deps.Repos.Favorite),
 		Organizations: newOrganizationService(deps.Repos.Organization),
+		Roles:         newRoleService(deps.Repos.UserRoles, deps.Repos.Users, deps.Repos.Organization),
+		Staff:         newStaffService(deps.Repos.StaffCredentials, users, deps.Hasher, deps.OtpGenerator, deps.Redis, deps.Config.Auth.Staff),
+		PartnerKeys:   newPartnerAPIKeyService(deps.Repos.PartnerAPIKeys, deps.Repos.Organization),
+		VerificationDocuments: newVerificationDocumentService(deps.Repos.VerificationDocs,
+			deps.Repos.HouseholdMembers,
+			users,
+			deps.Storage,
+			deps.Config.SocialGroupChecker,
+			deps.Config.Storage.VerificationDocumentMaxSize,
+		),
+		Household:     household,
+		UserDocuments: newUserDocumentService(deps.Repos.UserDocument, deps.Repos.Users),
+		Eligibility:   newEligibilityService(deps.Repos.Benefits, deps.Repos.Users, deps.Repos.Cities, household),
+		Questionnaire: newQuestionnaireService(benefits, users, deps.Repos.Cities, deps.Redis),
+		Contacts: newContactService(deps.Repos.Users,
+			deps.OtpGenerator,
+			deps.Redis,
+			deps.Config.Auth.ContactChange,
+			deps.Config.Auth.VerificationCodeLength,
+		),
+		BenefitImport: NewBenefitImportService(deps.Repos),
 	}
 }
 
 type Users interface {
-	Auth(ctx context.Context, code string, userAgent string, userIP string) (*Tokens, error)
-	createSession(ctx context.Context, userID *uuid.UUID, userAgent *string, userIP *string) (*Tokens, error)
+	Auth(ctx context.Context, code string, codeVerifier string, nonce string, userAgent string, userIP string) (*Tokens, error)
+	RefreshTokens(ctx context.Context, refreshToken uuid.UUID, userAgent string, userIP string) (*Tokens, error)
+	createSession(ctx context.Context, userID *uuid.UUID, familyID *uuid.UUID, userAgent *string, userIP *string) (*Tokens, error)
+	GetSessions(ctx context.Context, userID uuid.UUID) ([]domain.UserSession, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+	Logout(ctx context.Context, claims *auth.Claims) error
+	IsAccessTokenRevoked(ctx context.Context, claims *auth.Claims) (bool, error)
+	Deactivate(ctx context.Context, userID uuid.UUID) error
+	Activate(ctx context.Context, userID uuid.UUID) error
+	Delete(ctx context.Context, userID uuid.UUID) error
 	GetOneByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetProfileChanges(ctx context.Context, userID uuid.UUID) ([]domain.UserProfileChange, error)
 	UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.GroupTypeList) error
-	UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList) error
-	CreateDocument(ctx context.Context, document *domain.UserDocument) error
-	GetDocumentsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error)
+	UpdateEligibilityProfile(ctx context.Context, userID uuid.UUID, input EligibilityProfileInput) (*domain.User, error)
+	ApplyQuestionnaire(ctx context.Context, userID uuid.UUID, answers *domain.QuestionnaireAnswers) error
+	UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList, update domain.UserGroupUpdate) error
+	GetGroupEvents(ctx context.Context, userID uuid.UUID) ([]domain.UserGroupEvent, error)
+	GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.User, error)
 	GeneratePensionerCertificatePDF(ctx context.Context, userID uuid.UUID) ([]byte, error)
 	GenerateUserCertificatePDF(ctx context.Context, userID uuid.UUID, groupType domain.GroupType) ([]byte, error)
 	Count(ctx context.Context) (int64, error)
 	GetUserGroupsStats(ctx context.Context) (map[string]int64, error)
 }
 
+type Staff interface {
+	Create(ctx context.Context, input StaffCreateInput) (uuid.UUID, error)
+	Login(ctx context.Context, login string, password string, userAgent string, userIP string) (*StaffLoginResult, error)
+	VerifyMFA(ctx context.Context, mfaToken string, code string, recoveryCode string, userAgent string, userIP string) (*Tokens, error)
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
 type Cities interface {
 	GetAll(ctx context.Context) ([]domain.City, error)
 	Count(ctx context.Context) (int64, error)
 }
 
 type Benefits interface {
-	Create(ctx context.Context, benefit *domain.Benefit) error
-	Update(ctx context.Context, benefit *domain.Benefit) error
-	Delete(ctx context.Context, id string) error
+	Create(ctx context.Context, benefit *domain.Benefit, author domain.BenefitAuthor) error
+	Update(ctx context.Context, benefit *domain.Benefit, author domain.BenefitAuthor) error
+	Delete(ctx context.Context, id string, author domain.BenefitAuthor) error
+	ChangeStatus(ctx context.Context, benefit *domain.Benefit, change BenefitStatusChange, author domain.BenefitAuthor) error
+	PublishScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	ArchiveScheduled(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	ArchiveExpired(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	GetEndingNotices(ctx context.Context, now time.Time, before time.Duration) ([]domain.BenefitEndingNotice, error)
+	GetRevisions(ctx context.Context, benefitID uuid.UUID) (*domain.Benefit, []domain.BenefitRevision, error)
+	DiffRevisions(ctx context.Context, benefitID uuid.UUID, from, to int) ([]domain.BenefitFieldChange, error)
+	RestoreRevision(ctx context.Context, benefitID uuid.UUID, version int, author domain.BenefitAuthor) (*domain.Benefit, *domain.BenefitRevision, error)
 	GetAll(ctx context.Context, page, limit int, filters *repository.BenefitFilters) ([]*domain.Benefit, int64, error)
 	GetByID(ctx context.Context, id string, userID *uuid.UUID) (*domain.Benefit, error)
 	GetByIDWithoutIncrement(ctx context.Context, id string, userID *uuid.UUID) (*domain.Benefit, error)
@@ -91,6 +174,10 @@ type Benefits interface {
 	GetBenefitTypesStats(ctx context.Context) (map[string]int64, error)
 }
 
+type BenefitImport interface {
+	Import(ctx context.Context, rows []BenefitImportRow, options BenefitImportOptions) (*BenefitImportReport, error)
+}
+
 type Favorites interface {
 	GetTotalCount(ctx context.Context) (int64, error)
 }
@@ -102,4 +189,67 @@ type Organizations interface {
 	GetByID(ctx context.Context, id string) (*domain.Organization, error)
 	GetAll(ctx context.Context) ([]domain.Organization, error)
 	GetAllByCityID(ctx context.Context, cityID string) ([]domain.Organization, error)
+	CreateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error
+	GetBuildingByID(ctx context.Context, id uuid.UUID) (*domain.OrganizationBuilding, error)
+	UpdateBuilding(ctx context.Context, building *domain.OrganizationBuilding) error
+	DeleteBuilding(ctx context.Context, id uuid.UUID) error
+}
+
+type PartnerAPIKeys interface {
+	Create(ctx context.Context, input PartnerAPIKeyCreateInput) (*domain.PartnerAPIKey, string, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]domain.PartnerAPIKey, error)
+	Revoke(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error
+	Authenticate(ctx context.Context, rawKey string, ip string) (*domain.PartnerAPIKey, error)
+}
+
+type VerificationDocuments interface {
+	Upload(ctx context.Context, input VerificationDocumentUploadInput) (*domain.VerificationDocument, error)
+	UploadRelationProof(ctx context.Context, input HouseholdRelationProofInput) (*domain.VerificationDocument, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.VerificationDocument, error)
+	GetUserDocumentFile(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.VerificationDocument, io.ReadCloser, error)
+	GetQueue(ctx context.Context, status domain.VerificationDocumentStatus, page, limit int) ([]domain.VerificationDocument, int64, error)
+	GetFile(ctx context.Context, id uuid.UUID) (*domain.VerificationDocument, io.ReadCloser, error)
+	Approve(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error
+	Reject(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error
+}
+
+type Household interface {
+	AddMember(ctx context.Context, userID uuid.UUID, input HouseholdMemberInput) (*domain.HouseholdMember, error)
+	GetMembers(ctx context.Context, userID uuid.UUID) ([]domain.HouseholdMember, error)
+	GetMember(ctx context.Context, id uuid.UUID) (*domain.HouseholdMember, error)
+	UpdateMemberGroups(ctx context.Context, userID uuid.UUID, memberID uuid.UUID, groups domain.GroupTypeList) (*domain.HouseholdMember, error)
+	UpdateGroups(ctx context.Context, memberID uuid.UUID, groups domain.UserGroupList, update domain.UserGroupUpdate) error
+	DeleteMember(ctx context.Context, userID uuid.UUID, memberID uuid.UUID) error
+	GetHouseholdGroups(ctx context.Context, userID uuid.UUID) (domain.HouseholdGroups, error)
+	GenerateMemberCertificatePDF(ctx context.Context, userID uuid.UUID, memberID uuid.UUID, groupType domain.GroupType) ([]byte, error)
+	GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.HouseholdMember, error)
+}
+
+type UserDocuments interface {
+	Create(ctx context.Context, userID uuid.UUID, input UserDocumentInput) (*domain.UserDocument, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error)
+	Update(ctx context.Context, userID uuid.UUID, id uuid.UUID, input UserDocumentInput) (*domain.UserDocument, error)
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+}
+
+type Eligibility interface {
+	CheckBenefit(ctx context.Context, userID uuid.UUID, benefitID string) (*domain.EligibilityResult, error)
+	CheckBenefits(ctx context.Context, userID uuid.UUID, page, limit int) ([]domain.EligibilityResult, int64, error)
+}
+
+type Questionnaire interface {
+	Evaluate(ctx context.Context, answers domain.QuestionnaireAnswers, page, limit int) (*QuestionnaireResult, error)
+	SaveDraft(ctx context.Context, answers domain.QuestionnaireAnswers) (*QuestionnaireDraft, error)
+	ApplyDraft(ctx context.Context, userID uuid.UUID, draftID uuid.UUID) error
+}
+
+type Contacts interface {
+	RequestChange(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, value string) (*ContactChangeChallenge, error)
+	ConfirmChange(ctx context.Context, userID uuid.UUID, contactType domain.ContactType, code string) (*domain.User, error)
+}
+
+type Roles interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserRole, error)
+	Grant(ctx context.Context, role *domain.UserRole) error
+	Revoke(ctx context.Context, userID uuid.UUID, role domain.Role, organizationID *uuid.UUID) error
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -55,6 +56,18 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+var (
+	// ErrUnavailable - временная ошибка: сеть, таймаут, 5xx или 429. Проверку имеет смысл повторить позже
+	ErrUnavailable = errors.New("сервис проверки временно недоступен")
+	// ErrRequestRejected - сервис отклонил запрос (4xx). Повтор с теми же данными не поможет
+	ErrRequestRejected = errors.New("сервис проверки отклонил запрос")
+)
+
+// IsTransient сообщает, что ошибка CheckGroups временная и проверку можно повторить
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
+
 // Client представляет клиент для мок-сервиса проверки социальных групп
 type Client struct {
 	baseURL    string
@@ -71,8 +84,12 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
-// CheckGroups проверяет статус социальных групп для указанного СНИЛС
-func (c *Client) CheckGroups(ctx context.Context, snils string, groups []SocialGroup) (*CheckResponse, error) {
+// RequestIDHeader - заголовок с ID запроса, по нему запрос можно найти в журналах сервиса проверки
+const RequestIDHeader = "X-Request-ID"
+
+// CheckGroups проверяет статус социальных групп для указанного СНИЛС. requestID передается сервису
+// в заголовке RequestIDHeader
+func (c *Client) CheckGroups(ctx context.Context, requestID string, snils string, groups []SocialGroup) (*CheckResponse, error) {
 	if snils == "" {
 		return nil, fmt.Errorf("СНИЛС не может быть пустым")
 	}
@@ -96,21 +113,29 @@ func (c *Client) CheckGroups(ctx context.Context, snils string, groups []SocialG
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if requestID != "" {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+		return nil, fmt.Errorf("%w: ошибка выполнения запроса: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("неожиданный статус код: %d, тело: %s", resp.StatusCode, string(body))
+		kind := ErrRequestRejected
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout {
+			kind = ErrUnavailable
+		}
+		return nil, fmt.Errorf("%w: неожиданный статус код: %d, тело: %s", kind, resp.StatusCode, string(body))
 	}
 
+	// Неразборчивый ответ обычно отдает прокси перед сервисом, поэтому ошибка считается временной
 	var checkResp CheckResponse
 	if err := json.NewDecoder(resp.Body).Decode(&checkResp); err != nil {
-		return nil, fmt.Errorf("ошибка десериализации ответа: %w", err)
+		return nil, fmt.Errorf("%w: ошибка десериализации ответа: %w", ErrUnavailable, err)
 	}
 
 	return &checkResp, nil
@@ -0,0 +1,46 @@
+package socialgroupchecker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	socialgroupchecker "github.com/vibe-gaming/backend/internal/service/social_group_checker"
+	"github.com/vibe-gaming/backend/internal/standin"
+	"github.com/vibe-gaming/backend/internal/standin/standintest"
+)
+
+func TestClientCheckGroupsWithStandIn(t *testing.T) {
+	const snils = "112-233-445 95"
+
+	srv := standintest.NewServer(t, nil)
+	client := socialgroupchecker.NewClient(srv.CheckerBaseURL())
+
+	resp, err := client.CheckGroups(context.Background(), "request-1", snils,
+		[]socialgroupchecker.SocialGroup{socialgroupchecker.Pensioners, socialgroupchecker.Students})
+	if err != nil {
+		t.Fatalf("CheckGroups() error = %v", err)
+	}
+
+	want := map[socialgroupchecker.SocialGroup]socialgroupchecker.GroupStatus{
+		socialgroupchecker.Pensioners: socialgroupchecker.StatusConfirmed,
+		socialgroupchecker.Students:   socialgroupchecker.StatusRejected,
+	}
+	if len(resp.Results) != len(want) {
+		t.Fatalf("results = %+v, want %d results", resp.Results, len(want))
+	}
+	for _, result := range resp.Results {
+		if result.Status != want[result.Group] {
+			t.Errorf("group %s status = %s, want %s", result.Group, result.Status, want[result.Group])
+		}
+	}
+
+	if err := srv.InjectFailure(standin.Failure{Endpoint: standin.EndpointCheck, Status: http.StatusServiceUnavailable, Times: 1}); err != nil {
+		t.Fatalf("InjectFailure() error = %v", err)
+	}
+	_, err = client.CheckGroups(context.Background(), "request-2", snils, []socialgroupchecker.SocialGroup{socialgroupchecker.Pensioners})
+	if !socialgroupchecker.IsTransient(err) || !errors.Is(err, socialgroupchecker.ErrUnavailable) {
+		t.Fatalf("CheckGroups() error = %v, want %v", err, socialgroupchecker.ErrUnavailable)
+	}
+}
@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/hash"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"github.com/vibe-gaming/backend/pkg/otp"
+	"go.uber.org/zap"
+)
+
+const (
+	staffMFAChallengeKeyPrefix = "staff:mfa:"
+	staffTOTPSecretLength      = 32
+	staffRecoveryCodesCount    = 10
+	staffRecoveryCodeLength    = 10
+)
+
+type staffService struct {
+	staffCredentialRepository repository.StaffCredentials
+	users                     Users
+	hasher                    hash.PasswordHasher
+	otpGenerator              otp.Generator
+	redis                     redis.UniversalClient
+	config                    config.StaffAuthConfig
+	// dummyHash проверяется при неизвестном логине, чтобы время ответа не выдавало существующие логины
+	dummyHash string
+}
+
+func newStaffService(staffCredentialRepository repository.StaffCredentials,
+	users Users,
+	hasher hash.PasswordHasher,
+	otpGenerator otp.Generator,
+	redis redis.UniversalClient,
+	config config.StaffAuthConfig,
+) *staffService {
+	dummyHash, err := hasher.Hash(uuid.NewString())
+	if err != nil {
+		logger.Error("generate dummy password hash failed", zap.Error(err))
+	}
+
+	return &staffService{
+		staffCredentialRepository: staffCredentialRepository,
+		users:                     users,
+		hasher:                    hasher,
+		otpGenerator:              otpGenerator,
+		redis:                     redis,
+		config:                    config,
+		dummyHash:                 dummyHash,
+	}
+}
+
+type StaffCreateInput struct {
+	Login      string
+	Password   string
+	FirstName  string
+	LastName   string
+	MiddleName string
+	Email      string
+}
+
+// StaffLoginResult - результат входа по паролю. Если у сотрудника включен второй фактор,
+// токены не выдаются, а возвращается MFAToken для подтверждения TOTP кодом
+type StaffLoginResult struct {
+	Tokens   *Tokens
+	MFAToken string
+}
+
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type staffMFAChallenge struct {
+	UserID    uuid.UUID `json:"user_id"`
+	UserAgent string    `json:"user_agent"`
+}
+
+// Create создает учетную запись сотрудника. Роли выдаются отдельно через API ролей
+func (s *staffService) Create(ctx context.Context, input StaffCreateInput) (uuid.UUID, error) {
+	userID, err := uuid.NewV7()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("generate user id failed: %w", err)
+	}
+
+	passwordHash, err := s.hasher.Hash(input.Password)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("hash password failed: %w", err)
+	}
+
+	user := &domain.User{
+		ID:         userID,
+		FirstName:  sql.NullString{String: input.FirstName, Valid: input.FirstName != ""},
+		LastName:   sql.NullString{String: input.LastName, Valid: input.LastName != ""},
+		MiddleName: sql.NullString{String: input.MiddleName, Valid: input.MiddleName != ""},
+		Email:      sql.NullString{String: input.Email, Valid: input.Email != ""},
+	}
+	credential := &domain.StaffCredential{
+		UserID:       userID,
+		Login:        normalizeStaffLogin(input.Login),
+		PasswordHash: passwordHash,
+	}
+
+	if err := s.staffCredentialRepository.Create(ctx, user, credential); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return uuid.Nil, ErrStaffLoginTaken
+		}
+		return uuid.Nil, fmt.Errorf("create staff credential failed: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Login проверяет логин и пароль сотрудника. Пароли со старым алгоритмом хеширования
+// перехешируются при успешном входе
+func (s *staffService) Login(ctx context.Context, login string, password string, userAgent string, userIP string) (*StaffLoginResult, error) {
+	credential, err := s.staffCredentialRepository.GetByLogin(ctx, normalizeStaffLogin(login))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			_, _, _ = s.hasher.Verify(password, s.dummyHash)
+			return nil, ErrStaffInvalidCredentials
+		}
+		return nil, fmt.Errorf("get staff credential failed: %w", err)
+	}
+
+	if credential.IsLocked() {
+		return nil, ErrStaffAccountLocked
+	}
+
+	ok, needsRehash, err := s.hasher.Verify(password, credential.PasswordHash)
+	if err != nil {
+		return nil, fmt.Errorf("verify password failed: %w", err)
+	}
+	if !ok {
+		if err := s.registerFailedAttempt(ctx, credential.UserID, userIP); err != nil {
+			return nil, err
+		}
+		return nil, ErrStaffInvalidCredentials
+	}
+
+	if needsRehash {
+		if err := s.rehashPassword(ctx, credential.UserID, password); err != nil {
+			logger.Error("rehash staff password failed", zap.Error(err), zap.String("user_id", credential.UserID.String()))
+		}
+	}
+
+	if credential.TOTPEnabled {
+		mfaToken, err := s.createMFAChallenge(ctx, &staffMFAChallenge{UserID: credential.UserID, UserAgent: userAgent})
+		if err != nil {
+			return nil, err
+		}
+		return &StaffLoginResult{MFAToken: mfaToken}, nil
+	}
+
+	tokens, err := s.completeLogin(ctx, credential.UserID, userAgent, userIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StaffLoginResult{Tokens: tokens}, nil
+}
+
+// VerifyMFA завершает вход вторым фактором: TOTP кодом или одноразовым кодом восстановления
+func (s *staffService) VerifyMFA(ctx context.Context, mfaToken string, code string, recoveryCode string, userAgent string, userIP string) (*Tokens, error) {
+	key := staffMFAChallengeKeyPrefix + mfaToken
+
+	value, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrStaffMFAChallengeNotFound
+		}
+		return nil, fmt.Errorf("redis get mfa challenge failed: %w", err)
+	}
+
+	var challenge staffMFAChallenge
+	if err := json.Unmarshal([]byte(value), &challenge); err != nil {
+		return nil, fmt.Errorf("unmarshal mfa challenge failed: %w", err)
+	}
+
+	// Подтверждать вход должен тот же клиент, который ввел пароль
+	if challenge.UserAgent != userAgent {
+		return nil, ErrStaffMFAChallengeNotFound
+	}
+
+	credential, err := s.staffCredentialRepository.GetByUserID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get staff credential failed: %w", err)
+	}
+
+	if credential.IsLocked() {
+		s.deleteMFAChallenge(ctx, key)
+		return nil, ErrStaffAccountLocked
+	}
+
+	if recoveryCode != "" {
+		err = s.staffCredentialRepository.UseRecoveryCode(ctx, credential.UserID, hashRecoveryCode(recoveryCode))
+		if errors.Is(err, domain.ErrNotFound) {
+			err = ErrStaffInvalidRecoveryCode
+		}
+	} else {
+		err = s.verifyTOTP(ctx, credential, code)
+	}
+	if err != nil {
+		if errors.Is(err, ErrStaffInvalidTOTPCode) || errors.Is(err, ErrStaffInvalidRecoveryCode) {
+			if err := s.registerFailedAttempt(ctx, credential.UserID, userIP); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	// Вход по паролю подтверждается только один раз
+	if deleted, err := s.redis.Del(ctx, key).Result(); err != nil {
+		return nil, fmt.Errorf("redis delete mfa challenge failed: %w", err)
+	} else if deleted == 0 {
+		return nil, ErrStaffMFAChallengeNotFound
+	}
+
+	if recoveryCode != "" {
+		logger.Info("staff signed in with recovery code", zap.String("user_id", credential.UserID.String()))
+	}
+
+	return s.completeLogin(ctx, credential.UserID, userAgent, userIP)
+}
+
+// EnrollTOTP выпускает новый секрет TOTP. Второй фактор включается после подтверждения кодом из приложения
+func (s *staffService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error) {
+	credential, err := s.getCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.TOTPEnabled {
+		return nil, ErrStaffTOTPAlreadyEnabled
+	}
+
+	secret := s.otpGenerator.RandomSecret(staffTOTPSecretLength)
+	if err := s.staffCredentialRepository.SetTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, fmt.Errorf("set totp secret failed: %w", err)
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: s.otpGenerator.ProvisioningURI(secret, credential.Login, s.config.TOTPIssuer),
+	}, nil
+}
+
+// ConfirmTOTP включает второй фактор и возвращает коды восстановления. Коды показываются только один раз
+func (s *staffService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	credential, err := s.getCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.TOTPEnabled {
+		return nil, ErrStaffTOTPAlreadyEnabled
+	}
+	if !credential.TOTPSecret.Valid {
+		return nil, ErrStaffTOTPNotEnrolled
+	}
+
+	if err := s.verifyTOTP(ctx, credential, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.staffCredentialRepository.EnableTOTP(ctx, userID); err != nil {
+		return nil, fmt.Errorf("enable totp failed: %w", err)
+	}
+
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes заменяет коды восстановления новыми. Требует действующий TOTP код
+func (s *staffService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	credential, err := s.getCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !credential.TOTPEnabled {
+		return nil, ErrStaffTOTPNotEnrolled
+	}
+
+	if err := s.verifyTOTP(ctx, credential, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// CountRecoveryCodes возвращает количество неиспользованных кодов восстановления
+func (s *staffService) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	if _, err := s.getCredential(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	return s.staffCredentialRepository.CountUnusedRecoveryCodes(ctx, userID)
+}
+
+func (s *staffService) getCredential(ctx context.Context, userID uuid.UUID) (*domain.StaffCredential, error) {
+	credential, err := s.staffCredentialRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("get staff credential failed: %w", err)
+	}
+
+	return credential, nil
+}
+
+// verifyTOTP проверяет код и запоминает его шаг: повторно тот же код не принимается
+func (s *staffService) verifyTOTP(ctx context.Context, credential *domain.StaffCredential, code string) error {
+	if !credential.TOTPSecret.Valid {
+		return ErrStaffTOTPNotEnrolled
+	}
+
+	step, ok := s.otpGenerator.VerifyTOTP(credential.TOTPSecret.String, code, time.Now())
+	if !ok {
+		return ErrStaffInvalidTOTPCode
+	}
+
+	if err := s.staffCredentialRepository.UseTOTPStep(ctx, credential.UserID, step); err != nil {
+		if errors.Is(err, domain.ErrNoRowsAffected) {
+			return ErrStaffInvalidTOTPCode
+		}
+		return fmt.Errorf("use totp step failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *staffService) registerFailedAttempt(ctx context.Context, userID uuid.UUID, userIP string) error {
+	logger.Warn("staff sign in failed", zap.String("user_id", userID.String()), zap.String("ip", userIP))
+
+	if err := s.staffCredentialRepository.RegisterFailedAttempt(ctx, userID, s.config.MaxFailedAttempts, s.config.LockoutDuration); err != nil {
+		return fmt.Errorf("register failed attempt failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *staffService) completeLogin(ctx context.Context, userID uuid.UUID, userAgent string, userIP string) (*Tokens, error) {
+	if err := s.staffCredentialRepository.RegisterSuccessfulLogin(ctx, userID); err != nil {
+		return nil, fmt.Errorf("register successful login failed: %w", err)
+	}
+
+	tokens, err := s.users.createSession(ctx, &userID, nil, &userAgent, &userIP)
+	if err != nil {
+		return nil, fmt.Errorf("create session failed: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *staffService) rehashPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	passwordHash, err := s.hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash password failed: %w", err)
+	}
+
+	return s.staffCredentialRepository.UpdatePasswordHash(ctx, userID, passwordHash)
+}
+
+func (s *staffService) createMFAChallenge(ctx context.Context, challenge *staffMFAChallenge) (string, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("generate mfa token failed: %w", err)
+	}
+
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return "", fmt.Errorf("marshal mfa challenge failed: %w", err)
+	}
+
+	if err := s.redis.Set(ctx, staffMFAChallengeKeyPrefix+token, value, s.config.MFAChallengeTTL).Err(); err != nil {
+		return "", fmt.Errorf("redis save mfa challenge failed: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *staffService) deleteMFAChallenge(ctx context.Context, key string) {
+	if err := s.redis.Del(ctx, key).Err(); err != nil {
+		logger.Error("redis delete mfa challenge failed", zap.Error(err))
+	}
+}
+
+func (s *staffService) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, staffRecoveryCodesCount)
+	records := make([]domain.StaffRecoveryCode, 0, staffRecoveryCodesCount)
+
+	for range staffRecoveryCodesCount {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code failed: %w", err)
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code id failed: %w", err)
+		}
+
+		codes = append(codes, code)
+		records = append(records, domain.StaffRecoveryCode{ID: id, UserID: userID, CodeHash: hashRecoveryCode(code)})
+	}
+
+	if err := s.staffCredentialRepository.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, fmt.Errorf("replace recovery codes failed: %w", err)
+	}
+
+	return codes, nil
+}
+
+func normalizeStaffLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+// randomRecoveryCode генерирует код вида abcde-fghij
+func randomRecoveryCode() (string, error) {
+	b := make([]byte, staffRecoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:staffRecoveryCodeLength]
+
+	return code[:staffRecoveryCodeLength/2] + "-" + code[staffRecoveryCodeLength/2:], nil
+}
+
+// hashRecoveryCode хеширует код восстановления. Коды случайные и длинные, поэтому медленный хеш не нужен
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
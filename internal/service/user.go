@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/vibe-gaming/backend/internal/config"
@@ -28,8 +29,10 @@ type userService struct {
 	refreshSessionRepository repository.RefreshSession
 	cityRepository           repository.Cities
 	userDocumentRepository   repository.UserDocument
+	userRoleRepository       repository.UserRoles
 	hasher                   hash.PasswordHasher
 	tokenManager             auth.TokenManager
+	tokenDenylist            auth.Denylist
 	otpGenerator             otp.Generator
 	esiaClient               *esia.Client
 	authConfig               config.AuthConfig
@@ -40,8 +43,10 @@ func newUserService(userRepository repository.Users,
 	refreshSessionRepository repository.RefreshSession,
 	cityRepository repository.Cities,
 	userDocumentRepository repository.UserDocument,
+	userRoleRepository repository.UserRoles,
 	hasher hash.PasswordHasher,
 	tokenManager auth.TokenManager,
+	tokenDenylist auth.Denylist,
 	otpGenerator otp.Generator,
 	esiaClient *esia.Client,
 	authConfig config.AuthConfig,
@@ -52,8 +57,10 @@ func newUserService(userRepository repository.Users,
 		refreshSessionRepository: refreshSessionRepository,
 		cityRepository:           cityRepository,
 		userDocumentRepository:   userDocumentRepository,
+		userRoleRepository:       userRoleRepository,
 		hasher:                   hasher,
 		tokenManager:             tokenManager,
+		tokenDenylist:            tokenDenylist,
 		otpGenerator:             otpGenerator,
 		esiaClient:               esiaClient,
 		authConfig:               authConfig,
@@ -68,13 +75,45 @@ type Tokens struct {
 	RefreshTTL   time.Duration
 }
 
-func (s *userService) createSession(ctx context.Context, userID *uuid.UUID, userAgent *string, userIP *string) (*Tokens, error) {
+// createSession выпускает пару токенов. Если familyID не передан, начинается новая цепочка ротации
+func (s *userService) createSession(ctx context.Context, userID *uuid.UUID, familyID *uuid.UUID, userAgent *string, userIP *string) (*Tokens, error) {
 	var (
 		res Tokens
 		err error
 	)
 
-	res.AccessToken, res.AccessTTL, err = s.tokenManager.NewJWT(userID)
+	refreshSessionID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh session id failed: %w", err)
+	}
+	if familyID == nil {
+		familyID = &refreshSessionID
+	}
+
+	// Заблокированным и удаленным пользователям токены не выдаются, в том числе при refresh
+	user, err := s.userRepository.GetOneByID(ctx, *userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user failed: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
+	}
+
+	// Роли попадают в access токен и обновляются при каждом refresh
+	userRoles, err := s.userRoleRepository.GetByUserID(ctx, *userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user roles failed: %w", err)
+	}
+	roles := make([]auth.RoleClaim, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		role := auth.RoleClaim{Role: string(userRole.Role)}
+		if userRole.OrganizationID != nil {
+			role.OrganizationID = userRole.OrganizationID.String()
+		}
+		roles = append(roles, role)
+	}
+
+	res.AccessToken, res.AccessTTL, err = s.tokenManager.NewJWT(userID, familyID, roles)
 	if err != nil {
 		return &res, fmt.Errorf("generate access token failed: %w", err)
 	}
@@ -84,13 +123,10 @@ func (s *userService) createSession(ctx context.Context, userID *uuid.UUID, user
 		return &res, fmt.Errorf("generate refresh token failed: %w", err)
 	}
 
-	refreshSessionID, err := uuid.NewV7()
-	if err != nil {
-		return nil, fmt.Errorf("generate refresh session id failed: %w", err)
-	}
 	refreshSession := &domain.RefreshSession{
 		ID:           refreshSessionID,
 		UserID:       *userID,
+		FamilyID:     *familyID,
 		RefreshToken: res.RefreshToken,
 		UserAgent:    *userAgent,
 		IP:           *userIP,
@@ -104,26 +140,39 @@ func (s *userService) createSession(ctx context.Context, userID *uuid.UUID, user
 	return &res, nil
 }
 
-// Auth выполняет авторизацию пользователя через ESIA OAuth
-func (s *userService) Auth(ctx context.Context, code string, userAgent string, userIP string) (*Tokens, error) {
+// Auth выполняет авторизацию пользователя через ESIA OAuth. codeVerifier и nonce выданы при редиректе на ESIA
+func (s *userService) Auth(ctx context.Context, code string, codeVerifier string, nonce string, userAgent string, userIP string) (*Tokens, error) {
 	// Обменять код на токен
-	tokenResp, err := s.esiaClient.ExchangeCodeForToken(code)
+	tokenResp, err := s.esiaClient.ExchangeCodeForToken(code, codeVerifier)
 	if err != nil {
 		return nil, fmt.Errorf("exchange code for token failed: %w", err)
 	}
 
+	// id_token должен быть подписан ESIA и выпущен для этого входа
+	idToken, err := s.esiaClient.VerifyIDToken(tokenResp.IDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token failed: %w", err)
+	}
+
 	// Получить информацию о пользователе
 	userInfo, err := s.esiaClient.GetUserInfo(tokenResp.AccessToken)
 	if err != nil {
 		return nil, fmt.Errorf("get user info failed: %w", err)
 	}
 
+	// Данные userinfo должны относиться к тому же пользователю, что и id_token
+	if idToken.Subject != userInfo.OID {
+		return nil, fmt.Errorf("%w: subject %s does not match userinfo oid %s", esia.ErrIDTokenInvalid, idToken.Subject, userInfo.OID)
+	}
+
 	// Проверить, существует ли пользователь с таким ESIA OID
 	existingUser, err := s.userRepository.GetByExternalID(ctx, userInfo.OID)
 	if err != nil && !errors.Is(err, domain.ErrNotFound) {
 		return nil, fmt.Errorf("get user by external id failed: %w", err)
 	}
 
+	profile := profileFromUserInfo(userInfo)
+
 	var userID uuid.UUID
 
 	if existingUser == nil {
@@ -133,83 +182,37 @@ func (s *userService) Auth(ctx context.Context, code string, userAgent string, u
 			return nil, fmt.Errorf("generate user id failed: %w", err)
 		}
 
-		newUser := &domain.User{
-			ID: userID,
-			ExternalID: sql.NullString{
-				String: userInfo.OID,
-				Valid:  true,
-			},
-			FirstName: sql.NullString{
-				String: userInfo.FirstName,
-				Valid:  userInfo.FirstName != "",
-			},
-			LastName: sql.NullString{
-				String: userInfo.LastName,
-				Valid:  userInfo.LastName != "",
-			},
-			MiddleName: sql.NullString{
-				String: userInfo.MiddleName,
-				Valid:  userInfo.MiddleName != "",
-			},
-			SNILS: sql.NullString{
-				String: userInfo.SNILS,
-				Valid:  userInfo.SNILS != "",
-			},
-			Email: sql.NullString{
-				String: userInfo.Email,
-				Valid:  userInfo.Email != "",
-			},
-			PhoneNumber: sql.NullString{
-				String: userInfo.Mobile,
-				Valid:  userInfo.Mobile != "",
-			},
-		}
-
-		documents := []domain.UserDocument{
-			{
-				ID:             uuid.New(),
-				UserID:         userID,
-				DocumentType:   "passport",
-				DocumentNumber: "9800 123456\n выдан МВД по РС(Я) в г. Якутске, 01.01.2014, 140-002",
-				CreatedAt:      time.Now(),
-				UpdatedAt:      time.Now(),
-			},
-			{
-				ID:             uuid.New(),
-				UserID:         userID,
-				DocumentType:   "snils",
-				DocumentNumber: "1234567890",
-				CreatedAt:      time.Now(),
-				UpdatedAt:      time.Now(),
-			},
-			{
-				ID:             uuid.New(),
-				UserID:         userID,
-				DocumentType:   "registration",
-				DocumentNumber: "Республика Саха (Якутия), Якутск, ул. Петра-Алексеева, д. 100, кв.100",
-				CreatedAt:      time.Now(),
-				UpdatedAt:      time.Now(),
-			},
-		}
-
-		for _, document := range documents {
-			err := s.CreateDocument(ctx, &document)
-			if err != nil {
-				logger.Error("create document failed", zap.Error(err))
-				return nil, fmt.Errorf("create document failed: %w", err)
-			}
+		newUser := profile
+		newUser.ID = userID
+		newUser.ExternalID = sql.NullString{
+			String: userInfo.OID,
+			Valid:  true,
 		}
 
 		if err := s.userRepository.Create(ctx, newUser); err != nil {
 			return nil, fmt.Errorf("create user failed: %w", err)
 		}
 	} else {
-		// Пользователь уже существует
+		// Пользователь уже существует - обновляем профиль из ЕСИА
 		userID = existingUser.ID
+		if err := s.syncProfile(ctx, existingUser, profile); err != nil {
+			return nil, fmt.Errorf("sync user profile failed: %w", err)
+		}
+	}
+
+	// Администраторы из конфига получают роль при входе, чтобы было кому выдавать остальные роли
+	if slices.Contains(s.authConfig.AdminExternalIDs, userInfo.OID) {
+		adminRole := &domain.UserRole{UserID: userID, Role: domain.RoleAdministrator}
+		if adminRole.ID, err = uuid.NewV7(); err != nil {
+			return nil, fmt.Errorf("generate user role id failed: %w", err)
+		}
+		if err := s.userRoleRepository.Create(ctx, adminRole); err != nil && !errors.Is(err, domain.ErrDuplicateEntry) {
+			return nil, fmt.Errorf("grant administrator role failed: %w", err)
+		}
 	}
 
 	// Создать сессию для пользователя
-	tokens, err := s.createSession(ctx, &userID, &userAgent, &userIP)
+	tokens, err := s.createSession(ctx, &userID, nil, &userAgent, &userIP)
 	if err != nil {
 		return nil, fmt.Errorf("create session failed: %w", err)
 	}
@@ -217,6 +220,296 @@ func (s *userService) Auth(ctx context.Context, code string, userAgent string, u
 	return tokens, nil
 }
 
+// profileFromUserInfo переводит ответ /userinfo ЕСИА в поля профиля пользователя
+func profileFromUserInfo(userInfo *esia.UserInfo) *domain.User {
+	user := &domain.User{
+		FirstName:   nullString(userInfo.FirstName),
+		LastName:    nullString(userInfo.LastName),
+		MiddleName:  nullString(userInfo.MiddleName),
+		SNILS:       nullString(userInfo.SNILS),
+		Email:       nullString(userInfo.Email),
+		PhoneNumber: nullString(userInfo.Mobile),
+		Gender:      nullString(userInfo.Gender),
+		INN:         nullString(userInfo.INN),
+		Trusted:     userInfo.Trusted,
+		Citizenship: nullString(userInfo.Citizenship),
+	}
+
+	// ЕСИА отдает дату рождения в формате дд.мм.гггг
+	if userInfo.BirthDate != "" {
+		birthDate, err := time.Parse("02.01.2006", userInfo.BirthDate)
+		if err != nil {
+			logger.Warn("invalid esia birth date", zap.String("oid", userInfo.OID), zap.String("birth_date", userInfo.BirthDate))
+		} else {
+			user.BirthDate = &birthDate
+		}
+	}
+
+	return user
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// syncProfile обновляет профиль вернувшегося пользователя и записывает изменившиеся поля.
+// Группы, подтвержденные по прежнему СНИЛС, проверяются заново по новому
+func (s *userService) syncProfile(ctx context.Context, user *domain.User, profile *domain.User) error {
+	changes := user.ApplyProfile(profile)
+
+	snilsChanged := false
+	for i := range changes {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate profile change id failed: %w", err)
+		}
+		changes[i].ID = id
+		if changes[i].Field == domain.ProfileFieldSNILS {
+			snilsChanged = true
+		}
+	}
+
+	groups := make([]domain.GroupType, 0, len(user.GroupType))
+	before := slices.Clone(user.GroupType)
+	if snilsChanged {
+		for i := range user.GroupType {
+			user.GroupType[i] = domain.UserGroup{
+				Type:   user.GroupType[i].Type,
+				Status: domain.VerificationStatusPending,
+			}
+			groups = append(groups, user.GroupType[i].Type)
+		}
+	}
+
+	events, err := newUserGroupEvents(user.ID, before, user.GroupType, domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceProfileSync,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepository.UpdateProfile(ctx, user, changes, events); err != nil {
+		return err
+	}
+
+	if len(changes) > 0 {
+		fields := make([]string, 0, len(changes))
+		for _, change := range changes {
+			fields = append(fields, change.Field)
+		}
+		logger.Info("user profile changed in esia", zap.String("user_id", user.ID.String()), zap.Strings("fields", fields))
+	}
+
+	if snilsChanged {
+		s.enqueueSocialGroupCheck(ctx, user.ID, user.SNILS.String, groups)
+	}
+
+	return nil
+}
+
+// RefreshTokens обменивает refresh токен на новую пару токенов. Каждый refresh токен одноразовый:
+// повторное предъявление уже обменянного токена означает его утечку, и вся цепочка отзывается
+func (s *userService) RefreshTokens(ctx context.Context, refreshToken uuid.UUID, userAgent string, userIP string) (*Tokens, error) {
+	session, err := s.refreshSessionRepository.GetByRefreshToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("get refresh session failed: %w", err)
+	}
+
+	if session.IsDeleted() {
+		return nil, ErrRefreshTokenNotFound
+	}
+
+	if session.IsUsed() {
+		logger.Error("refresh token reuse detected, revoking session family",
+			zap.String("user_id", session.UserID.String()),
+			zap.String("family_id", session.FamilyID.String()),
+			zap.String("ip", userIP))
+		if err := s.refreshSessionRepository.DeleteByFamilyID(ctx, session.FamilyID); err != nil {
+			return nil, fmt.Errorf("revoke refresh session family failed: %w", err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if session.IsExpired() {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	// Токен привязан к клиенту, которому он был выдан
+	if session.UserAgent != userAgent || session.IP != userIP {
+		logger.Error("refresh token presented by another client, revoking session family",
+			zap.String("user_id", session.UserID.String()),
+			zap.String("family_id", session.FamilyID.String()),
+			zap.String("expected_ip", session.IP),
+			zap.String("ip", userIP))
+		if err := s.refreshSessionRepository.DeleteByFamilyID(ctx, session.FamilyID); err != nil {
+			return nil, fmt.Errorf("revoke refresh session family failed: %w", err)
+		}
+		return nil, ErrRefreshSessionMismatch
+	}
+
+	if err := s.refreshSessionRepository.MarkUsed(ctx, session.ID); err != nil {
+		if errors.Is(err, domain.ErrNoRowsAffected) {
+			// Параллельный запрос успел обменять этот же токен
+			if err := s.refreshSessionRepository.DeleteByFamilyID(ctx, session.FamilyID); err != nil {
+				return nil, fmt.Errorf("revoke refresh session family failed: %w", err)
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, fmt.Errorf("mark refresh session used failed: %w", err)
+	}
+
+	tokens, err := s.createSession(ctx, &session.UserID, &session.FamilyID, &userAgent, &userIP)
+	if err != nil {
+		return nil, fmt.Errorf("create session failed: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// GetSessions возвращает активные сессии пользователя на всех устройствах
+func (s *userService) GetSessions(ctx context.Context, userID uuid.UUID) ([]domain.UserSession, error) {
+	sessions, err := s.refreshSessionRepository.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get active sessions failed: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession отзывает одну сессию пользователя: её refresh токены перестают обмениваться сразу
+func (s *userService) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	if err := s.refreshSessionRepository.DeleteByUserIDAndFamilyID(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("revoke session failed: %w", err)
+	}
+
+	// Access токены сессии перестают приниматься сразу, не дожидаясь истечения
+	if err := s.tokenDenylist.RevokeSession(ctx, sessionID.String()); err != nil {
+		return fmt.Errorf("revoke session access tokens failed: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions завершает сессии пользователя на всех устройствах
+func (s *userService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	sessions, err := s.refreshSessionRepository.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get active sessions failed: %w", err)
+	}
+
+	if err := s.refreshSessionRepository.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("revoke all sessions failed: %w", err)
+	}
+
+	// Отзыв пользователя не задевает токены, выпущенные в секунду отзыва, поэтому сессии отзываются и по sid
+	for _, session := range sessions {
+		if err := s.tokenDenylist.RevokeSession(ctx, session.ID.String()); err != nil {
+			return fmt.Errorf("revoke session access tokens failed: %w", err)
+		}
+	}
+	if err := s.tokenDenylist.RevokeUser(ctx, userID.String()); err != nil {
+		return fmt.Errorf("revoke user access tokens failed: %w", err)
+	}
+
+	return nil
+}
+
+// Logout отзывает access токен, с которым пришел запрос, и refresh токены его сессии
+func (s *userService) Logout(ctx context.Context, claims *auth.Claims) error {
+	if claims.ExpiresAt != nil {
+		if err := s.tokenDenylist.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return fmt.Errorf("revoke access token failed: %w", err)
+		}
+	}
+
+	// У токенов, выпущенных до появления claim sid, сессию определить нельзя
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return fmt.Errorf("parse user id failed: %w", err)
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return fmt.Errorf("parse session id failed: %w", err)
+	}
+
+	if err := s.RevokeSession(ctx, userID, sessionID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// IsAccessTokenRevoked проверяет, не отозван ли access токен при выходе, завершении сессии или блокировке пользователя
+func (s *userService) IsAccessTokenRevoked(ctx context.Context, claims *auth.Claims) (bool, error) {
+	return s.tokenDenylist.IsRevoked(ctx, claims)
+}
+
+// Deactivate блокирует пользователя: завершает все его сессии и отзывает выданные токены
+func (s *userService) Deactivate(ctx context.Context, userID uuid.UUID) error {
+	if err := s.userRepository.SetDeactivated(ctx, userID, true); err != nil {
+		if errors.Is(err, domain.ErrNoRowsAffected) {
+			return s.userStateError(ctx, userID)
+		}
+		return fmt.Errorf("deactivate user failed: %w", err)
+	}
+
+	return s.RevokeAllSessions(ctx, userID)
+}
+
+// Activate снимает блокировку пользователя
+func (s *userService) Activate(ctx context.Context, userID uuid.UUID) error {
+	if err := s.userRepository.SetDeactivated(ctx, userID, false); err != nil {
+		if errors.Is(err, domain.ErrNoRowsAffected) {
+			return s.userStateError(ctx, userID)
+		}
+		return fmt.Errorf("activate user failed: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет пользователя: завершает все его сессии и отзывает выданные токены
+func (s *userService) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := s.userRepository.Delete(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("delete user failed: %w", err)
+	}
+
+	return s.RevokeAllSessions(ctx, userID)
+}
+
+// userStateError объясняет, почему смена блокировки не изменила пользователя
+func (s *userService) userStateError(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("get user failed: %w", err)
+	}
+
+	if user.DeletedAt != nil {
+		return ErrUserNotFound
+	}
+	if user.DeactivatedAt != nil {
+		return ErrUserAlreadyDeactivated
+	}
+
+	return ErrUserNotDeactivated
+}
+
 func (s *userService) GetOneByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	user, err := s.userRepository.GetOneByID(ctx, id)
 	if err != nil {
@@ -233,6 +526,115 @@ func (s *userService) GetOneByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	return user, nil
 }
 
+// GetProfileChanges возвращает журнал изменений профиля из ЕСИА, новые записи первыми
+func (s *userService) GetProfileChanges(ctx context.Context, userID uuid.UUID) ([]domain.UserProfileChange, error) {
+	if _, err := s.userRepository.GetOneByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	return s.userRepository.GetProfileChanges(ctx, userID)
+}
+
+// EligibilityProfileInput - данные профиля для проверки права на льготы. nil - пользователь не указал значение
+type EligibilityProfileInput struct {
+	MonthlyIncome      *int64
+	ChildrenCount      *int
+	DisabilityCategory *domain.DisabilityCategory
+}
+
+// UpdateEligibilityProfile сохраняет доход, количество детей и категорию инвалидности пользователя
+func (s *userService) UpdateEligibilityProfile(ctx context.Context, userID uuid.UUID, input EligibilityProfileInput) (*domain.User, error) {
+	if input.DisabilityCategory != nil && !input.DisabilityCategory.IsValid() {
+		return nil, ErrInvalidDisabilityCategory
+	}
+
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	user.MonthlyIncome = input.MonthlyIncome
+	user.ChildrenCount = input.ChildrenCount
+	user.DisabilityCategory = input.DisabilityCategory
+
+	if err := s.userRepository.UpdateEligibilityProfile(ctx, user); err != nil {
+		return nil, fmt.Errorf("update eligibility profile failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// ApplyQuestionnaire дополняет профиль ответами анкеты, заполненной до входа. Уже указанные в профиле город,
+// количество детей и категория инвалидности не меняются, группы из анкеты добавляются к группам пользователя
+// и отправляются на проверку
+func (s *userService) ApplyQuestionnaire(ctx context.Context, userID uuid.UUID, answers *domain.QuestionnaireAnswers) error {
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	if user.ChildrenCount == nil {
+		user.ChildrenCount = answers.ChildrenCount
+	}
+	if user.DisabilityCategory == nil {
+		if answers.DisabilityCategory != nil {
+			user.DisabilityCategory = answers.DisabilityCategory
+		} else if answers.Statuses != nil && !slices.Contains(answers.Statuses, domain.QuestionnaireStatusDisabled) {
+			none := domain.DisabilityCategoryNone
+			user.DisabilityCategory = &none
+		}
+	}
+	if err := s.userRepository.UpdateEligibilityProfile(ctx, user); err != nil {
+		return fmt.Errorf("update eligibility profile failed: %w", err)
+	}
+
+	groups := slices.Clone(user.GroupType)
+	var added domain.GroupTypeList
+	for _, groupType := range answers.GroupTypes() {
+		if !slices.ContainsFunc(groups, func(g domain.UserGroup) bool { return g.Type == groupType }) {
+			groups = append(groups, domain.UserGroup{Type: groupType, Status: domain.VerificationStatusPending})
+			added = append(added, groupType)
+		}
+	}
+
+	events, err := newUserGroupEvents(userID, user.GroupType, groups, domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceUser,
+	})
+	if err != nil {
+		return err
+	}
+
+	if user.CityID == nil && answers.CityID != nil {
+		if _, err := s.cityRepository.GetOneByID(ctx, *answers.CityID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return ErrCityNotFound
+			}
+			return fmt.Errorf("get city by id failed: %w", err)
+		}
+		err = s.userRepository.UpdateUserInfo(ctx, userID, *answers.CityID, groups, events)
+	} else if len(added) > 0 {
+		err = s.userRepository.UpdateUserGroups(ctx, userID, groups, events)
+	}
+	if err != nil {
+		return fmt.Errorf("update user groups failed: %w", err)
+	}
+
+	if user.SNILS.Valid {
+		s.enqueueSocialGroupCheck(ctx, userID, user.SNILS.String, added)
+	}
+
+	return nil
+}
+
 func (s *userService) UpdateUserInfo(ctx context.Context, userID uuid.UUID, cityID uuid.UUID, groups domain.GroupTypeList) error {
 	if _, err := s.cityRepository.GetOneByID(ctx, cityID); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
@@ -266,45 +668,96 @@ func (s *userService) UpdateUserInfo(ctx context.Context, userID uuid.UUID, city
 		}
 	}
 
-	err = s.userRepository.UpdateUserInfo(ctx, userID, cityID, groupsList)
+	events, err := newUserGroupEvents(userID, user.GroupType, groupsList, domain.UserGroupUpdate{
+		Source: domain.UserGroupEventSourceUser,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepository.UpdateUserInfo(ctx, userID, cityID, groupsList, events)
 	if err != nil {
 		return fmt.Errorf("update user info failed: %w", err)
 	}
 
 	// Запускаем асинхронную задачу для проверки социальных групп
-	if user.SNILS.Valid && len(groups) > 0 {
-		groupTypes := make([]string, len(groups))
-		for i, g := range groups {
-			groupTypes[i] = string(g)
-		}
-
-		asynqClient := client.GetClient(ctx)
-		if asynqClient != nil {
-			checkTask, err := task.NewCheckSocialGroupTask(userID, user.SNILS.String, groupTypes)
-			if err != nil {
-				// Логируем ошибку, но не возвращаем её, чтобы не прерывать основной процесс
-				logger.Error("failed to create check social group task", zap.Error(err))
-			} else {
-				if _, err := asynqClient.Enqueue(checkTask); err != nil {
-					logger.Error("failed to enqueue check social group task", zap.Error(err))
-				}
-			}
-		}
+	if user.SNILS.Valid {
+		s.enqueueSocialGroupCheck(ctx, userID, user.SNILS.String, groups)
 	}
 
 	return nil
 }
 
-func (s *userService) UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList) error {
-	return s.userRepository.UpdateUserGroups(ctx, userID, groups)
+// enqueueSocialGroupCheck ставит в очередь проверку групп пользователя во внешнем сервисе.
+// Ошибки только логируются, чтобы не прерывать основной процесс
+func (s *userService) enqueueSocialGroupCheck(ctx context.Context, userID uuid.UUID, snils string, groups []domain.GroupType) {
+	if snils == "" || len(groups) == 0 {
+		return
+	}
+
+	groupTypes := make([]string, len(groups))
+	for i, g := range groups {
+		groupTypes[i] = string(g)
+	}
+
+	asynqClient := client.GetClient(ctx)
+	if asynqClient == nil {
+		return
+	}
+
+	checkTask, err := task.NewCheckSocialGroupTask(userID, snils, groupTypes)
+	if err != nil {
+		logger.Error("failed to create check social group task", zap.Error(err))
+		return
+	}
+	if _, err := asynqClient.Enqueue(checkTask); err != nil {
+		logger.Error("failed to enqueue check social group task", zap.Error(err))
+	}
 }
 
-func (s *userService) CreateDocument(ctx context.Context, document *domain.UserDocument) error {
-	return s.userDocumentRepository.Create(ctx, document)
+// UpdateUserGroups сохраняет новые статусы групп пользователя. Через этот метод проходят решения сервиса проверки,
+// модераторов и задачи истечения сроков: каждый переход статуса записывается в журнал с источником изменения
+func (s *userService) UpdateUserGroups(ctx context.Context, userID uuid.UUID, groups domain.UserGroupList, update domain.UserGroupUpdate) error {
+	user, err := s.userRepository.GetOneByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	events, err := newUserGroupEvents(userID, user.GroupType, groups, update)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepository.UpdateUserGroups(ctx, userID, groups, events)
+}
+
+// GetGroupEvents возвращает журнал переходов статусов групп пользователя
+func (s *userService) GetGroupEvents(ctx context.Context, userID uuid.UUID) ([]domain.UserGroupEvent, error) {
+	if _, err := s.userRepository.GetOneByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	return s.userRepository.GetGroupEvents(ctx, userID)
+}
+
+func newUserGroupEvents(userID uuid.UUID, before, after domain.UserGroupList, update domain.UserGroupUpdate) ([]domain.UserGroupEvent, error) {
+	events := domain.UserGroupEvents(userID, before, after, update)
+	for i := range events {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate user group event id failed: %w", err)
+		}
+		events[i].ID = id
+	}
+
+	return events, nil
 }
 
-func (s *userService) GetDocumentsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error) {
-	return s.userDocumentRepository.GetByUserID(ctx, userID)
+func (s *userService) GetWithVerifiedGroups(ctx context.Context, afterID uuid.UUID, limit int) ([]domain.User, error) {
+	return s.userRepository.GetWithVerifiedGroups(ctx, afterID, limit)
 }
 
 func (s *userService) GeneratePensionerCertificatePDF(ctx context.Context, userID uuid.UUID) ([]byte, error) {
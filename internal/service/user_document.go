@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/validator"
+)
+
+type UserDocumentInput struct {
+	DocumentType   domain.UserDocumentType
+	DocumentNumber string
+	IssuedAt       *time.Time
+	IssuedBy       string
+	ExpiresAt      *time.Time
+}
+
+type userDocumentService struct {
+	documentRepository repository.UserDocument
+	userRepository     repository.Users
+}
+
+func newUserDocumentService(documentRepository repository.UserDocument, userRepository repository.Users) *userDocumentService {
+	return &userDocumentService{
+		documentRepository: documentRepository,
+		userRepository:     userRepository,
+	}
+}
+
+// Create добавляет документ пользователя. У пользователя может быть только один документ каждого типа
+func (s *userDocumentService) Create(ctx context.Context, userID uuid.UUID, input UserDocumentInput) (*domain.UserDocument, error) {
+	if !input.DocumentType.IsValid() {
+		return nil, ErrInvalidUserDocumentType
+	}
+
+	number, err := normalizeUserDocument(input)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := s.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		if document.DocumentType == input.DocumentType {
+			return nil, ErrUserDocumentExists
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate user document id failed: %w", err)
+	}
+
+	now := time.Now()
+	document := &domain.UserDocument{
+		ID:             id,
+		UserID:         userID,
+		DocumentType:   input.DocumentType,
+		DocumentNumber: number,
+		IssuedAt:       input.IssuedAt,
+		IssuedBy:       nullString(strings.TrimSpace(input.IssuedBy)),
+		ExpiresAt:      input.ExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.documentRepository.Create(ctx, document); err != nil {
+		return nil, fmt.Errorf("create user document failed: %w", err)
+	}
+
+	return document, nil
+}
+
+func (s *userDocumentService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserDocument, error) {
+	if _, err := s.userRepository.GetOneByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	return s.documentRepository.GetByUserID(ctx, userID)
+}
+
+// Update меняет номер и реквизиты документа. Тип документа не меняется
+func (s *userDocumentService) Update(ctx context.Context, userID uuid.UUID, id uuid.UUID, input UserDocumentInput) (*domain.UserDocument, error) {
+	document, err := s.documentRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserDocumentNotFound
+		}
+		return nil, fmt.Errorf("get user document by id failed: %w", err)
+	}
+	if document.UserID != userID {
+		return nil, ErrUserDocumentNotFound
+	}
+
+	input.DocumentType = document.DocumentType
+	number, err := normalizeUserDocument(input)
+	if err != nil {
+		return nil, err
+	}
+
+	document.DocumentNumber = number
+	document.IssuedAt = input.IssuedAt
+	document.IssuedBy = nullString(strings.TrimSpace(input.IssuedBy))
+	document.ExpiresAt = input.ExpiresAt
+	document.UpdatedAt = time.Now()
+
+	if err := s.documentRepository.Update(ctx, document); err != nil {
+		return nil, fmt.Errorf("update user document failed: %w", err)
+	}
+
+	return document, nil
+}
+
+func (s *userDocumentService) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if err := s.documentRepository.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrUserDocumentNotFound
+		}
+		return fmt.Errorf("delete user document failed: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeUserDocument проверяет номер и даты документа и возвращает номер в едином формате:
+// паспорт - 9800 123456, СНИЛС - 123-456-789 00 с проверкой контрольного числа
+func normalizeUserDocument(input UserDocumentInput) (string, error) {
+	if input.IssuedAt != nil && input.IssuedAt.After(time.Now()) {
+		return "", ErrInvalidUserDocumentDates
+	}
+	if input.IssuedAt != nil && input.ExpiresAt != nil && !input.ExpiresAt.After(*input.IssuedAt) {
+		return "", ErrInvalidUserDocumentDates
+	}
+
+	switch input.DocumentType {
+	case domain.UserDocumentTypePassport:
+		number, ok := domain.NormalizePassport(input.DocumentNumber)
+		if !ok || !validator.IsValidPassport(input.DocumentNumber) {
+			return "", ErrInvalidPassport
+		}
+		return number, nil
+	case domain.UserDocumentTypeSNILS:
+		number, ok := domain.NormalizeSNILS(input.DocumentNumber)
+		if !ok || !validator.IsValidSNILS(input.DocumentNumber) {
+			return "", ErrInvalidSNILS
+		}
+		return number, nil
+	default:
+		number := strings.TrimSpace(input.DocumentNumber)
+		if number == "" {
+			return "", ErrInvalidUserDocumentNumber
+		}
+		return number, nil
+	}
+}
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/repository"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"github.com/vibe-gaming/backend/pkg/storage"
+	"go.uber.org/zap"
+)
+
+// verificationDocumentKeyPrefix - каталог хранилища со сканами документов
+const verificationDocumentKeyPrefix = "verification-documents"
+
+// verificationDocumentTypes - допустимые типы файлов и расширения, с которыми они сохраняются.
+// Тип определяется по содержимому файла, Content-Type из запроса не учитывается
+var verificationDocumentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type VerificationDocumentUploadInput struct {
+	UserID    uuid.UUID
+	GroupType domain.GroupType
+	FileName  string
+	Size      int64
+	File      io.Reader
+}
+
+type HouseholdRelationProofInput struct {
+	UserID   uuid.UUID
+	MemberID uuid.UUID
+	FileName string
+	Size     int64
+	File     io.Reader
+}
+
+type verificationDocumentService struct {
+	documentRepository repository.VerificationDocuments
+	memberRepository   repository.HouseholdMembers
+	users              Users
+	storage            storage.Storage
+	checkerConfig      config.SocialGroupCheckerConfig
+	maxSize            int64
+}
+
+func newVerificationDocumentService(documentRepository repository.VerificationDocuments,
+	memberRepository repository.HouseholdMembers,
+	users Users,
+	storage storage.Storage,
+	checkerConfig config.SocialGroupCheckerConfig,
+	maxSize int64,
+) *verificationDocumentService {
+	return &verificationDocumentService{
+		documentRepository: documentRepository,
+		memberRepository:   memberRepository,
+		users:              users,
+		storage:            storage,
+		checkerConfig:      checkerConfig,
+		maxSize:            maxSize,
+	}
+}
+
+// Upload сохраняет скан документа для группы, которую не удалось подтвердить по СНИЛС,
+// и возвращает группу в ожидание до решения модератора
+func (s *verificationDocumentService) Upload(ctx context.Context, input VerificationDocumentUploadInput) (*domain.VerificationDocument, error) {
+	if !input.GroupType.IsValid() {
+		return nil, ErrInvalidGroupType
+	}
+	if input.Size <= 0 {
+		return nil, ErrInvalidDocumentFile
+	}
+	if input.Size > s.maxSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	user, err := s.users.GetOneByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	groupIndex := -1
+	for i, group := range user.GroupType {
+		if group.Type == input.GroupType {
+			groupIndex = i
+			break
+		}
+	}
+	if groupIndex == -1 || !awaitsVerification(user.GroupType[groupIndex].Status) {
+		return nil, ErrGroupNotAwaitingVerification
+	}
+
+	document := &domain.VerificationDocument{
+		UserID:    input.UserID,
+		GroupType: input.GroupType,
+		FileName:  input.FileName,
+		Size:      input.Size,
+	}
+	if err := s.create(ctx, document, input.File); err != nil {
+		return nil, err
+	}
+
+	group := &user.GroupType[groupIndex]
+	group.Status = domain.VerificationStatusPending
+	group.RejectedAt = nil
+	group.ErrorMessage = ""
+
+	update := domain.UserGroupUpdate{
+		Source:  domain.UserGroupEventSourceUser,
+		Payload: verificationDocumentPayload(document),
+	}
+	if err := s.users.UpdateUserGroups(ctx, user.ID, user.GroupType, update); err != nil {
+		return nil, fmt.Errorf("update user groups failed: %w", err)
+	}
+
+	return document, nil
+}
+
+// UploadRelationProof сохраняет документ, подтверждающий родство с членом семьи (свидетельство о браке
+// или о рождении). До решения модератора группы члена семьи не учитываются
+func (s *verificationDocumentService) UploadRelationProof(ctx context.Context, input HouseholdRelationProofInput) (*domain.VerificationDocument, error) {
+	if input.Size <= 0 {
+		return nil, ErrInvalidDocumentFile
+	}
+	if input.Size > s.maxSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	member, err := s.getMember(ctx, input.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.UserID != input.UserID {
+		return nil, ErrHouseholdMemberNotFound
+	}
+	if !member.RelationStatus.AwaitsProof() {
+		return nil, ErrHouseholdRelationNotAwaitingDoc
+	}
+	if err := checkRelationClaims(ctx, s.memberRepository, member); err != nil {
+		return nil, err
+	}
+
+	document := &domain.VerificationDocument{
+		UserID:            input.UserID,
+		HouseholdMemberID: &member.ID,
+		FileName:          input.FileName,
+		Size:              input.Size,
+	}
+	if err := s.create(ctx, document, input.File); err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepository.UpdateRelationStatus(ctx, member.ID, domain.HouseholdRelationStatusPending, nil); err != nil {
+		return nil, fmt.Errorf("update household member relation status failed: %w", err)
+	}
+
+	return document, nil
+}
+
+// create проверяет тип файла по содержимому, сохраняет файл в хранилище и документ в ожидании решения модератора
+func (s *verificationDocumentService) create(ctx context.Context, document *domain.VerificationDocument, input io.Reader) error {
+	// Первых 512 байт достаточно, чтобы определить тип файла по сигнатуре
+	head := make([]byte, 512)
+	n, err := io.ReadFull(input, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read document failed: %w", err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := verificationDocumentTypes[contentType]
+	if !ok {
+		return ErrInvalidDocumentFile
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate document id failed: %w", err)
+	}
+
+	document.ID = id
+	document.StorageKey = verificationDocumentKeyPrefix + "/" + document.UserID.String() + "/" + id.String() + ext
+	document.ContentType = contentType
+	document.Status = domain.VerificationDocumentStatusPending
+	document.CreatedAt = time.Now()
+
+	file := io.MultiReader(bytes.NewReader(head), input)
+	if err := s.storage.Put(ctx, document.StorageKey, file, document.Size, document.ContentType); err != nil {
+		return fmt.Errorf("put document to storage failed: %w", err)
+	}
+
+	if err := s.documentRepository.Create(ctx, document); err != nil {
+		if deleteErr := s.storage.Delete(ctx, document.StorageKey); deleteErr != nil {
+			logger.Error("delete orphaned document failed", zap.String("key", document.StorageKey), zap.Error(deleteErr))
+		}
+		return fmt.Errorf("create verification document failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *verificationDocumentService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.VerificationDocument, error) {
+	return s.documentRepository.GetByUserID(ctx, userID)
+}
+
+// GetUserDocumentFile отдает файл документа его владельцу. Чужие документы не находятся
+func (s *verificationDocumentService) GetUserDocumentFile(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.VerificationDocument, io.ReadCloser, error) {
+	document, err := s.getByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if document.UserID != userID {
+		return nil, nil, ErrVerificationDocumentNotFound
+	}
+
+	return s.openFile(ctx, document)
+}
+
+// GetQueue возвращает документы с указанным статусом, старые первыми, и их общее количество
+func (s *verificationDocumentService) GetQueue(ctx context.Context, status domain.VerificationDocumentStatus, page, limit int) ([]domain.VerificationDocument, int64, error) {
+	documents, err := s.documentRepository.GetByStatus(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get verification documents failed: %w", err)
+	}
+
+	total, err := s.documentRepository.CountByStatus(ctx, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count verification documents failed: %w", err)
+	}
+
+	return documents, total, nil
+}
+
+func (s *verificationDocumentService) GetFile(ctx context.Context, id uuid.UUID) (*domain.VerificationDocument, io.ReadCloser, error) {
+	document, err := s.getByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.openFile(ctx, document)
+}
+
+// Approve подтверждает документ и группу пользователя так же, как подтверждение по СНИЛС:
+// статус verified со сроком действия ValidityPeriod. Документ о родстве подтверждает родство с членом семьи
+func (s *verificationDocumentService) Approve(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error {
+	document, err := s.getByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if document.HouseholdMemberID != nil {
+		return s.approveRelation(ctx, document, moderatorID)
+	}
+
+	if err := s.review(ctx, document, moderatorID, domain.VerificationDocumentStatusApproved, nil); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.checkerConfig.ValidityPeriod)
+
+	return s.updateGroup(ctx, document, func(group *domain.UserGroup) {
+		group.Status = domain.VerificationStatusVerified
+		group.VerifiedAt = &now
+		group.ExpiresAt = &expiresAt
+		group.RejectedAt = nil
+		group.ErrorMessage = ""
+		group.ExternalID = domain.DocumentExternalID(document.ID)
+	})
+}
+
+// Reject отклоняет документ. Группа отклоняется, только если она все еще ждет решения:
+// группу могли подтвердить другим документом или повторной проверкой по СНИЛС
+func (s *verificationDocumentService) Reject(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	if reason == "" {
+		return ErrRejectReasonRequired
+	}
+
+	document, err := s.getByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.review(ctx, document, moderatorID, domain.VerificationDocumentStatusRejected, &reason); err != nil {
+		return err
+	}
+
+	if document.HouseholdMemberID != nil {
+		return s.rejectRelation(ctx, document)
+	}
+
+	now := time.Now()
+
+	return s.updateGroup(ctx, document, func(group *domain.UserGroup) {
+		if group.Status != domain.VerificationStatusPending {
+			return
+		}
+		group.Status = domain.VerificationStatusRejected
+		group.RejectedAt = &now
+		group.ErrorMessage = reason
+	})
+}
+
+func (s *verificationDocumentService) review(ctx context.Context, document *domain.VerificationDocument, moderatorID uuid.UUID,
+	status domain.VerificationDocumentStatus, reason *string,
+) error {
+	if document.Status != domain.VerificationDocumentStatusPending {
+		return ErrVerificationDocumentReviewed
+	}
+
+	now := time.Now()
+	document.Status = status
+	document.RejectReason = reason
+	document.ReviewedBy = &moderatorID
+	document.ReviewedAt = &now
+
+	if err := s.documentRepository.Review(ctx, document); err != nil {
+		// Документ успели рассмотреть параллельно
+		if errors.Is(err, domain.ErrNoRowsAffected) {
+			return ErrVerificationDocumentReviewed
+		}
+		return fmt.Errorf("review verification document failed: %w", err)
+	}
+
+	fields := []zap.Field{
+		zap.String("document_id", document.ID.String()),
+		zap.String("user_id", document.UserID.String()),
+		zap.String("group_type", string(document.GroupType)),
+		zap.String("status", string(status)),
+		zap.String("moderator_id", moderatorID.String()),
+	}
+	if document.HouseholdMemberID != nil {
+		fields = append(fields, zap.String("member_id", document.HouseholdMemberID.String()))
+	}
+	logger.Info("verification document reviewed", fields...)
+
+	return nil
+}
+
+// approveRelation подтверждает родство с членом семьи. Если родство с тем же СНИЛС уже подтверждено
+// на допустимом числе других аккаунтов, документ не одобряется
+func (s *verificationDocumentService) approveRelation(ctx context.Context, document *domain.VerificationDocument, moderatorID uuid.UUID) error {
+	member, err := s.getMember(ctx, *document.HouseholdMemberID)
+	if err != nil {
+		return err
+	}
+	if err := checkRelationClaims(ctx, s.memberRepository, member); err != nil {
+		return err
+	}
+
+	if err := s.review(ctx, document, moderatorID, domain.VerificationDocumentStatusApproved, nil); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.memberRepository.UpdateRelationStatus(ctx, member.ID, domain.HouseholdRelationStatusVerified, &now); err != nil {
+		return fmt.Errorf("update household member relation status failed: %w", err)
+	}
+
+	return nil
+}
+
+// rejectRelation отклоняет родство, только если оно все еще ждет решения: его могли подтвердить другим документом.
+// Удаленный член семьи пропускается
+func (s *verificationDocumentService) rejectRelation(ctx context.Context, document *domain.VerificationDocument) error {
+	member, err := s.getMember(ctx, *document.HouseholdMemberID)
+	if err != nil {
+		if errors.Is(err, ErrHouseholdMemberNotFound) {
+			return nil
+		}
+		return err
+	}
+	if member.RelationStatus != domain.HouseholdRelationStatusPending {
+		return nil
+	}
+
+	if err := s.memberRepository.UpdateRelationStatus(ctx, member.ID, domain.HouseholdRelationStatusRejected, nil); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("update household member relation status failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *verificationDocumentService) getMember(ctx context.Context, id uuid.UUID) (*domain.HouseholdMember, error) {
+	member, err := s.memberRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrHouseholdMemberNotFound
+		}
+		return nil, fmt.Errorf("get household member by id failed: %w", err)
+	}
+
+	return member, nil
+}
+
+// updateGroup применяет решение модератора к группе пользователя. Если пользователь успел убрать группу
+// из профиля, одобренная группа добавляется заново: документ подтверждает ее независимо от профиля
+func (s *verificationDocumentService) updateGroup(ctx context.Context, document *domain.VerificationDocument, apply func(group *domain.UserGroup)) error {
+	user, err := s.users.GetOneByID(ctx, document.UserID)
+	if err != nil {
+		return fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	groupIndex := -1
+	for i, group := range user.GroupType {
+		if group.Type == document.GroupType {
+			groupIndex = i
+			break
+		}
+	}
+	if groupIndex == -1 {
+		if document.Status != domain.VerificationDocumentStatusApproved {
+			return nil
+		}
+		user.GroupType = append(user.GroupType, domain.UserGroup{Type: document.GroupType})
+		groupIndex = len(user.GroupType) - 1
+	}
+
+	apply(&user.GroupType[groupIndex])
+
+	update := domain.UserGroupUpdate{
+		Source:  domain.UserGroupEventSourceModerator,
+		ActorID: document.ReviewedBy,
+		Payload: verificationDocumentPayload(document),
+	}
+	if err := s.users.UpdateUserGroups(ctx, user.ID, user.GroupType, update); err != nil {
+		return fmt.Errorf("update user groups failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *verificationDocumentService) getByID(ctx context.Context, id uuid.UUID) (*domain.VerificationDocument, error) {
+	document, err := s.documentRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrVerificationDocumentNotFound
+		}
+		return nil, fmt.Errorf("get verification document failed: %w", err)
+	}
+
+	return document, nil
+}
+
+func (s *verificationDocumentService) openFile(ctx context.Context, document *domain.VerificationDocument) (*domain.VerificationDocument, io.ReadCloser, error) {
+	file, err := s.storage.Get(ctx, document.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get document from storage failed: %w", err)
+	}
+
+	return document, file, nil
+}
+
+// verificationDocumentPayload - данные документа для журнала переходов статусов групп
+func verificationDocumentPayload(document *domain.VerificationDocument) json.RawMessage {
+	payload, err := json.Marshal(struct {
+		DocumentID   uuid.UUID                         `json:"document_id"`
+		Status       domain.VerificationDocumentStatus `json:"status"`
+		RejectReason *string                           `json:"reject_reason,omitempty"`
+	}{
+		DocumentID:   document.ID,
+		Status:       document.Status,
+		RejectReason: document.RejectReason,
+	})
+	if err != nil {
+		return nil
+	}
+	return payload
+}
+
+// awaitsVerification - группу можно подтвердить документом: она не подтверждена по СНИЛС,
+// отклонена реестром, не проверена из-за недоступности сервиса или истекла
+func awaitsVerification(status domain.VerificationStatus) bool {
+	switch status {
+	case domain.VerificationStatusPending,
+		domain.VerificationStatusRejected,
+		domain.VerificationStatusFailed,
+		domain.VerificationStatusExpired:
+		return true
+	}
+	return false
+}
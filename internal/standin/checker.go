@@ -0,0 +1,63 @@
+package standin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	socialgroupchecker "github.com/vibe-gaming/backend/internal/service/social_group_checker"
+)
+
+// check - API сервиса проверки социальных групп. Группа подтверждается, если она есть у гражданина
+// с этим СНИЛС в фикстуре; для неизвестного СНИЛС все группы отклоняются
+func (s *Server) check(w http.ResponseWriter, r *http.Request) {
+	if requestID := r.Header.Get(socialgroupchecker.RequestIDHeader); requestID != "" {
+		w.Header().Set(socialgroupchecker.RequestIDHeader, requestID)
+	}
+
+	var req socialgroupchecker.CheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	if applyFailure(w, r, s.takeFailure(EndpointCheck, req.SNILS)) {
+		return
+	}
+
+	if req.SNILS == "" || len(req.Groups) == 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "snils and groups are required"})
+		return
+	}
+
+	for _, group := range req.Groups {
+		if !socialgroupchecker.IsValidGroup(group) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unknown group " + string(group)})
+			return
+		}
+	}
+
+	s.mu.Lock()
+	citizen := s.bySNILS[normalizeSNILS(req.SNILS)]
+	s.mu.Unlock()
+
+	response := socialgroupchecker.CheckResponse{
+		SNILS:   req.SNILS,
+		Results: make([]socialgroupchecker.GroupResult, 0, len(req.Groups)),
+	}
+	for _, group := range req.Groups {
+		status := socialgroupchecker.StatusRejected
+		if citizen != nil && citizen.HasGroup(group) {
+			status = socialgroupchecker.StatusConfirmed
+		}
+		response.Results = append(response.Results, socialgroupchecker.GroupResult{
+			Group:  group,
+			Status: status,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) health(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, socialgroupchecker.HealthResponse{Status: "ok"})
+}
@@ -0,0 +1,311 @@
+package standin
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vibe-gaming/backend/internal/esia"
+)
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><title>Стенд ЕСИА</title></head>
+<body>
+<h1>Вход через стенд ЕСИА</h1>
+<ul>
+{{range .}}<li><a href="{{.URL}}">{{.Name}}</a> (OID {{.OID}}{{if .SNILS}}, СНИЛС {{.SNILS}}{{end}})</li>
+{{end}}</ul>
+</body>
+</html>`))
+
+type loginPageEntry struct {
+	URL   string
+	Name  string
+	OID   string
+	SNILS string
+}
+
+// authorize - страница входа ЕСИА. Гражданин выбирается параметром login_hint (OID или СНИЛС),
+// без него стенд показывает список граждан. После выбора сразу выполняется перенаправление с code
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	if applyFailure(w, r, s.takeFailure(EndpointAuthorize, "")) {
+		return
+	}
+
+	query := r.URL.Query()
+	clientID := query.Get("client_id")
+	redirectURI := query.Get("redirect_uri")
+
+	if s.config.ClientID != "" && clientID != s.config.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := url.Parse(redirectURI)
+	if err != nil || !redirect.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	state := query.Get("state")
+	if query.Get("response_type") != "code" {
+		redirectWithError(w, r, redirect, state, "unsupported_response_type")
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != esia.CodeChallengeMethodS256 {
+		redirectWithError(w, r, redirect, state, "invalid_request")
+		return
+	}
+
+	citizen, ok := s.findCitizen(query.Get("login_hint"))
+	if !ok {
+		s.renderLoginPage(w, r)
+		return
+	}
+
+	code := randomString(16)
+
+	s.mu.Lock()
+	s.codes[code] = &authCode{
+		oid:           citizen.OID,
+		clientID:      clientID,
+		redirectURI:   redirectURI,
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		expiresAt:     time.Now().Add(s.config.CodeTTL),
+	}
+	s.mu.Unlock()
+
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", state)
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// findCitizen ищет гражданина по OID или СНИЛС. Без подсказки выбирается единственный гражданин фикстуры
+func (s *Server) findCitizen(hint string) (*Citizen, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hint == "" {
+		if len(s.citizens) == 1 {
+			return s.citizens[0], true
+		}
+		return nil, false
+	}
+
+	if citizen, ok := s.byOID[hint]; ok {
+		return citizen, true
+	}
+
+	citizen, ok := s.bySNILS[normalizeSNILS(hint)]
+
+	return citizen, ok
+}
+
+func (s *Server) renderLoginPage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	entries := make([]loginPageEntry, 0, len(s.citizens))
+	for _, citizen := range s.citizens {
+		query := r.URL.Query()
+		query.Set("login_hint", citizen.OID)
+		entries = append(entries, loginPageEntry{
+			URL:   r.URL.Path + "?" + query.Encode(),
+			Name:  strings.TrimSpace(citizen.LastName + " " + citizen.FirstName + " " + citizen.MiddleName),
+			OID:   citizen.OID,
+			SNILS: citizen.SNILS,
+		})
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = loginPage.Execute(w, entries)
+}
+
+func redirectWithError(w http.ResponseWriter, r *http.Request, redirect *url.URL, state string, code string) {
+	values := redirect.Query()
+	values.Set("error", code)
+	values.Set("state", state)
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token обменивает code на access token и id_token. Проверяются client_id, redirect_uri и PKCE,
+// code одноразовый
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	failure := s.takeFailure(EndpointToken, "")
+	if applyFailure(w, r, failure) {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, "invalid_request", "malformed form")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeOAuthError(w, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+
+	s.mu.Lock()
+	code, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(code.expiresAt) {
+		writeOAuthError(w, "invalid_grant", "code is invalid or expired")
+		return
+	}
+	if r.PostForm.Get("client_id") != code.clientID || r.PostForm.Get("redirect_uri") != code.redirectURI {
+		writeOAuthError(w, "invalid_grant", "client_id or redirect_uri mismatch")
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	expected := base64.RawURLEncoding.EncodeToString(challenge[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(code.codeChallenge)) != 1 {
+		writeOAuthError(w, "invalid_grant", "code_verifier does not match code_challenge")
+		return
+	}
+
+	signingKey := s.key
+	if failure != nil && failure.Mode == FailureModeInvalidIDToken {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	idToken, err := s.signIDToken(r, signingKey, code)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	token := randomString(24)
+
+	s.mu.Lock()
+	s.tokens[token] = &accessToken{
+		oid:       code.oid,
+		expiresAt: time.Now().Add(s.config.TokenTTL),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, esia.TokenResponse{
+		AccessToken: token,
+		IDToken:     idToken,
+		ExpiresIn:   int(s.config.TokenTTL.Seconds()),
+		TokenType:   "Bearer",
+	})
+}
+
+func (s *Server) signIDToken(r *http.Request, key *rsa.PrivateKey, code *authCode) (string, error) {
+	now := time.Now()
+	claims := esia.IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer(r),
+			Subject:   code.oid,
+			Audience:  jwt.ClaimStrings{code.clientID},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.TokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        randomString(8),
+		},
+		Nonce: code.nonce,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("sign id_token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *Server) issuer(r *http.Request) string {
+	s.mu.Lock()
+	issuer := s.config.Issuer
+	s.mu.Unlock()
+
+	if issuer != "" {
+		return issuer
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	if applyFailure(w, r, s.takeFailure(EndpointJWKS, "")) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": s.keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.PublicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.PublicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// userInfo возвращает профиль гражданина по access token
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	if applyFailure(w, r, s.takeFailure(EndpointUserInfo, "")) {
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	access, ok := s.tokens[token]
+	var citizen *Citizen
+	if ok && time.Now().Before(access.expiresAt) {
+		citizen = s.byOID[access.oid]
+	}
+	s.mu.Unlock()
+
+	if citizen == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, citizen.UserInfo)
+}
+
+func writeOAuthError(w http.ResponseWriter, code string, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
@@ -0,0 +1,196 @@
+package standin
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vibe-gaming/backend/internal/esia"
+	socialgroupchecker "github.com/vibe-gaming/backend/internal/service/social_group_checker"
+)
+
+//go:embed fixtures/citizens.json
+var defaultFixture []byte
+
+// Fixture - граждане, от имени которых стенд выдает токены ЕСИА и отвечает сервис проверки групп,
+// и сбои, которые действуют с момента запуска
+type Fixture struct {
+	Citizens []Citizen `json:"citizens"`
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// Citizen - учетная запись ЕСИА. Поля профиля совпадают с ответом /userinfo,
+// Groups - социальные группы, которые подтверждает сервис проверки
+type Citizen struct {
+	esia.UserInfo
+	Groups []socialgroupchecker.SocialGroup `json:"groups"`
+}
+
+func (c *Citizen) HasGroup(group socialgroupchecker.SocialGroup) bool {
+	for _, g := range c.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoint - эндпоинт стенда, на который можно внедрить сбой
+type Endpoint string
+
+const (
+	EndpointAuthorize Endpoint = "authorize"
+	EndpointToken     Endpoint = "token"
+	EndpointUserInfo  Endpoint = "userinfo"
+	EndpointJWKS      Endpoint = "jwks"
+	EndpointCheck     Endpoint = "check"
+)
+
+type FailureMode string
+
+const (
+	// FailureModeStatus - ответ со статусом Status. Если статус не указан, запрос только задерживается на Delay
+	FailureModeStatus FailureMode = ""
+	// FailureModeDrop - соединение обрывается, не дописав ответ
+	FailureModeDrop FailureMode = "drop"
+	// FailureModeInvalidIDToken - /aas/oauth2/te отвечает id_token, подписанным неизвестным ключом
+	FailureModeInvalidIDToken FailureMode = "invalid_id_token"
+)
+
+// Failure - сбой эндпоинта. Times - сколько запросов подряд сбоят, 0 - все.
+// SNILS ограничивает сбой проверкой одного гражданина
+type Failure struct {
+	Endpoint Endpoint    `json:"endpoint"`
+	Mode     FailureMode `json:"mode,omitempty"`
+	Status   int         `json:"status,omitempty"`
+	Body     string      `json:"body,omitempty"`
+	Delay    Duration    `json:"delay,omitempty"`
+	Times    int         `json:"times,omitempty"`
+	SNILS    string      `json:"snils,omitempty"`
+}
+
+func (f *Failure) validate() error {
+	switch f.Endpoint {
+	case EndpointAuthorize, EndpointToken, EndpointUserInfo, EndpointJWKS, EndpointCheck:
+	default:
+		return fmt.Errorf("unknown endpoint %q", f.Endpoint)
+	}
+
+	switch f.Mode {
+	case FailureModeStatus, FailureModeDrop:
+	case FailureModeInvalidIDToken:
+		if f.Endpoint != EndpointToken {
+			return errors.New("invalid_id_token failure applies to token endpoint only")
+		}
+	default:
+		return fmt.Errorf("unknown failure mode %q", f.Mode)
+	}
+
+	if f.SNILS != "" && f.Endpoint != EndpointCheck {
+		return errors.New("snils filter applies to check endpoint only")
+	}
+
+	return nil
+}
+
+// Duration - time.Duration, который в JSON записывается строкой вида "1.5s"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"2s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// DefaultFixture возвращает набор граждан, встроенный в бинарник
+func DefaultFixture() *Fixture {
+	fixture, err := ParseFixture(strings.NewReader(string(defaultFixture)))
+	if err != nil {
+		panic(fmt.Sprintf("standin: invalid embedded fixture: %v", err))
+	}
+
+	return fixture
+}
+
+func LoadFixture(path string) (*Fixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open fixture: %w", err)
+	}
+	defer file.Close()
+
+	return ParseFixture(file)
+}
+
+// ParseFixture читает JSON фикстуру и проверяет, что OID и СНИЛС граждан не повторяются
+func ParseFixture(r io.Reader) (*Fixture, error) {
+	var fixture Fixture
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("decode fixture: %w", err)
+	}
+
+	oids := make(map[string]bool, len(fixture.Citizens))
+	snils := make(map[string]bool, len(fixture.Citizens))
+	for i, citizen := range fixture.Citizens {
+		if citizen.OID == "" {
+			return nil, fmt.Errorf("citizen #%d: oid is required", i)
+		}
+		if oids[citizen.OID] {
+			return nil, fmt.Errorf("citizen #%d: duplicate oid %s", i, citizen.OID)
+		}
+		oids[citizen.OID] = true
+
+		if citizen.SNILS != "" {
+			key := normalizeSNILS(citizen.SNILS)
+			if snils[key] {
+				return nil, fmt.Errorf("citizen #%d: duplicate snils %s", i, citizen.SNILS)
+			}
+			snils[key] = true
+		}
+
+		for _, group := range citizen.Groups {
+			if !socialgroupchecker.IsValidGroup(group) {
+				return nil, fmt.Errorf("citizen #%d: unknown group %s", i, group)
+			}
+		}
+	}
+
+	for i := range fixture.Failures {
+		if err := fixture.Failures[i].validate(); err != nil {
+			return nil, fmt.Errorf("failure #%d: %w", i, err)
+		}
+	}
+
+	return &fixture, nil
+}
+
+// normalizeSNILS оставляет в СНИЛС только цифры: приложение и фикстура могут записывать его по-разному
+func normalizeSNILS(snils string) string {
+	var b strings.Builder
+	for _, r := range snils {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,285 @@
+// Package standin - локальная замена ЕСИА и сервиса проверки социальных групп для разработки
+// и сквозных тестов без внешней сети. Граждане и сбои задаются фикстурой
+package standin
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCodeTTL  = 5 * time.Minute
+	defaultTokenTTL = time.Hour
+)
+
+type Config struct {
+	// Issuer - значение iss в id_token. Если не задан, берется адрес, по которому пришел запрос
+	Issuer string
+	// ClientID - ожидаемый client_id. Если не задан, принимается любой
+	ClientID string
+	// SigningKey - ключ подписи id_token. Если не задан, генерируется при запуске
+	SigningKey *rsa.PrivateKey
+	CodeTTL    time.Duration
+	TokenTTL   time.Duration
+}
+
+type authCode struct {
+	oid           string
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	expiresAt     time.Time
+}
+
+type accessToken struct {
+	oid       string
+	expiresAt time.Time
+}
+
+type activeFailure struct {
+	Failure
+	remaining int
+}
+
+// Server обслуживает эндпоинты ЕСИА (/aas/oauth2/ac, /aas/oauth2/te, /aas/oauth2/jwks, /userinfo),
+// сервиса проверки групп (/api/v1/check, /health) и управления стендом (/_standin/...)
+type Server struct {
+	mu sync.Mutex
+
+	config   Config
+	key      *rsa.PrivateKey
+	keyID    string
+	citizens []*Citizen
+	byOID    map[string]*Citizen
+	bySNILS  map[string]*Citizen
+	codes    map[string]*authCode
+	tokens   map[string]*accessToken
+	failures []*activeFailure
+
+	mux *http.ServeMux
+}
+
+func New(fixture *Fixture, cfg Config) (*Server, error) {
+	if cfg.CodeTTL == 0 {
+		cfg.CodeTTL = defaultCodeTTL
+	}
+	if cfg.TokenTTL == 0 {
+		cfg.TokenTTL = defaultTokenTTL
+	}
+
+	key := cfg.SigningKey
+	if key == nil {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("generate signing key: %w", err)
+		}
+	}
+
+	s := &Server{
+		config:  cfg,
+		key:     key,
+		keyID:   keyID(key),
+		byOID:   make(map[string]*Citizen, len(fixture.Citizens)),
+		bySNILS: make(map[string]*Citizen, len(fixture.Citizens)),
+		codes:   make(map[string]*authCode),
+		tokens:  make(map[string]*accessToken),
+	}
+
+	for i := range fixture.Citizens {
+		citizen := fixture.Citizens[i]
+		s.citizens = append(s.citizens, &citizen)
+		s.byOID[citizen.OID] = &citizen
+		if citizen.SNILS != "" {
+			s.bySNILS[normalizeSNILS(citizen.SNILS)] = &citizen
+		}
+	}
+
+	for _, failure := range fixture.Failures {
+		if err := s.InjectFailure(failure); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /aas/oauth2/ac", s.authorize)
+	s.mux.HandleFunc("POST /aas/oauth2/te", s.token)
+	s.mux.HandleFunc("GET /aas/oauth2/jwks", s.jwks)
+	s.mux.HandleFunc("GET /userinfo", s.userInfo)
+	s.mux.HandleFunc("POST /api/v1/check", s.check)
+	s.mux.HandleFunc("GET /health", s.health)
+	s.mux.HandleFunc("GET /_standin/citizens", s.listCitizens)
+	s.mux.HandleFunc("POST /_standin/failures", s.injectFailure)
+	s.mux.HandleFunc("DELETE /_standin/failures", s.resetFailures)
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetIssuer меняет iss выдаваемых id_token. Нужен, когда адрес стенда известен только после запуска
+func (s *Server) SetIssuer(issuer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.Issuer = issuer
+}
+
+// PublicKey - ключ проверки подписи id_token
+func (s *Server) PublicKey() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+// InjectFailure добавляет сбой. Сбои проверяются в порядке добавления, срабатывает первый подходящий
+func (s *Server) InjectFailure(failure Failure) error {
+	if err := failure.validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &activeFailure{Failure: failure, remaining: failure.Times})
+
+	return nil
+}
+
+// ResetFailures убирает все сбои, включая заданные фикстурой
+func (s *Server) ResetFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// takeFailure возвращает сбой для запроса и уменьшает счетчик его срабатываний
+func (s *Server) takeFailure(endpoint Endpoint, snils string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, failure := range s.failures {
+		if failure.Endpoint != endpoint {
+			continue
+		}
+		if failure.SNILS != "" && normalizeSNILS(failure.SNILS) != normalizeSNILS(snils) {
+			continue
+		}
+
+		taken := failure.Failure
+		if failure.Times > 0 {
+			failure.remaining--
+			if failure.remaining == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		return &taken
+	}
+
+	return nil
+}
+
+// applyFailure выполняет сбой. Возвращает true, если ответ уже записан и обработчик должен завершиться
+func applyFailure(w http.ResponseWriter, r *http.Request, failure *Failure) bool {
+	if failure == nil {
+		return false
+	}
+
+	if failure.Delay > 0 {
+		select {
+		case <-time.After(time.Duration(failure.Delay)):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch failure.Mode {
+	case FailureModeDrop:
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				// Обрывок статусной строки не дает http.Transport молча повторить запрос
+				// на новом соединении, как он делает при закрытии без единого байта
+				_, _ = conn.Write([]byte("HTTP/1.1 "))
+				conn.Close()
+				return true
+			}
+		}
+		w.WriteHeader(http.StatusBadGateway)
+		return true
+	case FailureModeStatus:
+		if failure.Status == 0 {
+			return false
+		}
+		body := failure.Body
+		if body == "" {
+			body = http.StatusText(failure.Status)
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(failure.Status)
+		_, _ = w.Write([]byte(body))
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) listCitizens(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	citizens := make([]Citizen, 0, len(s.citizens))
+	for _, citizen := range s.citizens {
+		citizens = append(citizens, *citizen)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, citizens)
+}
+
+func (s *Server) injectFailure(w http.ResponseWriter, r *http.Request) {
+	var failure Failure
+	if err := json.NewDecoder(r.Body).Decode(&failure); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if err := s.InjectFailure(failure); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resetFailures(w http.ResponseWriter, _ *http.Request) {
+	s.ResetFailures()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func randomString(length int) string {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("standin: read random: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func keyID(key *rsa.PrivateKey) string {
+	sum := sha256.Sum256(key.PublicKey.N.Bytes())
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
@@ -0,0 +1,101 @@
+// Package standintest поднимает стенд ЕСИА и сервиса проверки групп на httptest.Server для тестов
+package standintest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/standin"
+)
+
+type Server struct {
+	*standin.Server
+	URL string
+
+	httpServer *httptest.Server
+}
+
+// NewServer запускает стенд с фикстурой (nil - встроенный набор) и останавливает его по завершении теста
+func NewServer(tb testing.TB, fixture *standin.Fixture) *Server {
+	tb.Helper()
+
+	if fixture == nil {
+		fixture = standin.DefaultFixture()
+	}
+
+	srv, err := standin.New(fixture, standin.Config{})
+	if err != nil {
+		tb.Fatalf("standintest: %v", err)
+	}
+
+	httpServer := httptest.NewServer(srv)
+	tb.Cleanup(httpServer.Close)
+
+	srv.SetIssuer(httpServer.URL)
+
+	return &Server{
+		Server:     srv,
+		URL:        httpServer.URL,
+		httpServer: httpServer,
+	}
+}
+
+// ESIAConfig - настройки клиента ЕСИА, направленные на стенд, с проверкой подписи id_token по JWKS
+func (s *Server) ESIAConfig(clientID string, redirectURI string) config.ESIAConfig {
+	return config.ESIAConfig{
+		BaseURL:     s.URL,
+		ClientID:    clientID,
+		RedirectURI: redirectURI,
+		Scope:       "openid profile email",
+		Issuer:      s.URL,
+		JWKSURL:     s.URL + "/aas/oauth2/jwks",
+	}
+}
+
+// CheckerBaseURL - адрес для клиента сервиса проверки социальных групп
+func (s *Server) CheckerBaseURL() string {
+	return s.URL
+}
+
+// Authorize проходит страницу входа за гражданина с OID или СНИЛС loginHint и возвращает адрес,
+// на который стенд перенаправил браузер (redirect_uri с code и state)
+func (s *Server) Authorize(authURL string, loginHint string) (*url.URL, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse auth url: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("login_hint", loginHint)
+	u.RawQuery = query.Encode()
+
+	client := *s.httpServer.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil, err
+	}
+	if location.Query().Get("code") == "" {
+		return nil, errors.New("no code in redirect: " + location.Query().Get("error"))
+	}
+
+	return location, nil
+}
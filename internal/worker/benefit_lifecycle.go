@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/queue/task"
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type benefitLifecycle struct {
+	services *service.Services
+	config   config.BenefitsConfig
+}
+
+func newBenefitLifecycle(services *service.Services, config config.BenefitsConfig) *benefitLifecycle {
+	return &benefitLifecycle{
+		services: services,
+		config:   config,
+	}
+}
+
+// ProcessExpiry переводит в архив опубликованные льготы с прошедшим valid_to и предупреждает по email
+// пользователей, у которых в избранном есть льготы, срок действия которых заканчивается в ближайшие EndingNoticeBefore
+func (l *benefitLifecycle) ProcessExpiry(ctx context.Context) error {
+	now := time.Now()
+
+	archived, err := l.services.Benefits.ArchiveExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("archive expired benefits failed: %w", err)
+	}
+	for _, id := range archived {
+		logger.Info("expired benefit archived", zap.String("benefit_id", id.String()))
+	}
+
+	notices, err := l.services.Benefits.GetEndingNotices(ctx, now, l.config.EndingNoticeBefore)
+	if err != nil {
+		return fmt.Errorf("get benefit ending notices failed: %w", err)
+	}
+	for _, notice := range notices {
+		// Одно письмо пользователю на каждую дату окончания льготы: задача с тем же TaskID
+		// хранится после выполнения EndingNoticeBefore и повторно не ставится. Если срок продлят, придет новое письмо
+		taskID := "benefitEnding:" + notice.UserID.String() + ":" + notice.BenefitID.String() + ":" + strconv.FormatInt(notice.ValidTo.Unix(), 10)
+		enqueueTask(ctx, func() (*asynq.Task, error) {
+			return task.NewSendBenefitEndingEmailTask(notice.Email, notice.Title, notice.ValidTo,
+				asynq.TaskID(taskID),
+				asynq.Retention(l.config.EndingNoticeBefore),
+			)
+		})
+	}
+
+	return nil
+}
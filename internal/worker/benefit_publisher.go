@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vibe-gaming/backend/internal/service"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type benefitPublisher struct {
+	services *service.Services
+}
+
+func newBenefitPublisher(services *service.Services) *benefitPublisher {
+	return &benefitPublisher{
+		services: services,
+	}
+}
+
+// PublishScheduled публикует одобренные льготы, у которых наступило время publish_at,
+// и переводит в архив опубликованные льготы, у которых наступило время unpublish_at
+func (p *benefitPublisher) PublishScheduled(ctx context.Context) error {
+	now := time.Now()
+
+	published, err := p.services.Benefits.PublishScheduled(ctx, now)
+	if err != nil {
+		return fmt.Errorf("publish scheduled benefits failed: %w", err)
+	}
+	for _, id := range published {
+		logger.Info("scheduled benefit published", zap.String("benefit_id", id.String()))
+	}
+
+	archived, err := p.services.Benefits.ArchiveScheduled(ctx, now)
+	if err != nil {
+		return fmt.Errorf("archive scheduled benefits failed: %w", err)
+	}
+	for _, id := range archived {
+		logger.Info("scheduled benefit archived", zap.String("benefit_id", id.String()))
+	}
+
+	return nil
+}
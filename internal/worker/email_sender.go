@@ -3,8 +3,10 @@ package worker
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/domain"
 	emailProvider "github.com/vibe-gaming/backend/pkg/email"
 )
 
@@ -43,3 +45,53 @@ func (s *emailSender) SendUserVerificationEmail(ctx context.Context, email strin
 
 	return nil
 }
+
+type groupExpiredEmailInput struct {
+	Groups []string
+}
+
+// SendGroupExpiredEmail сообщает, что подтверждение групп истекло и льготы по ним больше не показываются
+func (s *emailSender) SendGroupExpiredEmail(ctx context.Context, email string, groups []string) error {
+	subject := "Истек срок подтверждения льготной категории"
+
+	titles := make([]string, 0, len(groups))
+	for _, group := range groups {
+		titles = append(titles, domain.GroupType(group).Title())
+	}
+
+	templateInput := groupExpiredEmailInput{Groups: titles}
+	sendInput := emailProvider.SendEmailInput{Subject: subject, To: email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.GroupExpired, templateInput); err != nil {
+		return fmt.Errorf("generate email failed: %w", err)
+	}
+
+	if err := s.sender.Send(sendInput); err != nil {
+		return fmt.Errorf("send email failed: %w", err)
+	}
+
+	return nil
+}
+
+type benefitEndingEmailInput struct {
+	Title   string
+	ValidTo string
+}
+
+// SendBenefitEndingEmail предупреждает, что срок действия льготы из избранного скоро закончится
+func (s *emailSender) SendBenefitEndingEmail(ctx context.Context, email string, title string, validTo time.Time) error {
+	subject := "Заканчивается срок действия льготы"
+
+	templateInput := benefitEndingEmailInput{Title: title, ValidTo: validTo.Format("02.01.2006")}
+	sendInput := emailProvider.SendEmailInput{Subject: subject, To: email}
+
+	if err := sendInput.GenerateBodyFromHTML(s.config.Templates.BenefitEnding, templateInput); err != nil {
+		return fmt.Errorf("generate email failed: %w", err)
+	}
+
+	if err := s.sender.Send(sendInput); err != nil {
+		return fmt.Errorf("send email failed: %w", err)
+	}
+
+	return nil
+}
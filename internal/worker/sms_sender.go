@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	smsProvider "github.com/vibe-gaming/backend/pkg/sms"
+)
+
+type smsSender struct {
+	sender smsProvider.Sender
+}
+
+func newSMSSender(sender smsProvider.Sender) *smsSender {
+	return &smsSender{
+		sender: sender,
+	}
+}
+
+func (s *smsSender) SendVerificationSMS(ctx context.Context, phone string, verificationCode string) error {
+	sendInput := smsProvider.SendSMSInput{
+		To:   phone,
+		Text: fmt.Sprintf("Код подтверждения: %s. Никому не сообщайте его", verificationCode),
+	}
+
+	if err := s.sender.Send(sendInput); err != nil {
+		return fmt.Errorf("send sms failed: %w", err)
+	}
+
+	return nil
+}
@@ -2,29 +2,49 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/vibe-gaming/backend/internal/config"
 	"github.com/vibe-gaming/backend/internal/domain"
+	"github.com/vibe-gaming/backend/internal/queue/task"
 	"github.com/vibe-gaming/backend/internal/service"
 	socialgroupchecker "github.com/vibe-gaming/backend/internal/service/social_group_checker"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"go.uber.org/zap"
 )
 
-func newSocialGroupChecker(client *socialgroupchecker.Client, services *service.Services) SocialGroupChecker {
+// Сообщения для пользователя, когда группу не удалось проверить
+const (
+	checkUnavailableMessage = "Сервис проверки льготных категорий временно недоступен. Отправьте категорию на проверку повторно позже"
+	checkFailedMessage      = "Не удалось проверить льготную категорию. Проверьте СНИЛС в профиле и отправьте категорию на проверку повторно"
+)
+
+// expiryBatchSize - сколько пользователей с подтвержденными группами обрабатывается за один запрос к БД
+const expiryBatchSize = 500
+
+func newSocialGroupChecker(client *socialgroupchecker.Client, services *service.Services, config config.SocialGroupCheckerConfig) SocialGroupChecker {
 	return &socialGroupChecker{
 		client:   client,
 		services: services,
+		config:   config,
 	}
 }
 
 type socialGroupChecker struct {
 	client   *socialgroupchecker.Client
 	services *service.Services
+	config   config.SocialGroupCheckerConfig
 }
 
-func (s *socialGroupChecker) CheckGroups(ctx context.Context, snils string, groups []socialgroupchecker.SocialGroup) (*socialgroupchecker.CheckResponse, error) {
-	return s.client.CheckGroups(ctx, snils, groups)
+func (s *socialGroupChecker) CheckGroups(ctx context.Context, requestID string, snils string, groups []socialgroupchecker.SocialGroup) (*socialgroupchecker.CheckResponse, error) {
+	return s.client.CheckGroups(ctx, requestID, snils, groups)
 }
 
 // CheckAndUpdateUserGroups проверяет социальные группы пользователя и обновляет их статус в БД
@@ -35,59 +55,114 @@ func (s *socialGroupChecker) CheckAndUpdateUserGroups(ctx context.Context, userI
 		return fmt.Errorf("get user by id failed: %w", err)
 	}
 
+	return s.checkAndUpdateGroups(ctx, snils, groupTypes, user.GroupType,
+		func(groups domain.UserGroupList, update domain.UserGroupUpdate) error {
+			return s.services.Users.UpdateUserGroups(ctx, userID, groups, update)
+		},
+		zap.String("user_id", userID.String()),
+	)
+}
+
+// CheckAndUpdateHouseholdMemberGroups проверяет социальные группы члена семьи пользователя по его СНИЛС.
+// Если член семьи уже удален, проверка пропускается
+func (s *socialGroupChecker) CheckAndUpdateHouseholdMemberGroups(ctx context.Context, memberID uuid.UUID, snils string, groupTypes []string) error {
+	member, err := s.services.Household.GetMember(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, service.ErrHouseholdMemberNotFound) {
+			logger.Info("household member deleted, skip social group check", zap.String("member_id", memberID.String()))
+			return nil
+		}
+		return fmt.Errorf("get household member by id failed: %w", err)
+	}
+
+	err = s.checkAndUpdateGroups(ctx, snils, groupTypes, member.GroupType,
+		func(groups domain.UserGroupList, update domain.UserGroupUpdate) error {
+			return s.services.Household.UpdateGroups(ctx, memberID, groups, update)
+		},
+		zap.String("user_id", member.UserID.String()),
+		zap.String("member_id", memberID.String()),
+	)
+	if errors.Is(err, service.ErrHouseholdMemberNotFound) {
+		// Член семьи удален, пока шла проверка
+		return nil
+	}
+
+	return err
+}
+
+// checkAndUpdateGroups проверяет группы groupTypes во внешнем сервисе и сохраняет новые статусы через save.
+// current - группы владельца на момент начала проверки
+func (s *socialGroupChecker) checkAndUpdateGroups(ctx context.Context, snils string, groupTypes []string, current domain.UserGroupList,
+	save func(groups domain.UserGroupList, update domain.UserGroupUpdate) error, logFields ...zap.Field,
+) error {
 	// Формируем список групп для проверки
 	socialGroups := make([]socialgroupchecker.SocialGroup, 0, len(groupTypes))
 	for _, gt := range groupTypes {
 		socialGroups = append(socialGroups, socialgroupchecker.SocialGroup(gt))
 	}
 
-	// Вызываем внешний API для проверки
-	checkResp, err := s.client.CheckGroups(ctx, snils, socialGroups)
+	// Вызываем внешний API для проверки. ID запроса попадает в журнал переходов статусов групп
+	requestID := uuid.NewString()
+	checkResp, err := s.client.CheckGroups(ctx, requestID, snils, socialGroups)
 	if err != nil {
-		// Если произошла ошибка при проверке, помечаем все группы как отклонённые с сообщением об ошибке
-		now := time.Now()
-		updatedGroups := make(domain.UserGroupList, 0, len(user.GroupType))
-
-		for _, userGroup := range user.GroupType {
-			// Проверяем, входит ли эта группа в список на проверку
-			shouldUpdate := false
-			for _, gt := range groupTypes {
-				if string(userGroup.Type) == gt {
-					shouldUpdate = true
-					break
-				}
-			}
+		// Временную ошибку asynq повторит с растущей задержкой, группы остаются в ожидании.
+		// После последней попытки или при ошибке, которую повтор не исправит, ожидающие группы
+		// получают статус failed: ответа о принадлежности к группе нет, отклонять их нельзя
+		transient := socialgroupchecker.IsTransient(err)
+		if transient && !isLastAttempt(ctx) {
+			return fmt.Errorf("check groups failed, will retry: %w", err)
+		}
+
+		message := checkFailedMessage
+		if transient {
+			message = checkUnavailableMessage
+		}
 
-			if shouldUpdate && userGroup.Status == domain.VerificationStatusPending {
-				userGroup.Status = domain.VerificationStatusRejected
-				userGroup.RejectedAt = &now
-				userGroup.ErrorMessage = fmt.Sprintf("Ошибка проверки: %v", err)
+		updatedGroups := make(domain.UserGroupList, 0, len(current))
+		for _, userGroup := range current {
+			if slices.Contains(groupTypes, string(userGroup.Type)) && userGroup.Status == domain.VerificationStatusPending {
+				userGroup.Status = domain.VerificationStatusFailed
+				userGroup.ErrorMessage = message
 			}
 			updatedGroups = append(updatedGroups, userGroup)
 		}
 
-		if err := s.services.Users.UpdateUserGroups(ctx, userID, updatedGroups); err != nil {
-			return fmt.Errorf("update user groups with error status failed: %w", err)
+		update := domain.UserGroupUpdate{
+			Source:            domain.UserGroupEventSourceChecker,
+			ExternalRequestID: requestID,
+			Payload:           checkErrorPayload(err),
 		}
-		return fmt.Errorf("check groups failed: %w", err)
+		if err := save(updatedGroups, update); err != nil {
+			return fmt.Errorf("update groups with error status failed: %w", err)
+		}
+
+		logger.Warn("social group check failed", append(logFields,
+			zap.Strings("groups", groupTypes),
+			zap.Bool("transient", transient),
+			zap.Error(err))...)
+
+		return fmt.Errorf("check groups failed: %w: %w", err, asynq.SkipRetry)
 	}
 
 	// Обновляем статус групп на основе ответа
 	now := time.Now()
-	updatedGroups := make(domain.UserGroupList, 0, len(user.GroupType))
+	updatedGroups := make(domain.UserGroupList, 0, len(current))
 
-	for _, userGroup := range user.GroupType {
+	for _, userGroup := range current {
 		// Ищем результат проверки для этой группы
 		for _, result := range checkResp.Results {
 			if string(userGroup.Type) == string(result.Group) {
-				if result.Status == socialgroupchecker.StatusConfirmed {
+				switch {
+				case result.Status == socialgroupchecker.StatusConfirmed:
 					userGroup.Status = domain.VerificationStatusVerified
 					userGroup.VerifiedAt = &now
-					// Установим срок действия через год
-					expiresAt := now.AddDate(1, 0, 0)
+					expiresAt := now.Add(s.config.ValidityPeriod)
 					userGroup.ExpiresAt = &expiresAt
+					userGroup.ExternalID = ""
 					userGroup.ErrorMessage = ""
-				} else {
+				case userGroup.VerifiedByDocument() && userGroup.IsVerified(now):
+					// Ответ реестра не отменяет действующее решение модератора: реестр этих людей и не находил
+				default:
 					userGroup.Status = domain.VerificationStatusRejected
 					userGroup.RejectedAt = &now
 					userGroup.ErrorMessage = ""
@@ -98,10 +173,172 @@ func (s *socialGroupChecker) CheckAndUpdateUserGroups(ctx context.Context, userI
 		updatedGroups = append(updatedGroups, userGroup)
 	}
 
-	// Сохраняем обновленные группы в БД
-	if err := s.services.Users.UpdateUserGroups(ctx, userID, updatedGroups); err != nil {
-		return fmt.Errorf("update user groups failed: %w", err)
+	// Сохраняем обновленные группы в БД вместе с ответом сервиса
+	update := domain.UserGroupUpdate{
+		Source:            domain.UserGroupEventSourceChecker,
+		ExternalRequestID: requestID,
+	}
+	if payload, err := json.Marshal(checkResp); err == nil {
+		update.Payload = payload
+	}
+	if err := save(updatedGroups, update); err != nil {
+		return fmt.Errorf("update groups failed: %w", err)
 	}
 
 	return nil
 }
+
+// ExpireUserGroups переводит в expired группы, срок подтверждения которых прошел, и уведомляет об этом
+// пользователей. Группам, которые истекают в ближайшие ReverifyBefore, заранее запускается повторная проверка
+func (s *socialGroupChecker) ExpireUserGroups(ctx context.Context) error {
+	now := time.Now()
+	afterID := uuid.Nil
+
+	for {
+		users, err := s.services.Users.GetWithVerifiedGroups(ctx, afterID, expiryBatchSize)
+		if err != nil {
+			return fmt.Errorf("get users with verified groups failed: %w", err)
+		}
+
+		for i := range users {
+			// Ошибка у одного пользователя не должна останавливать обработку остальных
+			if err := s.expireGroups(ctx, &users[i], now); err != nil {
+				logger.Error("expire user groups failed", zap.String("user_id", users[i].ID.String()), zap.Error(err))
+			}
+		}
+
+		if len(users) < expiryBatchSize {
+			break
+		}
+		afterID = users[len(users)-1].ID
+	}
+
+	return s.expireHouseholdMemberGroups(ctx, now)
+}
+
+// expireHouseholdMemberGroups переводит в expired истекшие группы членов семьи и заранее запускает
+// их повторную проверку. Письма не отправляются: пользователь видит статусы в списке членов семьи
+func (s *socialGroupChecker) expireHouseholdMemberGroups(ctx context.Context, now time.Time) error {
+	afterID := uuid.Nil
+
+	for {
+		members, err := s.services.Household.GetWithVerifiedGroups(ctx, afterID, expiryBatchSize)
+		if err != nil {
+			return fmt.Errorf("get household members with verified groups failed: %w", err)
+		}
+
+		for i := range members {
+			member := &members[i]
+			expired, reverify := expireVerifiedGroups(member.GroupType, now, s.config.ReverifyBefore)
+
+			if len(expired) > 0 {
+				update := domain.UserGroupUpdate{Source: domain.UserGroupEventSourceExpiry}
+				if err := s.services.Household.UpdateGroups(ctx, member.ID, member.GroupType, update); err != nil {
+					logger.Error("expire household member groups failed", zap.String("member_id", member.ID.String()), zap.Error(err))
+					continue
+				}
+
+				logger.Info("household member groups expired",
+					zap.String("user_id", member.UserID.String()),
+					zap.String("member_id", member.ID.String()),
+					zap.Strings("groups", expired))
+			}
+
+			for _, group := range reverify {
+				taskID := "reverifyHouseholdMemberGroup:" + member.ID.String() + ":" + string(group.Type) + ":" + strconv.FormatInt(group.ExpiresAt.Unix(), 10)
+				enqueueTask(ctx, func() (*asynq.Task, error) {
+					return task.NewCheckHouseholdMemberGroupTask(member.UserID, member.ID, member.SNILS, []string{string(group.Type)},
+						asynq.TaskID(taskID),
+						asynq.Retention(s.config.ReverifyBefore),
+					)
+				})
+			}
+		}
+
+		if len(members) < expiryBatchSize {
+			return nil
+		}
+		afterID = members[len(members)-1].ID
+	}
+}
+
+func (s *socialGroupChecker) expireGroups(ctx context.Context, user *domain.User, now time.Time) error {
+	expired, reverify := expireVerifiedGroups(user.GroupType, now, s.config.ReverifyBefore)
+
+	if len(expired) > 0 {
+		update := domain.UserGroupUpdate{Source: domain.UserGroupEventSourceExpiry}
+		if err := s.services.Users.UpdateUserGroups(ctx, user.ID, user.GroupType, update); err != nil {
+			return fmt.Errorf("update user groups failed: %w", err)
+		}
+
+		logger.Info("user groups expired", zap.String("user_id", user.ID.String()), zap.Strings("groups", expired))
+
+		if user.Email.Valid && user.Email.String != "" {
+			enqueueTask(ctx, func() (*asynq.Task, error) {
+				return task.NewSendGroupExpiredEmailTask(user.Email.String, expired)
+			})
+		}
+	}
+
+	if user.SNILS.Valid && user.SNILS.String != "" {
+		for _, group := range reverify {
+			// Одна повторная проверка на каждый срок подтверждения: задача с тем же TaskID
+			// хранится после выполнения ReverifyBefore и повторно не ставится
+			taskID := "reverifySocialGroup:" + user.ID.String() + ":" + string(group.Type) + ":" + strconv.FormatInt(group.ExpiresAt.Unix(), 10)
+			enqueueTask(ctx, func() (*asynq.Task, error) {
+				return task.NewCheckSocialGroupTask(user.ID, user.SNILS.String, []string{string(group.Type)},
+					asynq.TaskID(taskID),
+					asynq.Retention(s.config.ReverifyBefore),
+				)
+			})
+		}
+	}
+
+	return nil
+}
+
+// expireVerifiedGroups переводит в expired подтвержденные группы с прошедшим сроком прямо в groups.
+// Возвращает типы истекших групп и группы, которые истекают в ближайшие reverifyBefore.
+// Группы, подтвержденные документом, в реестр повторно не отправляются: реестр их не нашел при первой проверке.
+// Они просто истекают, после этого пользователь загружает документ заново
+func expireVerifiedGroups(groups domain.UserGroupList, now time.Time, reverifyBefore time.Duration) ([]string, []domain.UserGroup) {
+	var expired []string
+	var reverify []domain.UserGroup
+
+	for i := range groups {
+		group := &groups[i]
+		if group.Status != domain.VerificationStatusVerified || group.ExpiresAt == nil {
+			continue
+		}
+
+		switch {
+		case !now.Before(*group.ExpiresAt):
+			group.Status = domain.VerificationStatusExpired
+			expired = append(expired, string(group.Type))
+		case group.ExpiresAt.Sub(now) <= reverifyBefore && !group.VerifiedByDocument():
+			reverify = append(reverify, *group)
+		}
+	}
+
+	return expired, reverify
+}
+
+// checkErrorPayload - ошибка проверки для журнала переходов статусов групп
+func checkErrorPayload(checkErr error) json.RawMessage {
+	payload, err := json.Marshal(map[string]string{"error": checkErr.Error()})
+	if err != nil {
+		return nil
+	}
+	return payload
+}
+
+// isLastAttempt - текущая попытка выполнения задачи последняя, после ошибки asynq ее больше не повторит
+func isLastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return true
+	}
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+	return retried >= maxRetry
+}
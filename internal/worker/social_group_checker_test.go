@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vibe-gaming/backend/internal/domain"
+)
+
+func TestExpireVerifiedGroups(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		value := now.Add(d)
+		return &value
+	}
+
+	groups := domain.UserGroupList{
+		{Type: domain.UserGroupPensioners, Status: domain.VerificationStatusVerified, ExpiresAt: at(-time.Hour)},
+		{Type: domain.UserGroupDisabled, Status: domain.VerificationStatusVerified, ExpiresAt: at(24 * time.Hour)},
+		{Type: domain.UserGroupStudents, Status: domain.VerificationStatusVerified, ExpiresAt: at(24 * time.Hour),
+			ExternalID: domain.DocumentExternalID(uuid.New())},
+		{Type: domain.UserGroupLargeFamilies, Status: domain.VerificationStatusVerified, ExpiresAt: at(-time.Minute),
+			ExternalID: domain.DocumentExternalID(uuid.New())},
+		{Type: domain.UserGroupLowIncome, Status: domain.VerificationStatusVerified, ExpiresAt: at(30 * 24 * time.Hour)},
+		{Type: domain.UserGroupVeterans, Status: domain.VerificationStatusRejected, ExpiresAt: at(-time.Hour)},
+	}
+
+	expired, reverify := expireVerifiedGroups(groups, now, 7*24*time.Hour)
+
+	wantExpired := []string{string(domain.UserGroupPensioners), string(domain.UserGroupLargeFamilies)}
+	if !slices.Equal(expired, wantExpired) {
+		t.Errorf("expired = %v, want %v", expired, wantExpired)
+	}
+
+	// Группа, подтвержденная документом, в реестр повторно не отправляется
+	if len(reverify) != 1 || reverify[0].Type != domain.UserGroupDisabled {
+		t.Errorf("reverify = %+v, want only %s", reverify, domain.UserGroupDisabled)
+	}
+
+	for _, group := range groups {
+		wantStatus := domain.VerificationStatusVerified
+		switch group.Type {
+		case domain.UserGroupPensioners, domain.UserGroupLargeFamilies:
+			wantStatus = domain.VerificationStatusExpired
+		case domain.UserGroupVeterans:
+			wantStatus = domain.VerificationStatusRejected
+		}
+		if group.Status != wantStatus {
+			t.Errorf("group %s status = %s, want %s", group.Type, group.Status, wantStatus)
+		}
+	}
+}
@@ -2,40 +2,89 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 	"github.com/vibe-gaming/backend/internal/config"
+	"github.com/vibe-gaming/backend/internal/queue/client"
 	"github.com/vibe-gaming/backend/internal/service"
 	socialgroupchecker "github.com/vibe-gaming/backend/internal/service/social_group_checker"
 	emailProvider "github.com/vibe-gaming/backend/pkg/email"
+	"github.com/vibe-gaming/backend/pkg/logger"
+	smsProvider "github.com/vibe-gaming/backend/pkg/sms"
+	"go.uber.org/zap"
 )
 
 type Workers struct {
 	EmailSender        EmailSender
+	SMSSender          SMSSender
 	SocialGroupChecker SocialGroupChecker
+	BenefitPublisher   BenefitPublisher
+	BenefitLifecycle   BenefitLifecycle
 }
 
 type Deps struct {
 	Redis                    redis.UniversalClient
 	Services                 *service.Services
 	EmailProvider            emailProvider.Sender
+	SMSProvider              smsProvider.Sender
 	Config                   *config.Config
 	SocialGroupCheckerClient *socialgroupchecker.Client
 }
 
 type EmailSender interface {
 	SendUserVerificationEmail(ctx context.Context, email string, verificationCode string) error
+	SendGroupExpiredEmail(ctx context.Context, email string, groups []string) error
+	SendBenefitEndingEmail(ctx context.Context, email string, title string, validTo time.Time) error
+}
+
+type SMSSender interface {
+	SendVerificationSMS(ctx context.Context, phone string, verificationCode string) error
 }
 
 type SocialGroupChecker interface {
-	CheckGroups(ctx context.Context, snils string, groups []socialgroupchecker.SocialGroup) (*socialgroupchecker.CheckResponse, error)
+	CheckGroups(ctx context.Context, requestID string, snils string, groups []socialgroupchecker.SocialGroup) (*socialgroupchecker.CheckResponse, error)
 	CheckAndUpdateUserGroups(ctx context.Context, userID uuid.UUID, snils string, groupTypes []string) error
+	CheckAndUpdateHouseholdMemberGroups(ctx context.Context, memberID uuid.UUID, snils string, groupTypes []string) error
+	ExpireUserGroups(ctx context.Context) error
+}
+
+type BenefitPublisher interface {
+	PublishScheduled(ctx context.Context) error
+}
+
+type BenefitLifecycle interface {
+	ProcessExpiry(ctx context.Context) error
 }
 
 func NewWorkers(deps Deps) *Workers {
 	return &Workers{
 		EmailSender:        newEmailSender(deps.EmailProvider, deps.Config.Email),
-		SocialGroupChecker: newSocialGroupChecker(deps.SocialGroupCheckerClient, deps.Services),
+		SMSSender:          newSMSSender(deps.SMSProvider),
+		SocialGroupChecker: newSocialGroupChecker(deps.SocialGroupCheckerClient, deps.Services, deps.Config.SocialGroupChecker),
+		BenefitPublisher:   newBenefitPublisher(deps.Services),
+		BenefitLifecycle:   newBenefitLifecycle(deps.Services, deps.Config.Benefits),
+	}
+}
+
+// enqueueTask ставит задачу в очередь. Ошибки только логируются, чтобы не прерывать обработку остальных пользователей.
+// Задача с уже занятым TaskID не ставится повторно, это не ошибка
+func enqueueTask(ctx context.Context, newTask func() (*asynq.Task, error)) {
+	asynqClient := client.GetClient(ctx)
+	if asynqClient == nil {
+		return
+	}
+
+	t, err := newTask()
+	if err != nil {
+		logger.Error("failed to create task", zap.Error(err))
+		return
+	}
+
+	if _, err := asynqClient.Enqueue(t); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		logger.Error("failed to enqueue task", zap.String("task", t.Type()), zap.Error(err))
 	}
 }
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE refresh_session
+    ADD COLUMN family_id BINARY(16) DEFAULT NULL COMMENT 'Цепочка ротации refresh токенов' AFTER user_id,
+    ADD COLUMN used_at DATETIME DEFAULT NULL COMMENT 'Когда токен был обменян на новый' AFTER expires_in,
+    MODIFY ip VARCHAR(45) NOT NULL COMMENT 'IP пользователя';
+
+UPDATE refresh_session SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE refresh_session
+    MODIFY family_id BINARY(16) NOT NULL COMMENT 'Цепочка ротации refresh токенов',
+    ADD UNIQUE KEY refresh_session_idx_refresh_token (refresh_token),
+    ADD KEY refresh_session_idx_family_id (family_id),
+    ADD KEY refresh_session_idx_user_id (user_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE refresh_session
+    DROP KEY refresh_session_idx_refresh_token,
+    DROP KEY refresh_session_idx_family_id,
+    DROP KEY refresh_session_idx_user_id,
+    DROP COLUMN family_id,
+    DROP COLUMN used_at,
+    MODIFY ip VARCHAR(15) NOT NULL COMMENT 'IP пользователя';
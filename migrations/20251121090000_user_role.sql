@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE user_role (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    role VARCHAR(32) NOT NULL COMMENT 'content_editor, organization_manager, administrator',
+    organization_id BINARY(16) DEFAULT NULL COMMENT 'Организация, которой управляет organization_manager',
+    scope_id BINARY(16) AS (IFNULL(organization_id, 0x00000000000000000000000000000000)) STORED COMMENT 'Для уникальности ролей без организации',
+    granted_by BINARY(16) DEFAULT NULL COMMENT 'Кто выдал роль, NULL - выдана при старте',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY user_role_idx_user_role_scope (user_id, role, scope_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE user_role;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE user MODIFY external_id VARCHAR(255) NULL COMMENT 'External ID пользователя (ESIA), у сотрудников может отсутствовать';
+
+CREATE TABLE staff_credential (
+    user_id BINARY(16) NOT NULL,
+    login VARCHAR(64) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    totp_secret VARCHAR(64) DEFAULT NULL COMMENT 'Секрет TOTP, до подтверждения totp_enabled = 0',
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_step BIGINT NOT NULL DEFAULT 0 COMMENT 'Шаг последнего принятого TOTP кода, защита от повторного использования',
+    failed_attempts INT NOT NULL DEFAULT 0,
+    locked_until DATETIME DEFAULT NULL,
+    last_login_at DATETIME DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id),
+    UNIQUE KEY staff_credential_idx_login (login)
+);
+
+CREATE TABLE staff_recovery_code (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY staff_recovery_code_idx_user_code (user_id, code_hash)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE staff_recovery_code;
+DROP TABLE staff_credential;
+DELETE FROM user WHERE external_id IS NULL;
+ALTER TABLE user MODIFY external_id VARCHAR(255) NOT NULL COMMENT 'External ID пользователя (ESIA)';
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE user ADD COLUMN deactivated_at DATETIME DEFAULT NULL COMMENT 'Когда пользователь заблокирован администратором';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE user DROP COLUMN deactivated_at;
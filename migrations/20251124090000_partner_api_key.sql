@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE partner_api_key (
+    id BINARY(16) NOT NULL,
+    organization_id BINARY(16) NOT NULL COMMENT 'Организация-партнер, от имени которой действует ключ',
+    name VARCHAR(100) NOT NULL COMMENT 'Название ключа, например система партнера, в которой он используется',
+    key_prefix VARCHAR(16) NOT NULL COMMENT 'Открытая часть ключа для поиска и отображения в списке',
+    key_hash CHAR(64) NOT NULL COMMENT 'SHA-256 полного ключа, сам ключ не хранится',
+    scopes JSON NOT NULL COMMENT 'Разрешенные действия: benefits:read, benefits:write, buildings:read, buildings:write',
+    created_by BINARY(16) DEFAULT NULL COMMENT 'Пользователь, выпустивший ключ',
+    expires_at DATETIME DEFAULT NULL COMMENT 'NULL - бессрочный ключ',
+    last_used_at DATETIME DEFAULT NULL,
+    last_used_ip VARCHAR(45) DEFAULT NULL,
+    revoked_at DATETIME DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY partner_api_key_idx_key_prefix (key_prefix),
+    KEY partner_api_key_idx_organization_id (organization_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE partner_api_key;
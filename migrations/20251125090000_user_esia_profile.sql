@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE user
+    ADD COLUMN birth_date DATE DEFAULT NULL COMMENT 'Дата рождения из ЕСИА',
+    ADD COLUMN gender VARCHAR(8) DEFAULT NULL COMMENT 'Пол из ЕСИА: M, F',
+    ADD COLUMN inn VARCHAR(12) DEFAULT NULL COMMENT 'ИНН из ЕСИА',
+    ADD COLUMN trusted BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Подтвержденная учетная запись ЕСИА',
+    ADD COLUMN citizenship VARCHAR(8) DEFAULT NULL COMMENT 'Гражданство из ЕСИА, код страны',
+    ADD COLUMN profile_synced_at DATETIME DEFAULT NULL COMMENT 'Когда профиль последний раз обновлен из ЕСИА';
+
+CREATE TABLE user_profile_change (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    field VARCHAR(32) NOT NULL COMMENT 'Поле профиля: first_name, snils, email, ...',
+    old_value TEXT DEFAULT NULL,
+    new_value TEXT DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY user_profile_change_idx_user_id (user_id, created_at)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE user_profile_change;
+
+ALTER TABLE user
+    DROP COLUMN birth_date,
+    DROP COLUMN gender,
+    DROP COLUMN inn,
+    DROP COLUMN trusted,
+    DROP COLUMN citizenship,
+    DROP COLUMN profile_synced_at;
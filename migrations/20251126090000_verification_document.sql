@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE verification_document (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    group_type VARCHAR(32) NOT NULL COMMENT 'Группа, которую подтверждает документ',
+    storage_key VARCHAR(255) NOT NULL COMMENT 'Ключ файла в хранилище',
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, approved, rejected',
+    reject_reason TEXT DEFAULT NULL,
+    reviewed_by BINARY(16) DEFAULT NULL COMMENT 'Модератор, принявший решение',
+    reviewed_at DATETIME DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY verification_document_idx_user_id (user_id, created_at),
+    KEY verification_document_idx_status (status, created_at)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE verification_document;
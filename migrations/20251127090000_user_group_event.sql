@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE user_group_event (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL,
+    group_type VARCHAR(32) NOT NULL,
+    from_status VARCHAR(16) DEFAULT NULL COMMENT 'NULL - группы не было в профиле',
+    to_status VARCHAR(16) DEFAULT NULL COMMENT 'NULL - группа убрана из профиля',
+    source VARCHAR(16) NOT NULL COMMENT 'user, checker, moderator, expiry, profile_sync',
+    actor_id BINARY(16) DEFAULT NULL COMMENT 'Модератор, принявший решение',
+    external_request_id VARCHAR(64) DEFAULT NULL COMMENT 'ID запроса к сервису проверки групп',
+    message TEXT DEFAULT NULL COMMENT 'Причина отказа или ошибка проверки',
+    payload JSON DEFAULT NULL COMMENT 'Ответ сервиса проверки или данные решения модератора',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY user_group_event_idx_user_id (user_id, created_at)
+) COMMENT 'Журнал переходов статусов групп пользователя, записи не изменяются и не удаляются';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE user_group_event;
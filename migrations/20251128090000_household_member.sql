@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE household_member (
+    id BINARY(16) NOT NULL,
+    user_id BINARY(16) NOT NULL COMMENT 'Пользователь, который добавил члена семьи',
+    relation VARCHAR(16) NOT NULL COMMENT 'spouse, child',
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    middle_name VARCHAR(255) DEFAULT NULL,
+    birth_date DATE DEFAULT NULL,
+    snils VARCHAR(20) NOT NULL COMMENT 'СНИЛС в формате 123-456-789 00',
+    group_type JSON DEFAULT NULL COMMENT 'Группы члена семьи со статусами проверки',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY household_member_idx_user_id_snils (user_id, snils)
+);
+
+ALTER TABLE user_group_event
+    ADD COLUMN household_member_id BINARY(16) DEFAULT NULL COMMENT 'Член семьи, NULL - группа самого пользователя' AFTER user_id;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE user_group_event DROP COLUMN household_member_id;
+
+DROP TABLE household_member;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE user_document
+    ADD COLUMN issued_at DATE DEFAULT NULL COMMENT 'Дата выдачи' AFTER document_number,
+    ADD COLUMN issued_by VARCHAR(255) DEFAULT NULL COMMENT 'Кем выдан' AFTER issued_at,
+    ADD COLUMN expires_at DATE DEFAULT NULL COMMENT 'Срок действия' AFTER issued_by,
+    ADD INDEX user_document_idx_user_id (user_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE user_document
+    DROP INDEX user_document_idx_user_id,
+    DROP COLUMN expires_at,
+    DROP COLUMN issued_by,
+    DROP COLUMN issued_at;
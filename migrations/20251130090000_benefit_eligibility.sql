@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE benefit
+    ADD COLUMN eligibility_rules JSON DEFAULT NULL COMMENT 'Условия получения: возраст, место проживания, доход, дети, инвалидность';
+
+ALTER TABLE user
+    ADD COLUMN monthly_income BIGINT DEFAULT NULL COMMENT 'Среднедушевой доход семьи в месяц, руб.',
+    ADD COLUMN children_count INT DEFAULT NULL COMMENT 'Количество детей',
+    ADD COLUMN disability_category VARCHAR(32) DEFAULT NULL COMMENT 'Категория инвалидности: none, group_1, group_2, group_3, child';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE user
+    DROP COLUMN disability_category,
+    DROP COLUMN children_count,
+    DROP COLUMN monthly_income;
+
+ALTER TABLE benefit
+    DROP COLUMN eligibility_rules;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE user
+    ADD COLUMN email_confirmed_at DATETIME DEFAULT NULL COMMENT 'Когда пользователь сменил email с подтверждением кодом',
+    ADD COLUMN phone_confirmed_at DATETIME DEFAULT NULL COMMENT 'Когда пользователь сменил телефон с подтверждением кодом';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE user
+    DROP COLUMN phone_confirmed_at,
+    DROP COLUMN email_confirmed_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE benefit_revision (
+    id BINARY(16) NOT NULL,
+    benefit_id BINARY(16) NOT NULL,
+    version INT NOT NULL COMMENT 'Номер версии льготы, начиная с 1',
+    action VARCHAR(16) NOT NULL COMMENT 'create, update, delete, restore',
+    restored_from INT DEFAULT NULL COMMENT 'Номер восстановленной версии для action=restore',
+    snapshot JSON NOT NULL COMMENT 'Редактируемые поля льготы после изменения',
+    author_user_id BINARY(16) DEFAULT NULL COMMENT 'Сотрудник, изменивший льготу',
+    author_api_key_id BINARY(16) DEFAULT NULL COMMENT 'API ключ партнера, через который изменена льгота',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY benefit_revision_idx_benefit_version (benefit_id, version)
+) COMMENT 'Версии льгот, записи не изменяются и не удаляются';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE benefit_revision;
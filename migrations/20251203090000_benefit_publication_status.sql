@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE benefit
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft' COMMENT 'draft, in_review, published, archived' AFTER organization_id,
+    ADD COLUMN publish_at DATETIME DEFAULT NULL COMMENT 'Когда опубликовать одобренную льготу, находящуюся на проверке' AFTER status,
+    ADD COLUMN unpublish_at DATETIME DEFAULT NULL COMMENT 'Когда перевести опубликованную льготу в архив' AFTER publish_at,
+    ADD INDEX benefit_idx_status (status);
+
+-- Льготы, созданные до появления статусов, уже видны гражданам
+UPDATE benefit SET status = 'published';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE benefit
+    DROP INDEX benefit_idx_status,
+    DROP COLUMN unpublish_at,
+    DROP COLUMN publish_at,
+    DROP COLUMN status;
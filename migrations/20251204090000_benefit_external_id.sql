@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE benefit
+    ADD COLUMN external_id VARCHAR(255) DEFAULT NULL COMMENT 'Идентификатор льготы во внешнем источнике, ключ повторного импорта' AFTER organization_id,
+    ADD UNIQUE KEY benefit_idx_external_id (external_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE benefit
+    DROP INDEX benefit_idx_external_id,
+    DROP COLUMN external_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Документы-заглушки, которые раньше создавались каждому пользователю при первом входе и через add-mock-documents.
+-- Из-за них пользователь не может добавить свой паспорт и СНИЛС: документ каждого типа хранится один
+DELETE FROM user_document
+WHERE (document_type = 'passport' AND document_number = CONCAT('9800 123456', CHAR(10), ' выдан МВД по РС(Я) в г. Якутске, 01.01.2014, 140-002'))
+   OR (document_type = 'snils' AND document_number = '1234567890')
+   OR (document_type = 'registration' AND document_number = 'Республика Саха (Якутия), Якутск, ул. Петра-Алексеева, д. 100, кв.100');
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+-- Удаленные заглушки не восстанавливаются
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE household_member
+    ADD COLUMN relation_status VARCHAR(16) NOT NULL DEFAULT 'unconfirmed' COMMENT 'unconfirmed, pending, verified, rejected' AFTER relation,
+    ADD COLUMN relation_verified_at DATETIME DEFAULT NULL COMMENT 'Когда модератор подтвердил родство' AFTER relation_status,
+    ADD KEY household_member_idx_snils (snils);
+
+ALTER TABLE verification_document
+    ADD COLUMN household_member_id BINARY(16) DEFAULT NULL COMMENT 'Член семьи, родство с которым подтверждает документ' AFTER user_id,
+    MODIFY COLUMN group_type VARCHAR(32) DEFAULT NULL COMMENT 'Группа, которую подтверждает документ, NULL - документ о родстве';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DELETE FROM verification_document WHERE household_member_id IS NOT NULL;
+
+ALTER TABLE verification_document
+    DROP COLUMN household_member_id,
+    MODIFY COLUMN group_type VARCHAR(32) NOT NULL COMMENT 'Группа, которую подтверждает документ';
+
+ALTER TABLE household_member
+    DROP KEY household_member_idx_snils,
+    DROP COLUMN relation_verified_at,
+    DROP COLUMN relation_status;
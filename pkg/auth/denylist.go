@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisRevokedTokenPrefix   = "jwt:revoked:jti:"
+	redisRevokedSessionPrefix = "jwt:revoked:sid:"
+	redisRevokedUserPrefix    = "jwt:revoked:user:"
+)
+
+// Denylist - список отозванных access токенов. Записи живут не дольше access токенов,
+// после этого отозванные токены и так не проходят проверку срока
+type Denylist interface {
+	// RevokeToken отзывает один токен по jti до момента его истечения
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// RevokeSession отзывает все access токены, выпущенные в рамках сессии (claim sid)
+	RevokeSession(ctx context.Context, sessionID string) error
+	// RevokeUser отзывает все access токены пользователя, выпущенные раньше текущей секунды.
+	// Токены, выпущенные в ту же секунду, остаются действительными, см. userRevokedBefore
+	RevokeUser(ctx context.Context, userID string) error
+	IsRevoked(ctx context.Context, claims *Claims) (bool, error)
+}
+
+type redisDenylist struct {
+	client         redis.UniversalClient
+	accessTokenTTL time.Duration
+}
+
+// NewRedisDenylist создает список отозванных токенов в Redis. accessTokenTTL - время жизни access токенов,
+// столько хранятся записи об отзыве сессий и пользователей
+func NewRedisDenylist(client redis.UniversalClient, accessTokenTTL time.Duration) Denylist {
+	return &redisDenylist{
+		client:         client,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
+func (d *redisDenylist) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if tokenID == "" || ttl <= 0 {
+		return nil
+	}
+
+	if err := d.client.Set(ctx, redisRevokedTokenPrefix+tokenID, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("redis revoke token: %w", err)
+	}
+
+	return nil
+}
+
+func (d *redisDenylist) RevokeSession(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+
+	if err := d.client.Set(ctx, redisRevokedSessionPrefix+sessionID, 1, d.accessTokenTTL).Err(); err != nil {
+		return fmt.Errorf("redis revoke session tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (d *redisDenylist) RevokeUser(ctx context.Context, userID string) error {
+	if err := d.client.Set(ctx, redisRevokedUserPrefix+userID, userRevokedBefore(time.Now()), d.accessTokenTTL).Err(); err != nil {
+		return fmt.Errorf("redis revoke user tokens: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked проверяет jti, sid и момент отзыва всех токенов пользователя. Ключи могут лежать
+// в разных слотах кластера, поэтому читаются пайплайном, а не MGET
+func (d *redisDenylist) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	pipe := d.client.Pipeline()
+
+	var tokenCmd, sessionCmd *redis.IntCmd
+	if claims.ID != "" {
+		tokenCmd = pipe.Exists(ctx, redisRevokedTokenPrefix+claims.ID)
+	}
+	if claims.SessionID != "" {
+		sessionCmd = pipe.Exists(ctx, redisRevokedSessionPrefix+claims.SessionID)
+	}
+	userCmd := pipe.Get(ctx, redisRevokedUserPrefix+claims.Subject)
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return false, fmt.Errorf("redis check revoked token: %w", err)
+	}
+
+	if tokenCmd != nil && tokenCmd.Val() > 0 {
+		return true, nil
+	}
+	if sessionCmd != nil && sessionCmd.Val() > 0 {
+		return true, nil
+	}
+
+	value, err := userCmd.Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("redis get user revocation: %w", err)
+	}
+
+	revokedAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("parse user revocation time: %w", err)
+	}
+
+	return issuedBeforeRevocation(claims.IssuedAt, revokedAt), nil
+}
+
+func issuedBeforeRevocation(issuedAt *jwt.NumericDate, revokedBefore int64) bool {
+	// Токены без iat выпущены до появления отзыва и считаются отозванными
+	if issuedAt == nil {
+		return true
+	}
+
+	return issuedAt.Unix() <= revokedBefore
+}
+
+// userRevokedBefore - последняя секунда, токены которой отзываются вместе с пользователем.
+// iat хранится с точностью до секунды, поэтому токен, выпущенный в секунду отзыва, нельзя отличить
+// от выпущенного до отзыва. Такие токены остаются действительными: иначе пользователь, который вышел
+// со всех устройств и сразу вошел снова, получает 401 на новый токен. Токены старых сессий, выпущенные
+// меньше чем за секунду до отзыва, отклоняются по sid и отозванным refresh токенам
+func userRevokedBefore(now time.Time) int64 {
+	return now.Add(-time.Second).Unix()
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestUserRevocation(t *testing.T) {
+	revokedAt := time.Date(2025, 6, 1, 12, 0, 10, 500_000_000, time.UTC)
+	cutoff := userRevokedBefore(revokedAt)
+
+	at := func(d time.Duration) *time.Time {
+		value := revokedAt.Add(d)
+		return &value
+	}
+
+	tests := []struct {
+		name        string
+		issuedAt    *time.Time
+		wantRevoked bool
+	}{
+		{name: "issued a minute before", issuedAt: at(-time.Minute), wantRevoked: true},
+		{name: "issued in the previous second", issuedAt: at(-time.Second), wantRevoked: true},
+		{name: "issued in the same second after revocation", issuedAt: at(300 * time.Millisecond)},
+		{name: "issued after revocation", issuedAt: at(time.Second)},
+		{name: "without iat", wantRevoked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// iat в токене округляется до секунды так же, как при выпуске
+			var iat *jwt.NumericDate
+			if tt.issuedAt != nil {
+				iat = jwt.NewNumericDate(*tt.issuedAt)
+			}
+			if revoked := issuedBeforeRevocation(iat, cutoff); revoked != tt.wantRevoked {
+				t.Errorf("revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	SigningMethodHS256 = "HS256"
+	SigningMethodRS256 = "RS256"
+	SigningMethodEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+)
+
+// SigningKey - ключ подписи access токенов. Хранится вместе с приватной частью,
+// чтобы все реплики сервиса подписывали и проверяли токены одним набором ключей
+type SigningKey struct {
+	ID        string    `json:"kid"`
+	Algorithm string    `json:"alg"`
+	Private   string    `json:"private"` // PKCS#8 в PEM
+	CreatedAt time.Time `json:"created_at"`
+	// ActiveFrom - момент, с которого ключ используется для подписи. До этого ключ уже опубликован в JWKS,
+	// чтобы другие сервисы успели его получить
+	ActiveFrom time.Time `json:"active_from"`
+
+	privateKey crypto.Signer
+}
+
+// KeyStore - общее для всех реплик хранилище ключей подписи
+type KeyStore interface {
+	List(ctx context.Context) ([]SigningKey, error)
+	Add(ctx context.Context, key SigningKey) error
+	Delete(ctx context.Context, kid string) error
+	// TryLock захватывает блокировку ротации, чтобы ключ выпускала только одна реплика
+	TryLock(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+// JWK - публичный ключ в формате RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// generateSigningKey создает новый ключ для алгоритма alg
+func generateSigningKey(alg string, activeFrom time.Time) (*SigningKey, error) {
+	var (
+		private crypto.Signer
+		err     error
+	)
+
+	switch alg {
+	case SigningMethodRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case SigningMethodEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing method %s", alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generate %s key: %w", alg, err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key: %w", err)
+	}
+
+	kid, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate kid: %w", err)
+	}
+
+	return &SigningKey{
+		ID:         kid.String(),
+		Algorithm:  alg,
+		Private:    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:  time.Now(),
+		ActiveFrom: activeFrom,
+		privateKey: private,
+	}, nil
+}
+
+// signer возвращает приватный ключ, разбирая PEM при первом обращении
+func (k *SigningKey) signer() (crypto.Signer, error) {
+	if k.privateKey != nil {
+		return k.privateKey, nil
+	}
+
+	block, _ := pem.Decode([]byte(k.Private))
+	if block == nil {
+		return nil, errors.New("invalid private key pem")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key is not a signer")
+	}
+	k.privateKey = signer
+
+	return signer, nil
+}
+
+func (k *SigningKey) signingMethod() jwt.SigningMethod {
+	if k.Algorithm == SigningMethodEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
+
+func (k *SigningKey) jwk() (*JWK, error) {
+	signer, err := k.signer()
+	if err != nil {
+		return nil, err
+	}
+
+	jwk := &JWK{
+		Kid: k.ID,
+		Use: "sig",
+		Alg: k.Algorithm,
+	}
+
+	switch public := signer.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	return jwk, nil
+}
+
+// sortKeys упорядочивает ключи по моменту активации
+func sortKeys(keys []SigningKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ActiveFrom.Before(keys[j].ActiveFrom)
+	})
+}
+
+type memoryKeyStore struct {
+	sync.Mutex
+
+	keys     map[string]SigningKey
+	lockedTo time.Time
+}
+
+// NewMemoryKeyStore создает хранилище ключей в памяти процесса. Подходит только для одной реплики
+func NewMemoryKeyStore() KeyStore {
+	return &memoryKeyStore{
+		keys: make(map[string]SigningKey),
+	}
+}
+
+func (s *memoryKeyStore) List(_ context.Context) ([]SigningKey, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	keys := make([]SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (s *memoryKeyStore) Add(_ context.Context, key SigningKey) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.keys[key.ID] = key
+
+	return nil
+}
+
+func (s *memoryKeyStore) Delete(_ context.Context, kid string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.keys, kid)
+
+	return nil
+}
+
+func (s *memoryKeyStore) TryLock(_ context.Context, ttl time.Duration) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if time.Now().Before(s.lockedTo) {
+		return false, nil
+	}
+	s.lockedTo = time.Now().Add(ttl)
+
+	return true, nil
+}
@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vibe-gaming/backend/internal/config"
@@ -15,23 +17,57 @@ import (
 var ErrAccessTokenExpired = errors.New("token has invalid claims: token is expired")
 
 type TokenManager interface {
-	NewJWT(userID *uuid.UUID) (string, time.Duration, error)
-	Parse(accessToken string) (string, error)
+	NewJWT(userID *uuid.UUID, sessionID *uuid.UUID, roles []RoleClaim) (string, time.Duration, error)
+	Parse(accessToken string) (*Claims, error)
 	NewRefreshToken() (uuid.UUID, time.Duration, error)
 	ValidateRefreshToken(refreshToken string) (*uuid.UUID, error)
+	JWKS() JWKS
+}
+
+// Claims - содержимое access токена
+type Claims struct {
+	jwt.RegisteredClaims
+	// SessionID - идентификатор входа (цепочки refresh токенов), в рамках которого выпущен токен
+	SessionID string `json:"sid,omitempty"`
+	// Roles - роли пользователя, кроме citizen, которая есть у всех
+	Roles []RoleClaim `json:"roles,omitempty"`
+}
+
+// RoleClaim - роль пользователя в access токене. OrganizationID заполнен для ролей, выданных на организацию
+type RoleClaim struct {
+	Role           string `json:"role"`
+	OrganizationID string `json:"org,omitempty"`
 }
 
 type Manager struct {
+	signingMethod   string
 	signingKey      string
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+
+	// Асимметричная подпись: ключи с kid, общие для всех реплик, и их ротация
+	keyStore         KeyStore
+	rotationInterval time.Duration
+	gracePeriod      time.Duration
+
+	keysMu       sync.RWMutex
+	keys         []SigningKey // по возрастанию ActiveFrom
+	keysLoadedAt time.Time
 }
 
-func NewManager(cfg config.JWTConfig) (*Manager, error) {
-	if cfg.SigningKey == "" {
-		return nil, errors.New("empty signing key")
-	}
+const (
+	// keysRefreshInterval - как часто реплика перечитывает ключи из хранилища и проверяет необходимость ротации
+	keysRefreshInterval = time.Minute
+	// keyPublishDelay - через сколько новый ключ начинает использоваться для подписи. До этого он уже
+	// отдается в JWKS, и все реплики и внешние сервисы успевают его получить
+	keyPublishDelay = 2 * keysRefreshInterval
+	// keysReloadMinDelay ограничивает перечитывание ключей при встрече неизвестного kid
+	keysReloadMinDelay = 10 * time.Second
+	keysStoreTimeout   = 5 * time.Second
+)
 
+// NewManager создает менеджер токенов. keyStore нужен только для асимметричной подписи (RS256, EdDSA)
+func NewManager(cfg config.JWTConfig, keyStore KeyStore) (*Manager, error) {
 	if cfg.AccessTokenTTL == 0 {
 		return nil, errors.New("empty access token ttl")
 	}
@@ -40,46 +76,308 @@ func NewManager(cfg config.JWTConfig) (*Manager, error) {
 		return nil, errors.New("empty refresh token ttl")
 	}
 
-	return &Manager{
-		signingKey:      cfg.SigningKey,
-		accessTokenTTL:  cfg.AccessTokenTTL,
-		refreshTokenTTL: cfg.RefreshTokenTTL,
-	}, nil
+	m := &Manager{
+		signingMethod:    cfg.SigningMethod,
+		signingKey:       cfg.SigningKey,
+		accessTokenTTL:   cfg.AccessTokenTTL,
+		refreshTokenTTL:  cfg.RefreshTokenTTL,
+		keyStore:         keyStore,
+		rotationInterval: cfg.KeyRotationInterval,
+		gracePeriod:      cfg.KeyGracePeriod,
+	}
+
+	switch cfg.SigningMethod {
+	case SigningMethodHS256:
+		if cfg.SigningKey == "" {
+			return nil, errors.New("empty signing key")
+		}
+		return m, nil
+	case SigningMethodRS256, SigningMethodEdDSA:
+	default:
+		return nil, fmt.Errorf("unsupported signing method %s", cfg.SigningMethod)
+	}
+
+	if keyStore == nil {
+		return nil, errors.New("empty key store")
+	}
+
+	if cfg.KeyRotationInterval <= keyPublishDelay {
+		return nil, errors.New("key rotation interval is too short")
+	}
+
+	// Старый ключ должен проверяться, пока не истекут все подписанные им токены
+	if cfg.KeyGracePeriod < cfg.AccessTokenTTL {
+		return nil, errors.New("key grace period must not be shorter than access token ttl")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), keysStoreTimeout)
+	defer cancel()
+
+	if err := m.reloadKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	// Первый запуск: ключей еще нет, выпускаем сразу активный ключ
+	if m.currentKey() == nil {
+		key, err := generateSigningKey(m.signingMethod, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := keyStore.Add(ctx, *key); err != nil {
+			return nil, err
+		}
+		if err := m.reloadKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
 }
 
-func (m *Manager) NewJWT(userID *uuid.UUID) (string, time.Duration, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenTTL)),
-		Subject:   userID.String(),
-	})
+func (m *Manager) NewJWT(userID *uuid.UUID, sessionID *uuid.UUID, roles []RoleClaim) (string, time.Duration, error) {
+	// jti позволяет отозвать конкретный токен до истечения его срока
+	tokenID, err := uuid.NewV7()
+	if err != nil {
+		return "", 0, fmt.Errorf("generate jti failed: %w", err)
+	}
+
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTokenTTL)),
+			Subject:   userID.String(),
+		},
+		Roles: roles,
+	}
+	if sessionID != nil {
+		claims.SessionID = sessionID.String()
+	}
+
+	if m.signingMethod == SigningMethodHS256 {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+		accessToken, err := token.SignedString([]byte(m.signingKey))
+		if err != nil {
+			return "", 0, errors.New("sign jwt failed")
+		}
 
-	accessToken, err := token.SignedString([]byte(m.signingKey))
+		return accessToken, m.accessTokenTTL, nil
+	}
+
+	key := m.currentKey()
+	if key == nil {
+		return "", 0, errors.New("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header["kid"] = key.ID
+
+	accessToken, err := token.SignedString(key.privateKey)
 	if err != nil {
-		return "", 0, errors.New("sign jwt failed")
+		return "", 0, fmt.Errorf("sign jwt failed: %w", err)
 	}
 
 	return accessToken, m.accessTokenTTL, nil
 }
 
-func (m *Manager) Parse(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
+func (m *Manager) Parse(accessToken string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, m.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("error get user claims from token")
+	}
+
+	return &claims, nil
+}
+
+func (m *Manager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if m.signingMethod == SigningMethodHS256 {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
 		return []byte(m.signingKey), nil
-	})
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token kid is empty")
+	}
+
+	key := m.findKey(kid)
+	if key == nil {
+		// Ключ мог быть выпущен другой репликой после последнего чтения хранилища
+		m.reloadKeysIfStale()
+		key = m.findKey(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %s", kid)
+	}
+
+	if token.Method.Alg() != key.signingMethod().Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.privateKey.Public(), nil
+}
+
+// JWKS возвращает публичные ключи для проверки access токенов другими сервисами.
+// При подписи HS256 набор пустой
+func (m *Manager) JWKS() JWKS {
+	m.keysMu.RLock()
+	defer m.keysMu.RUnlock()
+
+	set := JWKS{Keys: make([]JWK, 0, len(m.keys))}
+	for i := range m.keys {
+		jwk, err := m.keys[i].jwk()
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, *jwk)
+	}
+
+	return set
+}
+
+// RunKeyRotation периодически перечитывает ключи из хранилища, выпускает новый ключ по истечении
+// интервала ротации и удаляет старые ключи после grace периода. Блокируется до отмены ctx
+func (m *Manager) RunKeyRotation(ctx context.Context, onError func(error)) {
+	if m.signingMethod == SigningMethodHS256 {
+		return
+	}
+
+	ticker := time.NewTicker(keysRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.rotateKeys(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (m *Manager) rotateKeys(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, keysStoreTimeout)
+	defer cancel()
+
+	if err := m.reloadKeys(ctx); err != nil {
+		return err
+	}
+
+	locked, err := m.keyStore.TryLock(ctx, keysRefreshInterval/2)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	m.keysMu.RLock()
+	keys := make([]SigningKey, len(m.keys))
+	copy(keys, m.keys)
+	m.keysMu.RUnlock()
+
+	now := time.Now()
+
+	if len(keys) == 0 || keys[len(keys)-1].ActiveFrom.Add(m.rotationInterval).Before(now) {
+		key, err := generateSigningKey(m.signingMethod, now.Add(keyPublishDelay))
+		if err != nil {
+			return err
+		}
+		if err := m.keyStore.Add(ctx, *key); err != nil {
+			return err
+		}
+	}
+
+	// Ключ удаляется, когда следующий за ним ключ используется для подписи дольше grace периода
+	for i := 0; i < len(keys)-1; i++ {
+		if keys[i+1].ActiveFrom.Add(m.gracePeriod).Before(now) {
+			if err := m.keyStore.Delete(ctx, keys[i].ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return m.reloadKeys(ctx)
+}
+
+func (m *Manager) reloadKeys(ctx context.Context) error {
+	keys, err := m.keyStore.List(ctx)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("load signing keys: %w", err)
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", errors.New("error get user claims from token")
+	loaded := make([]SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if _, err := key.signer(); err != nil {
+			return fmt.Errorf("load signing key %s: %w", key.ID, err)
+		}
+		loaded = append(loaded, key)
+	}
+	sortKeys(loaded)
+
+	m.keysMu.Lock()
+	m.keys = loaded
+	m.keysLoadedAt = time.Now()
+	m.keysMu.Unlock()
+
+	return nil
+}
+
+func (m *Manager) reloadKeysIfStale() {
+	m.keysMu.RLock()
+	stale := time.Since(m.keysLoadedAt) > keysReloadMinDelay
+	m.keysMu.RUnlock()
+
+	if !stale {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), keysStoreTimeout)
+	defer cancel()
+
+	_ = m.reloadKeys(ctx)
+}
+
+// currentKey возвращает последний ключ, уже допущенный к подписи
+func (m *Manager) currentKey() *SigningKey {
+	m.keysMu.RLock()
+	defer m.keysMu.RUnlock()
+
+	now := time.Now()
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if !m.keys[i].ActiveFrom.After(now) {
+			key := m.keys[i]
+			return &key
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) findKey(kid string) *SigningKey {
+	m.keysMu.RLock()
+	defer m.keysMu.RUnlock()
+
+	for i := range m.keys {
+		if m.keys[i].ID == kid {
+			key := m.keys[i]
+			return &key
+		}
 	}
 
-	//nolint:forcetypeassert
-	return claims["sub"].(string), nil
+	return nil
 }
 
 func (m *Manager) NewRefreshToken() (uuid.UUID, time.Duration, error) {
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeysHash       = "jwt:signing_keys"
+	redisKeysRotateLock = "jwt:signing_keys:rotation_lock"
+
+	// encryptedKeyPrefix - версия формата зашифрованного ключа в Redis
+	encryptedKeyPrefix   = "v1:"
+	keyEncryptionKeySize = 32
+)
+
+type redisKeyStore struct {
+	client redis.UniversalClient
+	aead   cipher.AEAD
+}
+
+// NewRedisKeyStore создает хранилище ключей подписи в Redis: ключи лежат в hash, поле - kid.
+// Ключ целиком шифруется AES-256-GCM ключом kek (base64, 32 байта), kid подписывается как associated data,
+// поэтому чтение Redis не дает выпускать токены
+func NewRedisKeyStore(client redis.UniversalClient, kek string) (KeyStore, error) {
+	aead, err := newKeyEncryption(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisKeyStore{client: client, aead: aead}, nil
+}
+
+func newKeyEncryption(kek string) (cipher.AEAD, error) {
+	if kek == "" {
+		return nil, errors.New("empty key encryption key")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(kek)
+	if err != nil {
+		return nil, fmt.Errorf("decode key encryption key: %w", err)
+	}
+	if len(secret) != keyEncryptionKeySize {
+		return nil, fmt.Errorf("key encryption key must be %d bytes, got %d", keyEncryptionKeySize, len(secret))
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, fmt.Errorf("create key cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (s *redisKeyStore) List(ctx context.Context) ([]SigningKey, error) {
+	values, err := s.client.HGetAll(ctx, redisKeysHash).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get signing keys: %w", err)
+	}
+
+	keys := make([]SigningKey, 0, len(values))
+	for kid, value := range values {
+		// Ключи, сохраненные до шифрования, лежали в Redis открытыми и считаются скомпрометированными:
+		// они удаляются, менеджер выпустит новый ключ
+		if !strings.HasPrefix(value, encryptedKeyPrefix) {
+			if err := s.Delete(ctx, kid); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		key, err := s.decrypt(kid, value)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+func (s *redisKeyStore) Add(ctx context.Context, key SigningKey) error {
+	value, err := s.encrypt(key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.HSet(ctx, redisKeysHash, key.ID, value).Err(); err != nil {
+		return fmt.Errorf("redis add signing key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisKeyStore) Delete(ctx context.Context, kid string) error {
+	if err := s.client.HDel(ctx, redisKeysHash, kid).Err(); err != nil {
+		return fmt.Errorf("redis delete signing key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisKeyStore) TryLock(ctx context.Context, ttl time.Duration) (bool, error) {
+	ok, err := s.client.SetNX(ctx, redisKeysRotateLock, time.Now().Unix(), ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis lock signing keys rotation: %w", err)
+	}
+
+	return ok, nil
+}
+
+func (s *redisKeyStore) encrypt(key SigningKey) (string, error) {
+	plaintext, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("marshal signing key: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate signing key nonce: %w", err)
+	}
+
+	sealed := s.aead.Seal(nonce, nonce, plaintext, []byte(key.ID))
+
+	return encryptedKeyPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *redisKeyStore) decrypt(kid string, value string) (*SigningKey, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedKeyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("decode signing key %s: %w", kid, err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("decrypt signing key %s: value is too short", kid)
+	}
+
+	plaintext, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(kid))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt signing key %s: %w", kid, err)
+	}
+
+	var key SigningKey
+	if err := json.Unmarshal(plaintext, &key); err != nil {
+		return nil, fmt.Errorf("unmarshal signing key %s: %w", kid, err)
+	}
+
+	return &key, nil
+}
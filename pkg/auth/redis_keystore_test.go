@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestKEK(t *testing.T) string {
+	t.Helper()
+
+	secret := make([]byte, keyEncryptionKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate kek: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(secret)
+}
+
+func newTestRedisKeyStore(t *testing.T, kek string) *redisKeyStore {
+	t.Helper()
+
+	store, err := NewRedisKeyStore(nil, kek)
+	if err != nil {
+		t.Fatalf("NewRedisKeyStore() error = %v", err)
+	}
+	return store.(*redisKeyStore)
+}
+
+func TestRedisKeyStoreEncryption(t *testing.T) {
+	key, err := generateSigningKey(SigningMethodEdDSA, time.Now())
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	kek := newTestKEK(t)
+	store := newTestRedisKeyStore(t, kek)
+
+	value, err := store.encrypt(*key)
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+	if !strings.HasPrefix(value, encryptedKeyPrefix) {
+		t.Fatalf("encrypt() value = %q, want prefix %q", value, encryptedKeyPrefix)
+	}
+	if strings.Contains(value, "PRIVATE KEY") || strings.Contains(value, key.ID) {
+		t.Fatal("encrypt() value contains plaintext key")
+	}
+
+	t.Run("same kek", func(t *testing.T) {
+		decrypted, err := newTestRedisKeyStore(t, kek).decrypt(key.ID, value)
+		if err != nil {
+			t.Fatalf("decrypt() error = %v", err)
+		}
+		if decrypted.Private != key.Private || decrypted.Algorithm != key.Algorithm {
+			t.Fatal("decrypt() returned another key")
+		}
+	})
+
+	t.Run("other kek", func(t *testing.T) {
+		if _, err := newTestRedisKeyStore(t, newTestKEK(t)).decrypt(key.ID, value); err == nil {
+			t.Fatal("decrypt() with other kek succeeded")
+		}
+	})
+
+	t.Run("moved to other kid", func(t *testing.T) {
+		if _, err := store.decrypt("other-kid", value); err == nil {
+			t.Fatal("decrypt() under other kid succeeded")
+		}
+	})
+}
+
+func TestNewRedisKeyStoreInvalidKEK(t *testing.T) {
+	tests := map[string]string{
+		"empty":      "",
+		"not base64": "not base64!",
+		"too short":  base64.StdEncoding.EncodeToString([]byte("short")),
+	}
+
+	for name, kek := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRedisKeyStore(nil, kek); err == nil {
+				t.Fatal("NewRedisKeyStore() error = nil")
+			}
+		})
+	}
+}
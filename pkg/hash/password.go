@@ -2,12 +2,16 @@ package hash
 
 import (
 	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 )
 
 // PasswordHasher provides hashing logic to securely store passwords.
 type PasswordHasher interface {
 	Hash(password string) (string, error)
+	// Verify checks password against stored hash. needsRehash is true when the hash
+	// was created with an outdated algorithm or parameters and should be replaced.
+	Verify(password, hash string) (ok bool, needsRehash bool, err error)
 }
 
 // SHA256Hasher uses SHA256 to hash passwords with provided salt.
@@ -30,3 +34,13 @@ func (h *SHA256Hasher) Hash(password string) (string, error) {
 	//nolint:perfsprint
 	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
 }
+
+// Verify compares password with hash created by Hash.
+func (h *SHA256Hasher) Verify(password, hash string) (bool, bool, error) {
+	expected, err := h.Hash(password)
+	if err != nil {
+		return false, false, err
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(hash)) == 1, false, nil
+}
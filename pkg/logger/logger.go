@@ -69,6 +69,10 @@ func Debug(msg string, fields ...zap.Field) {
 	logger.Debug(msg, fields...)
 }
 
+func Warn(msg string, fields ...zap.Field) {
+	logger.Warn(msg, fields...)
+}
+
 func Error(msg string, fields ...zap.Field) {
 	logger.Error(msg, fields...)
 }
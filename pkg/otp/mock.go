@@ -12,3 +12,10 @@ func (m *MockGenerator) RandomSecret(length int) string {
 	//nolint:forcetypeassert
 	return args.Get(0).(string)
 }
+
+func (m *MockGenerator) RandomCode(digits int) (string, error) {
+	args := m.Called(digits)
+
+	//nolint:forcetypeassert
+	return args.Get(0).(string), args.Error(1)
+}
@@ -2,6 +2,7 @@ package pdf
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
 	"os"
 	"strings"
@@ -473,6 +474,29 @@ func (g *Generator) getCategoryText(cat domain.Category) string {
 
 // GenerateUserCertificatePDF генерирует PDF-документ удостоверения для любой социальной группы
 func (g *Generator) GenerateUserCertificatePDF(user *domain.User, groupType domain.GroupType) ([]byte, error) {
+	return g.generateCertificatePDF(user, groupType, "")
+}
+
+// GenerateHouseholdCertificatePDF генерирует удостоверение на члена семьи пользователя holder.
+// В удостоверении указывается, кем член семьи приходится пользователю
+func (g *Generator) GenerateHouseholdCertificatePDF(member *domain.HouseholdMember, holder *domain.User, groupType domain.GroupType) ([]byte, error) {
+	memberAsUser := &domain.User{
+		ID:         member.ID,
+		FirstName:  sql.NullString{String: member.FirstName, Valid: member.FirstName != ""},
+		LastName:   sql.NullString{String: member.LastName, Valid: member.LastName != ""},
+		MiddleName: member.MiddleName,
+		SNILS:      sql.NullString{String: member.SNILS, Valid: member.SNILS != ""},
+		GroupType:  member.GroupType,
+	}
+
+	holderName := strings.TrimSpace(strings.Join([]string{holder.LastName.String, holder.FirstName.String, holder.MiddleName.String}, " "))
+	note := fmt.Sprintf("Член семьи (%s) пользователя %s", member.Relation.Title(), holderName)
+
+	return g.generateCertificatePDF(memberAsUser, groupType, note)
+}
+
+// generateCertificatePDF генерирует удостоверение. note - необязательная строка под номером удостоверения
+func (g *Generator) generateCertificatePDF(user *domain.User, groupType domain.GroupType, note string) ([]byte, error) {
 	// Проверяем, загружен ли шрифт
 	if !g.hasFont {
 		return nil, fmt.Errorf("TTF font not loaded. Font should be at /app/fonts/DejaVuSans.ttf (production) or ./fonts/DejaVuSans.ttf (development)")
@@ -557,16 +581,24 @@ func (g *Generator) GenerateUserCertificatePDF(user *domain.User, groupType doma
 	g.pdf.Cell(nil, user.ID.String()[:8])
 	currentY += 50
 
+	// Кому принадлежит удостоверение, если оно выдано на члена семьи
+	if note != "" {
+		g.pdf.SetY(currentY - 20)
+		g.pdf.SetX(80)
+		g.pdf.Cell(nil, note)
+		currentY += 20
+	}
+
 	// Дата выдачи
 	g.pdf.SetY(currentY)
 	g.pdf.SetX(80)
 	g.pdf.Cell(nil, "Дата выдачи:")
 	g.pdf.SetX(200)
 
-	// Ищем подтвержденную группу пенсионеров
+	// Ищем подтвержденную группу, на которую выдается удостоверение
 	var issueDate time.Time
 	for _, group := range user.GroupType {
-		if group.Type == domain.UserGroupPensioners && group.Status == domain.VerificationStatusVerified {
+		if group.Type == groupType && group.Status == domain.VerificationStatusVerified {
 			if group.VerifiedAt != nil {
 				issueDate = *group.VerifiedAt
 			}
@@ -0,0 +1,24 @@
+package mock_sms
+
+import (
+	"github.com/vibe-gaming/backend/pkg/logger"
+	"github.com/vibe-gaming/backend/pkg/sms"
+	"go.uber.org/zap"
+)
+
+// LogSender не отправляет SMS, а пишет их в лог. Используется, пока не подключен SMS провайдер
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(input sms.SendSMSInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	logger.Info("sms sent", zap.String("to", input.To), zap.String("text", input.Text))
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package sms
+
+import (
+	"errors"
+	"regexp"
+)
+
+var phoneRegex = regexp.MustCompile(`^7\d{10}$`)
+
+type SendSMSInput struct {
+	// To - номер в формате 7XXXXXXXXXX
+	To   string
+	Text string
+}
+
+type Sender interface {
+	Send(input SendSMSInput) error
+}
+
+func (s *SendSMSInput) Validate() error {
+	if !phoneRegex.MatchString(s.To) {
+		return errors.New("invalid to phone number")
+	}
+
+	if s.Text == "" {
+		return errors.New("empty text")
+	}
+
+	return nil
+}
@@ -23,6 +23,14 @@ func RegisterGinValidator() {
 		if err != nil {
 			log.Fatal("register phonenumber validator failed")
 		}
+		err = v.RegisterValidation("snils", snilsValidator)
+		if err != nil {
+			log.Fatal("register snils validator failed")
+		}
+		err = v.RegisterValidation("passport", passportValidator)
+		if err != nil {
+			log.Fatal("register passport validator failed")
+		}
 	}
 }
 
@@ -35,3 +43,63 @@ var phoneNumberValidator validator.Func = func(fl validator.FieldLevel) bool {
 	}
 	return matched
 }
+
+var snilsValidator validator.Func = func(fl validator.FieldLevel) bool {
+	return IsValidSNILS(fl.Field().String())
+}
+
+var passportValidator validator.Func = func(fl validator.FieldLevel) bool {
+	return IsValidPassport(fl.Field().String())
+}
+
+// IsValidSNILS проверяет СНИЛС: 11 цифр и контрольное число. Пробелы и дефисы между цифрами допускаются
+func IsValidSNILS(snils string) bool {
+	digits, ok := onlyDigits(snils, 11)
+	if !ok {
+		return false
+	}
+
+	// Контрольное число проверяется только у номеров больше 001-001-998
+	number := 0
+	for _, d := range digits[:9] {
+		number = number*10 + d
+	}
+	if number <= 1001998 {
+		return true
+	}
+
+	sum := 0
+	for i, d := range digits[:9] {
+		sum += d * (9 - i)
+	}
+
+	checksum := sum % 101
+	if checksum == 100 {
+		checksum = 0
+	}
+
+	return checksum == digits[9]*10+digits[10]
+}
+
+// IsValidPassport проверяет серию и номер паспорта гражданина РФ: 4 цифры серии и 6 цифр номера.
+// Пробелы между цифрами допускаются: "9800 123456", "98 00 123456"
+func IsValidPassport(passport string) bool {
+	_, ok := onlyDigits(passport, 10)
+	return ok
+}
+
+// onlyDigits возвращает цифры строки, если их ровно n, а кроме них в строке только пробелы и дефисы
+func onlyDigits(s string, n int) ([]int, bool) {
+	digits := make([]int, 0, n)
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, int(c-'0'))
+		case c == ' ' || c == '-':
+		default:
+			return nil, false
+		}
+	}
+
+	return digits, len(digits) == n
+}
@@ -0,0 +1,60 @@
+package validator
+
+import "testing"
+
+func TestIsValidSNILS(t *testing.T) {
+	tests := []struct {
+		snils string
+		want  bool
+	}{
+		{snils: "112-233-445 95", want: true},
+		{snils: "11223344595", want: true},
+		{snils: "112 233 445 95", want: true},
+		{snils: "205-310-742 06", want: true},
+		{snils: "112-233-445 94", want: false},
+		// Контрольная сумма 100 дает контрольное число 00
+		{snils: "001-019-989 00", want: true},
+		{snils: "001-019-989 100", want: false},
+		// Номера до 001-001-998 выдавались без контрольного числа
+		{snils: "001-001-998 00", want: true},
+		{snils: "001-001-999 00", want: false},
+		{snils: "1122334459", want: false},
+		{snils: "112233445950", want: false},
+		{snils: "112-233-445_95", want: false},
+		{snils: "112-233-445 9a", want: false},
+		{snils: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.snils, func(t *testing.T) {
+			if got := IsValidSNILS(tt.snils); got != tt.want {
+				t.Errorf("IsValidSNILS(%q) = %v, want %v", tt.snils, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidPassport(t *testing.T) {
+	tests := []struct {
+		passport string
+		want     bool
+	}{
+		{passport: "9800 123456", want: true},
+		{passport: "98 00 123456", want: true},
+		{passport: "9800123456", want: true},
+		{passport: "9800-123456", want: true},
+		{passport: "9800 12345", want: false},
+		{passport: "9800 1234567", want: false},
+		{passport: "98OO 123456", want: false},
+		{passport: "9800\n123456", want: false},
+		{passport: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.passport, func(t *testing.T) {
+			if got := IsValidPassport(tt.passport); got != tt.want {
+				t.Errorf("IsValidPassport(%q) = %v, want %v", tt.passport, got, tt.want)
+			}
+		})
+	}
+}